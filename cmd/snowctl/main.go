@@ -1,29 +1,117 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"flag"
 	"fmt"
 	"os"
 
+	"github.com/costrouc/snowctl/internal/cli"
 	"github.com/costrouc/snowctl/internal/components"
+	"github.com/costrouc/snowctl/internal/config"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 )
 
+// defaultView is opened on startup unless configured
+const defaultView = "roles"
+
 func run() error {
+	args := os.Args[1:]
+	if cli.IsHelp(args) {
+		return cli.Run(context.Background(), nil, args, os.Stdout)
+	}
+
+	cfg, err := config.Read()
+	if err != nil {
+		return fmt.Errorf("reading snowctl configuration %w", err)
+	}
+
+	refreshInterval := components.DefaultRefreshInterval
+	if cfg.Refresh != nil {
+		refreshInterval = *cfg.Refresh
+	}
+
+	// flags override the configuration file and come before a subcommand
+	flags := flag.NewFlagSet("snowctl", flag.ContinueOnError)
+	flags.StringVar(&cfg.Connection, "connection", cfg.Connection, "connection to use, snowflake.NAME, snowsql.NAME or NAME")
+	flags.StringVar(&cfg.Role, "role", cfg.Role, "role to use instead of the one of the connection")
+	flags.StringVar(&cfg.Warehouse, "warehouse", cfg.Warehouse, "warehouse to use instead of the one of the connection")
+	flags.StringVar(&cfg.View, "view", cfg.View, "startup view as typed in the command bar, services DB.SCHEMA")
+	flags.StringVar(&cfg.Skin, "skin", cfg.Skin, "skin to use, default, light, terminal or the name of a skin file")
+	flags.BoolVar(&cfg.ReadOnly, "read-only", cfg.ReadOnly, "disable the actions changing objects of the account")
+	flags.DurationVar(&refreshInterval, "refresh", refreshInterval, "auto refresh interval of the views, 0 disables it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) > 0 && !cli.IsCommand(args) {
+		return fmt.Errorf("unknown command %s, see snowctl help", args[0])
+	}
+	if len(args) > 0 && !cli.NeedsConnection(args) {
+		return cli.Run(context.Background(), nil, args, os.Stdout)
+	}
+
 	cm, err := snowflake.NewConnectionManager()
 	if err != nil {
 		return fmt.Errorf("creating snowflake connection manager %w", err)
 	}
+	cm.SetSessionDefaults(cfg.Role, cfg.Warehouse)
+	err = cm.SetSafeguards(&snowflake.Safeguards{
+		Environments:        cfg.Environments,
+		Protected:           cfg.ProtectedEnvironments,
+		DenyDrop:            cfg.DenyDrop,
+		ReadOnly:            cfg.ReadOnly,
+		ReadOnlyConnections: cfg.ReadOnlyConnections,
+	})
+	if err != nil {
+		return fmt.Errorf("applying snowctl configuration %w", err)
+	}
 
-	err = cm.SetDefault()
+	if cfg.Connection != "" {
+		err = cm.SetClient(cfg.Connection)
+	} else {
+		err = cm.SetDefault()
+	}
 	if err != nil {
 		return fmt.Errorf("create client from connection manager %w", err)
 	}
 
+	ctx := context.Background()
+
+	if len(args) > 0 {
+		defer cm.Close()
+		return cli.Run(ctx, cm, args, os.Stdout)
+	}
+
+	// tview reads its styles when the primitives are created
+	skinsDir, err := config.SkinsDir()
+	if err != nil {
+		return err
+	}
+	theme, err := components.LoadTheme(cfg.Skin, skinsDir)
+	if err != nil {
+		return fmt.Errorf("loading skin %w", err)
+	}
+	components.SetTheme(theme)
+
 	applicationState := components.NewApplication(cm)
+	defer applicationState.Close()
+	applicationState.SetRefreshInterval(refreshInterval)
+	if err := configure(applicationState, cfg); err != nil {
+		return fmt.Errorf("applying snowctl configuration %w", err)
+	}
+	if err := applicationState.CheckBindings(ctx); err != nil {
+		return fmt.Errorf("checking key bindings\n%w", err)
+	}
 
-	ctx := context.Background()
-	applicationState.Push(ctx, components.NewRolesView(cm, &components.RolesOptions{}))
+	if err := applicationState.SetStartView(cmp.Or(cfg.View, defaultView)); err != nil {
+		return fmt.Errorf("parsing startup view %w", err)
+	}
+	if err := applicationState.Start(ctx); err != nil {
+		return fmt.Errorf("opening startup view %w", err)
+	}
 
 	if err := applicationState.Application.Run(); err != nil {
 		return err
@@ -32,6 +120,42 @@ func run() error {
 	return nil
 }
 
+// configure applies the aliases, the keymap and the per view settings of the
+// configuration file, columns and states included
+func configure(applicationState *components.ApplicationState, cfg *config.Config) error {
+	for alias, command := range cfg.Aliases {
+		if err := registry.RegisterAlias(alias, command); err != nil {
+			return err
+		}
+	}
+
+	for name, interval := range cfg.RefreshIntervals {
+		if err := applicationState.SetViewRefreshInterval(name, interval); err != nil {
+			return fmt.Errorf("setting refresh interval %w", err)
+		}
+	}
+
+	keymap, err := components.NewKeymap(cfg.KeyPreset, cfg.Keys)
+	if err != nil {
+		return err
+	}
+	applicationState.SetKeymap(keymap)
+
+	for name, columns := range cfg.Columns {
+		if err := applicationState.SetViewColumns(name, columns); err != nil {
+			return fmt.Errorf("setting columns %w", err)
+		}
+	}
+
+	for name, rules := range cfg.States {
+		if err := applicationState.SetViewStates(name, rules); err != nil {
+			return fmt.Errorf("setting states %w", err)
+		}
+	}
+
+	return nil
+}
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Printf("Error running %s\n", err.Error())
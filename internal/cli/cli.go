@@ -0,0 +1,162 @@
+// Package cli implements the non-interactive snowctl subcommands. Every
+// subcommand goes through the same snowflake client interfaces as the TUI
+// views and prints its result to stdout.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	// Offline commands run without a connection, the connection manager
+	// given to Run is nil
+	Offline bool
+	Run     func(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error
+}
+
+var commands = []*Command{
+	getCommand,
+	describeCommand,
+	dropCommand,
+	logsCommand,
+	configCommand,
+}
+
+// IsCommand reports whether the arguments select a subcommand rather than
+// the interactive TUI
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	return IsHelp(args) || findCommand(args[0]) != nil
+}
+
+// IsHelp reports whether the arguments only ask for usage, which does not
+// need a connection
+func IsHelp(args []string) bool {
+	return len(args) > 0 && (args[0] == "help" || args[0] == "-h" || args[0] == "--help")
+}
+
+// NeedsConnection reports whether the subcommand selected by args connects
+// to snowflake
+func NeedsConnection(args []string) bool {
+	if len(args) == 0 || IsHelp(args) {
+		return false
+	}
+
+	command := findCommand(args[0])
+	return command != nil && !command.Offline
+}
+
+// Run executes the subcommand selected by args
+func Run(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error {
+	if len(args) == 0 || IsHelp(args) {
+		printUsage(stdout)
+		return nil
+	}
+
+	command := findCommand(args[0])
+	if command == nil {
+		printUsage(stdout)
+		return fmt.Errorf("unknown command %s", args[0])
+	}
+
+	return command.Run(ctx, cm, args[1:], stdout)
+}
+
+func findCommand(name string) *Command {
+	for _, command := range commands {
+		if command.Name == name {
+			return command
+		}
+	}
+	return nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  snowctl [--connection NAME] [--role ROLE] [--warehouse WAREHOUSE] [--read-only] [--view VIEW] [--refresh DURATION] [--skin SKIN]\n      start the interactive interface, views refresh every 30s unless set")
+	fmt.Fprintln(w, "  snowctl [--connection NAME] [--role ROLE] [--warehouse WAREHOUSE] [--read-only] COMMAND\n      run a command, the flags must come before it")
+	for _, command := range commands {
+		fmt.Fprintf(w, "  snowctl %s\n      %s\n", command.Usage, command.Description)
+	}
+	fmt.Fprintf(w, "Resources:\n  %s\n", getResourceNames())
+	fmt.Fprintln(w, "Configuration:\n  ~/.config/snowctl/config.toml sets the defaults of the flags, aliases and columns of the views")
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments and returns the positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// expectArgs checks the number of positional arguments
+func expectArgs(usage string, args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("usage: snowctl %s", usage)
+	}
+	return nil
+}
+
+func newFlagSet(name string, stdout io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	return fs
+}
+
+// outputFlag registers -o and --output on the flag set
+func outputFlag(fs *flag.FlagSet) *string {
+	output := fs.String("output", string(formatter.Table), "output format one of table, wide, json, yaml, csv")
+	fs.StringVar(output, "o", string(formatter.Table), "shorthand for --output")
+	return output
+}
+
+// resource is an object type a subcommand can act on, the first name is
+// the canonical one and the rest are accepted aliases
+type resource[T any] struct {
+	names  []string
+	action T
+}
+
+func findResource[T any](resources []resource[T], name string) *resource[T] {
+	name = strings.ToLower(name)
+	for i := range resources {
+		for _, n := range resources[i].names {
+			if n == name {
+				return &resources[i]
+			}
+		}
+	}
+	return nil
+}
+
+func resourceNames[T any](resources []resource[T]) string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.names[0])
+	}
+	return strings.Join(names, ", ")
+}
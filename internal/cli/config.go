@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/costrouc/snowctl/internal/snowflake/configuration"
+	"github.com/costrouc/snowctl/internal/snowflake/snowsql"
+)
+
+const configUsage = "config doctor"
+
+var configCommand = &Command{
+	Name:        "config",
+	Usage:       configUsage,
+	Description: "show the configuration files searched and the file or environment variable each connection key was read from",
+	Offline:     true,
+	Run:         runConfig,
+}
+
+func runConfig(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error {
+	if len(args) != 1 || args[0] != "doctor" {
+		return fmt.Errorf("usage: snowctl %s", configUsage)
+	}
+
+	snowflakeConfig, err := configuration.Discover()
+	if err != nil {
+		return fmt.Errorf("reading snowflake standard configuration %w", err)
+	}
+	snowsqlConfig, err := snowsql.Discover()
+	if err != nil {
+		return fmt.Errorf("reading snowflake snowsql configuration %w", err)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(tw, "Files, lowest precedence first:")
+	for _, files := range [][]configuration.File{snowflakeConfig.Files, snowsqlConfig.Files} {
+		for _, file := range files {
+			status := "missing"
+			if file.Found {
+				status = "found"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\n", file.Path, status)
+		}
+	}
+	if os.Getenv("SNOWFLAKE_CONNECTIONS") != "" {
+		fmt.Fprintf(tw, "  SNOWFLAKE_CONNECTIONS\tset\n")
+	}
+
+	fmt.Fprintln(tw, "Default connection:")
+	if snowflakeConfig.DefaultConnectionName != "" {
+		fmt.Fprintf(tw, "  %s\t%s\n", snowflakeConfig.DefaultConnectionName, snowflakeConfig.DefaultConnectionSource)
+	} else {
+		fmt.Fprintf(tw, "  none\tsnowflake.default, snowsql.default or the first connection by name is used\n")
+	}
+
+	fmt.Fprintln(tw, "Connections:")
+	for _, name := range sortedKeys(snowflakeConfig.Connections) {
+		connection := snowflakeConfig.Connections[name]
+		printConnectionSources(tw, "snowflake."+name, connection, connection.Sources)
+	}
+	for _, name := range sortedKeys(snowsqlConfig.Connections) {
+		connection := snowsqlConfig.Connections[name]
+		printConnectionSources(tw, "snowsql."+name, connection, connection.Sources)
+	}
+
+	return tw.Flush()
+}
+
+// printConnectionSources prints whether the connection can be configured,
+// reading its key and token files, then the source of each of its keys
+func printConnectionSources(w io.Writer, name string, connection snowflake.Connection, sources map[string]string) {
+	status := "ok"
+	if _, err := connection.SnowflakeConfig(); err != nil {
+		status = err.Error()
+	}
+	fmt.Fprintf(w, "  %s\t%s\n", name, status)
+
+	for _, key := range sortedKeys(sources) {
+		fmt.Fprintf(w, "    %s\t%s\n", key, sources[key])
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
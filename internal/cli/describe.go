@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+type describer func(ctx context.Context, client *snowflake.Client, name string) (any, error)
+
+var describeResources = []resource[describer]{
+	{
+		names: []string{"compute-pool", "compute-pools", "cp"},
+		action: func(ctx context.Context, client *snowflake.Client, name string) (any, error) {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return nil, err
+			}
+			return client.ComputePools.Describe(ctx, id)
+		},
+	},
+	{
+		names: []string{"service", "services", "svc"},
+		action: func(ctx context.Context, client *snowflake.Client, name string) (any, error) {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return nil, err
+			}
+			return client.Services.Describe(ctx, id)
+		},
+	},
+	{
+		names: []string{"listing", "listings"},
+		action: func(ctx context.Context, client *snowflake.Client, name string) (any, error) {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return nil, err
+			}
+			return client.Listings.Describe(ctx, id)
+		},
+	},
+}
+
+const describeUsage = "describe RESOURCE NAME [-o table|json|yaml|csv]"
+
+var describeCommand = &Command{
+	Name:        "describe",
+	Usage:       describeUsage,
+	Description: "show the details of a compute pool, service or listing",
+	Run:         runDescribe,
+}
+
+func runDescribe(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error {
+	fs := newFlagSet("describe", stdout)
+	output := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := expectArgs(describeUsage, positional, 2); err != nil {
+		return err
+	}
+
+	format, err := formatter.ParseFormat(*output)
+	if err != nil {
+		return err
+	}
+
+	r := findResource(describeResources, positional[0])
+	if r == nil {
+		return fmt.Errorf("unknown resource %s, expected one of %s", positional[0], resourceNames(describeResources))
+	}
+
+	details, err := r.action(ctx, cm.GetClient(), positional[1])
+	if err != nil {
+		return fmt.Errorf("describing %s %s %w", r.names[0], positional[1], err)
+	}
+
+	return formatter.WriteRecord(stdout, format, details)
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+type dropper func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error
+
+var dropResources = []resource[dropper]{
+	{
+		names: []string{"warehouse", "warehouses", "wh"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Warehouses.Drop(ctx, id, &snowflake.DropWarehouseOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"database", "databases", "db"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Databases.Drop(ctx, id, &snowflake.DropDatabaseOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"schema", "schemas"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseDatabaseObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Schemas.Drop(ctx, id, &snowflake.DropSchemaOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"table", "tables"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Tables.Drop(ctx, id, &snowflake.DropTableOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"stage", "stages"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Stages.Drop(ctx, id, &snowflake.DropStageOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"security-integration", "security-integrations"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.SecurityIntegrations.Drop(ctx, id, &snowflake.DropSecurityIntegrationOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"listing", "listings"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Listings.Drop(ctx, id, &snowflake.DropListingOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"compute-pool", "compute-pools", "cp"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseAccountObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.ComputePools.Drop(ctx, id, &snowflake.DropComputePoolOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"service", "services", "svc"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Services.Drop(ctx, id, &snowflake.DropServiceOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"image-repository", "image-repositories", "ir"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.ImageRepositories.Drop(ctx, id, &snowflake.DropImageRepositoryOptions{IfExists: ifExists})
+		},
+	},
+	{
+		names: []string{"snapshot", "snapshots"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Snapshots.Drop(ctx, &snowflake.DropSnapshotOptions{IfExists: ifExists, Snapshot: &id})
+		},
+	},
+	{
+		names: []string{"secret", "secrets"},
+		action: func(ctx context.Context, client *snowflake.Client, name string, ifExists bool) error {
+			id, err := snowflake.ParseSchemaObjectIdentifier(name)
+			if err != nil {
+				return err
+			}
+			return client.Secrets.Drop(ctx, &snowflake.DropSecretsOptions{IfExists: ifExists, Secret: &id})
+		},
+	},
+}
+
+const dropUsage = "drop RESOURCE NAME [--if-exists] [--confirm NAME]"
+
+var dropCommand = &Command{
+	Name:        "drop",
+	Usage:       dropUsage,
+	Description: "drop a snowflake object",
+	Run:         runDrop,
+}
+
+func runDrop(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error {
+	fs := newFlagSet("drop", stdout)
+	ifExists := fs.Bool("if-exists", false, "do not fail when the object does not exist")
+	confirm := fs.String("confirm", "", "name of the object again, required on protected environments")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := expectArgs(dropUsage, positional, 2); err != nil {
+		return err
+	}
+
+	r := findResource(dropResources, positional[0])
+	if r == nil {
+		return fmt.Errorf("unknown resource %s, expected one of %s", positional[0], resourceNames(dropResources))
+	}
+
+	if cm.ReadOnly() {
+		return fmt.Errorf("connection %s is read-only, drop is disabled", cm.Name())
+	}
+	if cm.Protected() && *confirm != positional[1] {
+		return fmt.Errorf("connection %s is a %s environment, confirm the drop with --confirm %s", cm.Name(), cm.Environment(), positional[1])
+	}
+
+	if err := r.action(ctx, cm.GetClient(), positional[1], *ifExists); err != nil {
+		return fmt.Errorf("dropping %s %s %w", r.names[0], positional[1], err)
+	}
+
+	fmt.Fprintf(stdout, "Dropped %s %s\n", r.names[0], positional[1])
+	return nil
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+const getUsage = "get RESOURCE [-o table|wide|json|yaml|csv] [--database DB] [--schema DB.SCHEMA] [--compute-pool POOL] [--service DB.SCHEMA.SERVICE] [--application-package PACKAGE]"
+
+var getCommand = &Command{
+	Name:        "get",
+	Usage:       getUsage,
+	Description: "list snowflake objects",
+	Run:         runGet,
+}
+
+func runGet(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error {
+	fs := newFlagSet("get", stdout)
+	database := fs.String("database", "", "show objects in database")
+	schema := fs.String("schema", "", "show objects in schema DB.SCHEMA")
+	computePool := fs.String("compute-pool", "", "show services in compute pool")
+	service := fs.String("service", "", "service DB.SCHEMA.SERVICE for instances, containers and endpoints")
+	applicationPackage := fs.String("application-package", "", "application package for versions and release directives")
+	output := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := expectArgs(getUsage, positional, 1); err != nil {
+		return err
+	}
+
+	format, err := formatter.ParseFormat(*output)
+	if err != nil {
+		return err
+	}
+
+	r := registry.FindResource(positional[0])
+	if r == nil || r.Interactive {
+		return fmt.Errorf("unknown resource %s, expected one of %s", positional[0], getResourceNames())
+	}
+
+	s := &registry.Scope{}
+	if *database != "" {
+		id, err := snowflake.ParseAccountObjectIdentifier(*database)
+		if err != nil {
+			return fmt.Errorf("parsing --database %w", err)
+		}
+		s.Database = &id
+	}
+	if *schema != "" {
+		id, err := snowflake.ParseDatabaseObjectIdentifier(*schema)
+		if err != nil {
+			return fmt.Errorf("parsing --schema %w", err)
+		}
+		s.Schema = &id
+	}
+	if *computePool != "" {
+		id, err := snowflake.ParseAccountObjectIdentifier(*computePool)
+		if err != nil {
+			return fmt.Errorf("parsing --compute-pool %w", err)
+		}
+		s.ComputePool = &id
+	}
+	if *service != "" {
+		id, err := snowflake.ParseSchemaObjectIdentifier(*service)
+		if err != nil {
+			return fmt.Errorf("parsing --service %w", err)
+		}
+		s.Service = &id
+	}
+	if *applicationPackage != "" {
+		id, err := snowflake.ParseAccountObjectIdentifier(*applicationPackage)
+		if err != nil {
+			return fmt.Errorf("parsing --application-package %w", err)
+		}
+		s.ApplicationPackage = &id
+	}
+
+	for _, argument := range registry.ScopeArguments {
+		if s.Has(argument) && !slices.Contains(r.Scopes, argument) {
+			return fmt.Errorf("%s do not accept --%s", r.Name, argument)
+		}
+	}
+
+	source, err := r.Open(cm, s)
+	if err != nil {
+		return err
+	}
+
+	table, err := source.GetData(ctx)
+	if err != nil {
+		return fmt.Errorf("getting %s %w", r.Name, err)
+	}
+
+	return formatter.Write(stdout, format, table.Data())
+}
+
+// getResourceNames lists the registered resources as command line names,
+// the interactive ones left out
+func getResourceNames() string {
+	names := make([]string, 0)
+	for _, r := range registry.Resources() {
+		if r.Interactive {
+			continue
+		}
+		names = append(names, strings.ReplaceAll(r.Name, " ", "-"))
+	}
+	return strings.Join(names, ", ")
+}
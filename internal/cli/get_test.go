@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	// the views register their resources when the package is loaded
+	_ "github.com/costrouc/snowctl/internal/components"
+)
+
+func TestGetInteractiveResources(t *testing.T) {
+	names := strings.Split(getResourceNames(), ", ")
+	for _, name := range []string{"help", "connections"} {
+		if slices.Contains(names, name) {
+			t.Errorf("%s is listed in %q", name, names)
+		}
+	}
+	if !slices.Contains(names, "compute-pools") {
+		t.Errorf("compute-pools is missing from %q", names)
+	}
+
+	for _, name := range []string{"help", "connections"} {
+		err := runGet(context.Background(), nil, []string{name}, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), "unknown resource "+name) {
+			t.Errorf("got %v, want an unknown resource error", err)
+		}
+	}
+}
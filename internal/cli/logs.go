@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+const logsUsage = "logs DB.SCHEMA.SERVICE --container NAME [--instance ID]"
+
+var logsCommand = &Command{
+	Name:        "logs",
+	Usage:       logsUsage,
+	Description: "print the logs of a service container",
+	Run:         runLogs,
+}
+
+func runLogs(ctx context.Context, cm *snowflake.ConnectionManager, args []string, stdout io.Writer) error {
+	fs := newFlagSet("logs", stdout)
+	instance := fs.Int("instance", 0, "service instance id")
+	container := fs.String("container", "", "container name")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := expectArgs(logsUsage, positional, 1); err != nil {
+		return err
+	}
+	if *container == "" {
+		return fmt.Errorf("usage: snowctl %s", logsUsage)
+	}
+
+	service, err := snowflake.ParseSchemaObjectIdentifier(positional[0])
+	if err != nil {
+		return fmt.Errorf("parsing service %w", err)
+	}
+
+	logs, err := cm.GetClient().Services.Logs(ctx, service, &snowflake.ServiceLogsOptions{
+		InstanceId:    *instance,
+		ContainerName: *container,
+	})
+	if err != nil {
+		return fmt.Errorf("getting logs for service %s %w", service.FullyQualifiedName(), err)
+	}
+
+	_, err = fmt.Fprint(stdout, logs)
+	return err
+}
@@ -0,0 +1,61 @@
+// Package clipboard copies text to the system clipboard using the copy
+// command of the platform, falling back to the OSC 52 terminal escape
+// sequence which also works over ssh in most terminals.
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// commands are tried in order, the first one found in the path is used
+var commands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip.exe"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+		{"clip.exe"},
+	},
+}
+
+// Copy writes the text to the clipboard
+func Copy(text string) error {
+	for _, command := range commands[runtime.GOOS] {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+
+		cmd := exec.Command(path, command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("running %s %w", command[0], err)
+		}
+		return nil
+	}
+
+	return copyOSC52(text)
+}
+
+// copyOSC52 asks the terminal to set the clipboard, the sequence is written
+// to the controlling terminal so that it does not mix with the screen
+// output buffered by tcell
+func copyOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("no clipboard command found and opening terminal %w", err)
+	}
+	defer tty.Close()
+
+	_, err = fmt.Fprintf(tty, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	if err != nil {
+		return fmt.Errorf("writing clipboard escape sequence %w", err)
+	}
+	return nil
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ApplicationPackagesView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *ApplicationPackagesOptions
 }
@@ -20,45 +21,47 @@ type ApplicationPackagesOptions struct{}
 
 func NewApplicationPackagesView(connectionManager *snowflake.ConnectionManager, opts *ApplicationPackagesOptions) *ApplicationPackagesView {
 	applicationPackages := &ApplicationPackagesView{
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		connectionManager: connectionManager,
 		options:           opts,
 	}
 
-	applicationPackages.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return applicationPackages
 }
 
-func (v *ApplicationPackagesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating users data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "application packages",
+		Aliases:     []string{"application package", "ap"},
+		Description: "native application packages",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewApplicationPackagesView(cm, &ApplicationPackagesOptions{})
+		},
+	})
 }
 
-func (v *ApplicationPackagesView) getData(ctx context.Context) (*Table, error) {
-	applicationPackages, err := v.connectionManager.GetClient().SDKClient.ApplicationPackages.Show(ctx, sdk.NewShowApplicationPackageRequest())
+func (v *ApplicationPackagesView) GetData(ctx context.Context) (*registry.Table, error) {
+	applicationPackages, err := v.connectionManager.GetClient().ApplicationPackages.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show application packages %w", err)
 	}
 
 	columns := []string{"Name", "Distribution", "Owner"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, applicationPackage := range applicationPackages {
-		rows = append(rows, []string{
-			applicationPackage.Name,
-			applicationPackage.Distribution,
-			applicationPackage.Owner,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				applicationPackage.Name,
+				applicationPackage.Distribution,
+				applicationPackage.Owner,
+			},
+			Record:     applicationPackage,
+			Identifier: sdk.NewAccountObjectIdentifier(applicationPackage.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "application packages",
 		Columns: columns,
 		Rows:    rows,
@@ -72,15 +75,17 @@ func (t *ApplicationPackagesView) GetBindings(ctx context.Context, applicationSt
 			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				name := t.table.GetCell(r, 0).Text
+				applicationPackage, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewVersionsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&VersionsOptions{
-							ApplicationPackage: sdk.NewAccountObjectIdentifier(name),
+							ApplicationPackage: applicationPackage,
 						},
 					),
 				)
@@ -92,14 +97,15 @@ func (t *ApplicationPackagesView) GetBindings(ctx context.Context, applicationSt
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				name := t.table.GetCell(r, 0).Text
-				applicationPackage := sdk.NewAccountObjectIdentifier(name)
+				applicationPackage, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewReleaseDirectivesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ReleaseDirectivesOptions{
 							ApplicationPackage: &applicationPackage,
 						},
@@ -113,16 +119,15 @@ func (t *ApplicationPackagesView) GetBindings(ctx context.Context, applicationSt
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				applicationPackageName := t.table.GetCell(r, 0).Text
-				applicationPackage := sdk.NewAccountObjectIdentifier(
-					applicationPackageName,
-				)
+				applicationPackage, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeApplicationPackage,
 							ObjectIdentifier: applicationPackage,
@@ -134,7 +139,3 @@ func (t *ApplicationPackagesView) GetBindings(ctx context.Context, applicationSt
 		},
 	}
 }
-
-func (v *ApplicationPackagesView) GetRender() tview.Primitive {
-	return v.table
-}
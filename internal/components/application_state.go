@@ -3,7 +3,11 @@ package components
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -12,47 +16,93 @@ import (
 type Component interface {
 	GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding
 	GetRender() tview.Primitive
-	Update(ctx context.Context) error
+	GetData(ctx context.Context) (*registry.Table, error)
+	Render(table *registry.Table)
+	SetLoading(frame string)
 }
 
 type ApplicationState struct {
 	// current key bindings for the given application
 	bindings []*KeyBinding
 
-	// snowflake client
-	ConnectionManager *snowflake.ConnectionManager
+	// sessions holds the connection manager of every tab, tab is the
+	// current one
+	sessions *snowflake.SessionRegistry
+	tabs     []*tab
+	tab      *tab
+	tabCount int
 
-	// history is a stack that represents the state of the application
-	history []Component
+	// startResource and startScope are the view opened by new tabs
+	startResource *registry.Resource
+	startScope    *registry.Scope
+
+	// loading is the fetch in flight for the top of the history, nil once rendered
+	loading *load
+
+	// refreshInterval is the default auto refresh interval of the views
+	refreshInterval time.Duration
+	refreshPaused   bool
+	refreshTimer    *time.Timer
+
+	// keymap remaps the default keys of the bindings
+	keymap Keymap
+
+	// viewRefreshIntervals, viewColumns and viewStates are configured by
+	// resource name
+	viewRefreshIntervals map[string]time.Duration
+	viewColumns          map[string][]string
+	viewStates           map[string][]StateRule
 
 	Application *tview.Application
 	Pages       *tview.Pages
-	Main        *tview.Pages
+	// Main and header hold a page per tab, the views of the tab and its
+	// session context
+	Main   *tview.Pages
+	header *tview.Pages
 
-	context     *SnowflakeContext
+	tabBar      *TabBar
 	keyBindings *KeyBindings
 	search      *Search
+	export      *Export
+	filter      *Filter
 	status      *Status
 	modal       *ConfirmModal
+
+	connectionForm *ConnectionForm
 }
 
 func NewApplication(cm *snowflake.ConnectionManager) *ApplicationState {
+	application := tview.NewApplication()
 	applicationState := &ApplicationState{
-		bindings:          make([]*KeyBinding, 0),
-		history:           make([]Component, 0),
-		ConnectionManager: cm,
+		bindings:        make([]*KeyBinding, 0),
+		sessions:        snowflake.NewSessionRegistry(cm),
+		refreshInterval: DefaultRefreshInterval,
 
-		Application: tview.NewApplication(),
+		viewRefreshIntervals: make(map[string]time.Duration),
+		viewColumns:          make(map[string][]string),
+		viewStates:           make(map[string][]StateRule),
+
+		Application: application,
 		Pages:       tview.NewPages(),
 		Main:        tview.NewPages(),
+		header:      tview.NewPages(),
 
-		context:     NewSnowflakeContext(cm),
+		tabBar:      NewTabBar(),
 		keyBindings: NewKeyBindings(),
-		search:      NewSearch(),
+		search:      NewSearch(cm, application),
+		export:      NewExport(),
+		filter:      NewFilter(),
 		status:      NewStatus(),
 		modal:       NewConfirmModal(),
+
+		connectionForm: NewConnectionForm(),
 	}
 
+	applicationState.tab = applicationState.addTab(cm)
+	applicationState.Main.SwitchToPage(applicationState.tab.page())
+	applicationState.header.SwitchToPage(applicationState.tab.page())
+	applicationState.tabBar.Render(applicationState.tabs, applicationState.tab)
+
 	applicationState.Pages.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		for _, keyBinding := range applicationState.bindings {
 			if event.Name() == keyBinding.Event.Name() {
@@ -64,7 +114,11 @@ func NewApplication(cm *snowflake.ConnectionManager) *ApplicationState {
 
 	applicationState.Pages.AddPage("main", viewPage(applicationState), true, true)
 	applicationState.Pages.AddPage("search", searchPage(applicationState), true, false)
+	applicationState.Pages.AddPage("export", exportPage(applicationState), true, false)
+	applicationState.Pages.AddPage("filter", filterPage(applicationState), true, false)
 	applicationState.Pages.AddPage("modal", applicationState.modal.GetRender(), true, false)
+	applicationState.Pages.AddPage("confirm", confirmPage(applicationState), true, false)
+	applicationState.Pages.AddPage("connection", connectionPage(applicationState), true, false)
 
 	applicationState.Application.SetRoot(applicationState.Pages, true).EnableMouse(true).EnablePaste(true)
 
@@ -72,49 +126,252 @@ func NewApplication(cm *snowflake.ConnectionManager) *ApplicationState {
 }
 
 func viewPage(applicationState *ApplicationState) *tview.Grid {
-	grid := tview.NewGrid().SetRows(4, 1, 0, 2).SetColumns(0, 0).
-		AddItem(applicationState.context.GetRender(), 0, 0, 1, 1, 0, 0, false).
-		AddItem(applicationState.keyBindings.GetRender(), 0, 1, 1, 1, 0, 0, false).
-		AddItem(applicationState.Main, 1, 0, 2, 2, 0, 0, true).
-		AddItem(applicationState.status.GetRender(), 3, 0, 1, 2, 0, 0, false)
+	grid := tview.NewGrid().SetRows(1, 4, 1, 0, 2).SetColumns(0, 0).
+		AddItem(applicationState.tabBar.GetRender(), 0, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(applicationState.keyBindings.GetRender(), 1, 1, 1, 1, 0, 0, false).
+		AddItem(applicationState.Main, 2, 0, 2, 2, 0, 0, true).
+		AddItem(applicationState.status.GetRender(), 4, 0, 1, 2, 0, 0, false)
 
 	return grid
 }
 
 func searchPage(applicationState *ApplicationState) *tview.Grid {
-	grid := tview.NewGrid().SetRows(4, 1, 0, 2).SetColumns(0, 0).
-		AddItem(applicationState.context.GetRender(), 0, 0, 1, 1, 0, 0, false).
-		AddItem(applicationState.keyBindings.GetRender(), 0, 1, 1, 1, 0, 0, false).
-		AddItem(applicationState.search.GetRender(), 1, 0, 1, 2, 0, 0, true).
-		AddItem(applicationState.Main, 2, 0, 1, 2, 0, 0, false).
-		AddItem(applicationState.status.GetRender(), 3, 0, 1, 2, 0, 0, false)
+	grid := tview.NewGrid().SetRows(1, 4, 1, 0, 2).SetColumns(0, 0).
+		AddItem(applicationState.tabBar.GetRender(), 0, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(applicationState.keyBindings.GetRender(), 1, 1, 1, 1, 0, 0, false).
+		AddItem(applicationState.search.GetRender(), 2, 0, 1, 2, 0, 0, true).
+		AddItem(applicationState.Main, 3, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.status.GetRender(), 4, 0, 1, 2, 0, 0, false)
 
 	return grid
 }
 
+func exportPage(applicationState *ApplicationState) *tview.Grid {
+	grid := tview.NewGrid().SetRows(1, 4, 1, 0, 2).SetColumns(0, 0).
+		AddItem(applicationState.tabBar.GetRender(), 0, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(applicationState.keyBindings.GetRender(), 1, 1, 1, 1, 0, 0, false).
+		AddItem(applicationState.export.GetRender(), 2, 0, 1, 2, 0, 0, true).
+		AddItem(applicationState.Main, 3, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.status.GetRender(), 4, 0, 1, 2, 0, 0, false)
+
+	return grid
+}
+
+func filterPage(applicationState *ApplicationState) *tview.Grid {
+	grid := tview.NewGrid().SetRows(1, 4, 1, 0, 2).SetColumns(0, 0).
+		AddItem(applicationState.tabBar.GetRender(), 0, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(applicationState.keyBindings.GetRender(), 1, 1, 1, 1, 0, 0, false).
+		AddItem(applicationState.filter.GetRender(), 2, 0, 1, 2, 0, 0, true).
+		AddItem(applicationState.Main, 3, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.status.GetRender(), 4, 0, 1, 2, 0, 0, false)
+
+	return grid
+}
+
+func connectionPage(applicationState *ApplicationState) *tview.Grid {
+	grid := tview.NewGrid().SetRows(1, 4, 1, 0, 2).SetColumns(0, 0).
+		AddItem(applicationState.tabBar.GetRender(), 0, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(applicationState.keyBindings.GetRender(), 1, 1, 1, 1, 0, 0, false).
+		AddItem(applicationState.connectionForm.GetRender(), 2, 0, 2, 2, 0, 0, true).
+		AddItem(applicationState.status.GetRender(), 4, 0, 1, 2, 0, 0, false)
+
+	return grid
+}
+
+func confirmPage(applicationState *ApplicationState) *tview.Grid {
+	grid := tview.NewGrid().SetRows(1, 4, 1, 0, 2).SetColumns(0, 0).
+		AddItem(applicationState.tabBar.GetRender(), 0, 0, 1, 2, 0, 0, false).
+		AddItem(applicationState.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(applicationState.keyBindings.GetRender(), 1, 1, 1, 1, 0, 0, false).
+		AddItem(applicationState.modal.GetNameRender(), 2, 0, 2, 2, 0, 0, true).
+		AddItem(applicationState.status.GetRender(), 4, 0, 1, 2, 0, 0, false)
+
+	return grid
+}
+
+// editConnection opens the connection form on the named connection, an
+// empty name adds a connection
+func (a *ApplicationState) editConnection(ctx context.Context, name string) error {
+	if err := a.connectionForm.Edit(ctx, a, name); err != nil {
+		return err
+	}
+	a.Pages.SwitchToPage("connection")
+	a.UpdateView(ctx, false)
+	return nil
+}
+
+// confirmDrop asks before dropping the object, its name is typed on the
+// protected environments and the objects denied by the safeguards are
+// refused without asking
+func (a *ApplicationState) confirmDrop(ctx context.Context, kind string, id sdk.ObjectIdentifier, confirm func(action bool)) {
+	cm := a.ConnectionManager()
+	if err := cm.CheckDrop(id); err != nil {
+		a.status.SetError(err)
+		return
+	}
+
+	if cm.Protected() {
+		message := fmt.Sprintf("Drop %s %s on %s?", kind, id.FullyQualifiedName(), cm.Environment())
+		a.modal.PromptName(ctx, a, message, id.Name(), confirm)
+		return
+	}
+	a.modal.Prompt(ctx, a, fmt.Sprintf("Drop %s %s?", kind, id.FullyQualifiedName()), confirm)
+}
+
 func (a *ApplicationState) Push(ctx context.Context, component Component) {
-	a.history = append(a.history, component)
+	if resource := registry.ResourceOf(component); resource != nil {
+		if columnar, ok := component.(Columnar); ok && len(a.viewColumns[resource.Name]) > 0 {
+			columnar.SetColumns(a.viewColumns[resource.Name])
+		}
+		if stateful, ok := component.(Stateful); ok {
+			stateful.SetStates(append(slices.Clone(a.viewStates[resource.Name]), resource.States...))
+		}
+	}
+
+	a.tab.history = append(a.tab.history, component)
 	a.UpdateView(ctx, true)
 }
 
+// SetKeymap remaps the key bindings, see CheckBindings for conflicts
+func (a *ApplicationState) SetKeymap(keymap Keymap) {
+	a.keymap = keymap
+}
+
+// SetViewColumns sets the columns shown by the views of a resource
+func (a *ApplicationState) SetViewColumns(name string, columns []string) error {
+	resource := registry.FindResource(name)
+	if resource == nil {
+		return fmt.Errorf("unknown resource %s", name)
+	}
+	a.viewColumns[resource.Name] = columns
+	return nil
+}
+
+// SetViewStates adds state rules to the views of a resource, they apply
+// before the default rules of the resource
+func (a *ApplicationState) SetViewStates(name string, rules []StateRule) error {
+	resource := registry.FindResource(name)
+	if resource == nil {
+		return fmt.Errorf("unknown resource %s", name)
+	}
+	for _, rule := range rules {
+		if err := validateState(&rule); err != nil {
+			return err
+		}
+	}
+	a.viewStates[resource.Name] = append(a.viewStates[resource.Name], rules...)
+	return nil
+}
+
 func (a *ApplicationState) Pop(ctx context.Context) {
-	if len(a.history) == 1 {
+	if len(a.tab.history) == 1 {
 		return
 	}
 
-	a.Main.RemovePage(fmt.Sprintf("page%d", len(a.history)))
-	a.history = a.history[:len(a.history)-1]
+	a.tab.main.RemovePage(fmt.Sprintf("page%d", len(a.tab.history)))
+	a.tab.history = a.tab.history[:len(a.tab.history)-1]
 	a.UpdateView(ctx, false)
 }
 
 func (a *ApplicationState) UpdateView(ctx context.Context, newPage bool) {
-	a.bindings = []*KeyBinding{
+	name, _ := a.Pages.GetFrontPage()
+	if name != "main" {
+		a.bindings = a.bindingsFor(ctx, name, nil)
+		return
+	}
+
+	component := a.tab.history[len(a.tab.history)-1]
+	a.bindings = a.bindingsFor(ctx, name, component)
+	if newPage {
+		a.tab.main.AddAndSwitchToPage(fmt.Sprintf("page%d", len(a.tab.history)), component.GetRender(), true)
+	}
+	a.load(ctx, component, true)
+
+	a.updateContext(ctx)
+
+	a.keyBindings.Clear()
+	for _, binding := range a.bindings {
+		a.keyBindings.Add(binding)
+	}
+	a.keyBindings.Update()
+}
+
+// bindingsFor returns the key bindings of a page remapped by the keymap,
+// on the main page the bindings of the component are added. The mutating
+// bindings are left out on read-only connections.
+func (a *ApplicationState) bindingsFor(ctx context.Context, page string, component Component) []*KeyBinding {
+	bindings := a.allBindingsFor(ctx, page, component)
+	if a.ConnectionManager().ReadOnly() {
+		bindings = slices.DeleteFunc(bindings, func(binding *KeyBinding) bool {
+			return binding.Mutating
+		})
+	}
+	return bindings
+}
+
+// allBindingsFor returns the key bindings of a page remapped by the keymap,
+// the mutating ones included, CheckBindings checks them all
+func (a *ApplicationState) allBindingsFor(ctx context.Context, page string, component Component) []*KeyBinding {
+	bindings := append(a.globalBindings(ctx), a.tabBindings(ctx)...)
+
+	switch page {
+	case "search", "export", "filter", "connection", "confirm":
+		// plain keys are typed in the input fields
+		a.keymap.apply("", bindings)
+		bindings = slices.DeleteFunc(bindings, func(binding *KeyBinding) bool {
+			return isTextKey(binding.Event)
+		})
+
+		var pageBindings []*KeyBinding
+		switch page {
+		case "search":
+			pageBindings = a.search.GetBindings(ctx, a)
+		case "export":
+			pageBindings = a.export.GetBindings(ctx, a)
+		case "filter":
+			pageBindings = a.filter.GetBindings(ctx, a)
+		}
+		a.keymap.apply("", pageBindings)
+		return append(bindings, pageBindings...)
+	case "modal":
+		a.keymap.apply("", bindings)
+		return bindings
+	}
+
+	bindings = append(bindings, component.GetBindings(ctx, a)...)
+	if sortable, ok := component.(Sortable); ok {
+		bindings = append(bindings, sortBindings(sortable)...)
+	}
+	if selector, ok := component.(recordSelector); ok {
+		if _, describing := component.(*DescribeView); !describing {
+			bindings = append(bindings, describeBindings(ctx, a, selector)...)
+		}
+	}
+
+	resource := ""
+	if r := registry.ResourceOf(component); r != nil {
+		resource = r.Name
+	}
+	a.keymap.apply(resource, bindings)
+	return bindings
+}
+
+// globalBindings are active on every page
+func (a *ApplicationState) globalBindings(ctx context.Context) []*KeyBinding {
+	return []*KeyBinding{
 		{
 			Description: "quit",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				if name, _ := a.Pages.GetFrontPage(); name != "search" {
+				if name, _ := a.Pages.GetFrontPage(); name != "search" && name != "export" && name != "filter" && name != "connection" && name != "confirm" {
+					a.cancelLoad()
+					a.stopRefresh()
 					a.Application.Stop()
 				}
 				return event
@@ -131,6 +388,58 @@ func (a *ApplicationState) UpdateView(ctx context.Context, newPage bool) {
 				return nil
 			},
 		},
+		{
+			Description: "export",
+			Event:       tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				if name, _ := a.Pages.GetFrontPage(); name != "main" {
+					return event
+				}
+
+				var table *registry.Table
+				if component, ok := a.tab.history[len(a.tab.history)-1].(Exportable); ok {
+					table = component.GetTable()
+				}
+				a.export.Reset(table)
+				a.Pages.SwitchToPage("export")
+				a.UpdateView(ctx, false)
+				return nil
+			},
+		},
+		{
+			Description: "filter",
+			Event:       tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				if name, _ := a.Pages.GetFrontPage(); name != "main" {
+					return event
+				}
+
+				component, ok := a.tab.history[len(a.tab.history)-1].(Filterable)
+				if !ok {
+					return event
+				}
+				a.filter.Reset(component)
+				a.Pages.SwitchToPage("filter")
+				a.UpdateView(ctx, false)
+				return nil
+			},
+		},
+		{
+			Description: "help",
+			Event:       tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				if name, _ := a.Pages.GetFrontPage(); name != "main" {
+					return event
+				}
+				if _, ok := a.tab.history[len(a.tab.history)-1].(*HelpView); !ok {
+					a.Push(ctx, NewHelpView(a.ConnectionManager()))
+				}
+				return nil
+			},
+		},
 		{
 			Description: "cancel",
 			Event:       tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone),
@@ -142,13 +451,57 @@ func (a *ApplicationState) UpdateView(ctx context.Context, newPage bool) {
 					a.search.Clear()
 					a.Pages.SwitchToPage("main")
 					a.UpdateView(ctx, false)
+				case "export", "connection":
+					a.Pages.SwitchToPage("main")
+					a.UpdateView(ctx, false)
+				case "confirm":
+					a.modal.Cancel()
+				case "filter":
+					a.filter.Clear()
+					a.Pages.SwitchToPage("main")
+					a.UpdateView(ctx, false)
 				case "main":
+					if a.cancelLoad() {
+						a.status.SetMessage("Cancelled loading")
+						return nil
+					}
+					if component, ok := a.tab.history[len(a.tab.history)-1].(Filterable); ok {
+						if query, mode := component.GetFilter(); query != "" {
+							component.SetFilter("", mode)
+							return nil
+						}
+					}
 					a.Pop(ctx)
 				}
 				return event
 			},
 		},
-		// emacs compatibility
+		{
+			Description: "auto refresh",
+			Event:       tcell.NewEventKey(tcell.KeyCtrlR, 0, tcell.ModCtrl),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				a.toggleRefresh(ctx)
+				return nil
+			},
+		},
+		// navigation, emacs keys by default and remapped by the key presets
+		{
+			Description: "top",
+			Event:       tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				return tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModNone)
+			},
+		},
+		{
+			Description: "bottom",
+			Event:       tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				return tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone)
+			},
+		},
 		{
 			Description: "page_down",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlV, 0, tcell.ModCtrl),
@@ -198,32 +551,4 @@ func (a *ApplicationState) UpdateView(ctx context.Context, newPage bool) {
 			},
 		},
 	}
-
-	name, _ := a.Pages.GetFrontPage()
-	switch name {
-	case "search":
-		a.bindings = append(a.bindings, a.search.GetBindings(ctx, a)...)
-		return
-	case "modal":
-		return
-	}
-
-	component := a.history[len(a.history)-1]
-	a.bindings = append(a.bindings, component.GetBindings(ctx, a)...)
-	err := component.Update(ctx)
-	if err != nil {
-		a.status.SetError(err)
-		return
-	}
-	if newPage {
-		a.Main.AddAndSwitchToPage(fmt.Sprintf("page%d", len(a.history)), component.GetRender(), true)
-	}
-
-	a.context.Update(ctx)
-
-	a.keyBindings.Clear()
-	for _, binding := range a.bindings {
-		a.keyBindings.Add(binding)
-	}
-	a.keyBindings.Update()
 }
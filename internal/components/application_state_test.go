@@ -0,0 +1,230 @@
+package components
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/costrouc/snowctl/internal/snowflake/fake"
+	"github.com/gdamore/tcell/v2"
+)
+
+// testApplication runs an application on a simulation screen, the keys
+// injected in the screen go through the key bindings as typed ones do
+type testApplication struct {
+	*ApplicationState
+	screen tcell.SimulationScreen
+}
+
+// startApplication opens the view of the command and runs the application
+// until the test ends
+func startApplication(t *testing.T, cm *snowflake.ConnectionManager, command string) *testApplication {
+	t.Helper()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(160, 50)
+
+	a := NewApplication(cm)
+	a.SetKeymap(Keymap{})
+	a.Application.SetScreen(screen)
+	if err := a.SetStartView(command); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := a.Application.Run(); err != nil {
+			t.Error(err)
+		}
+	}()
+	t.Cleanup(func() {
+		a.Application.QueueUpdate(func() {
+			a.cancelLoad()
+			a.stopRefresh()
+		})
+		a.Application.Stop()
+		<-done
+	})
+	return &testApplication{ApplicationState: a, screen: screen}
+}
+
+// onUI runs f on the event loop of the application and waits for it
+func (a *testApplication) onUI(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	a.Application.QueueUpdate(func() {
+		f()
+		close(done)
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the event loop")
+	}
+}
+
+// eventually polls the condition on the event loop until it holds
+func (a *testApplication) eventually(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		held := false
+		a.onUI(t, func() { held = condition() })
+		if held {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", description)
+}
+
+// waitForRows waits until the current view has rendered a selected row
+func (a *testApplication) waitForRows(t *testing.T) {
+	t.Helper()
+	a.eventually(t, "rows", func() bool {
+		view, ok := a.tab.history[len(a.tab.history)-1].(interface{ Selected() *registry.Row })
+		return ok && view.Selected() != nil
+	})
+}
+
+// status returns the text of the status bar
+func (a *testApplication) status(t *testing.T) string {
+	t.Helper()
+	var text string
+	a.onUI(t, func() { text = a.ApplicationState.status.view.GetText(true) })
+	return text
+}
+
+func (a *testApplication) press(key tcell.Key, r rune, mod tcell.ModMask) {
+	a.screen.InjectKey(key, r, mod)
+}
+
+func (a *testApplication) frontPage(t *testing.T) string {
+	t.Helper()
+	var name string
+	a.onUI(t, func() { name, _ = a.Pages.GetFrontPage() })
+	return name
+}
+
+func warehouseNames(backend *fake.Backend) []string {
+	var names []string
+	for _, warehouse := range backend.Fixtures().Warehouses {
+		names = append(names, warehouse.Name)
+	}
+	return names
+}
+
+func TestDropWarehouse(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	a := startApplication(t, snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client()), "warehouses")
+	a.waitForRows(t)
+
+	a.press(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	a.eventually(t, "the confirm modal", func() bool {
+		name, _ := a.Pages.GetFrontPage()
+		return name == "modal"
+	})
+	a.press(tcell.KeyRight, 0, tcell.ModNone)
+	a.press(tcell.KeyEnter, 0, tcell.ModNone)
+	a.eventually(t, "the main page", func() bool {
+		name, _ := a.Pages.GetFrontPage()
+		return name == "main"
+	})
+
+	if got := a.status(t); got != "Dropped warehouse "+sdk.NewAccountObjectIdentifier("COMPUTE_WH").FullyQualifiedName() {
+		t.Errorf("got status %q", got)
+	}
+	if names := warehouseNames(backend); slices.Contains(names, "COMPUTE_WH") {
+		t.Errorf("COMPUTE_WH was not dropped from %q", names)
+	}
+}
+
+func TestDropWarehouseCanceled(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	a := startApplication(t, snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client()), "warehouses")
+	a.waitForRows(t)
+
+	a.press(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	a.eventually(t, "the confirm modal", func() bool {
+		name, _ := a.Pages.GetFrontPage()
+		return name == "modal"
+	})
+	a.press(tcell.KeyEnter, 0, tcell.ModNone)
+	a.eventually(t, "the main page", func() bool {
+		name, _ := a.Pages.GetFrontPage()
+		return name == "main"
+	})
+
+	if got := a.status(t); !strings.HasPrefix(got, "Canceled drop warehouse") {
+		t.Errorf("got status %q", got)
+	}
+	if names := warehouseNames(backend); len(names) != len(testFixtures.Warehouses) {
+		t.Errorf("got warehouses %q after canceling", names)
+	}
+}
+
+func TestDropWarehouseDenied(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	cm := snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client())
+	if err := cm.SetSafeguards(&snowflake.Safeguards{DenyDrop: []string{"compute_*"}}); err != nil {
+		t.Fatal(err)
+	}
+	a := startApplication(t, cm, "warehouses")
+	a.waitForRows(t)
+
+	a.press(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	a.eventually(t, "the deny drop error", func() bool {
+		return strings.Contains(a.ApplicationState.status.view.GetText(true), "deny drop pattern compute_*")
+	})
+	if name := a.frontPage(t); name != "main" {
+		t.Errorf("got page %s, the drop was not refused before confirming", name)
+	}
+
+	// the fake backend refuses it too, as the client of a connection does
+	err := backend.Client().Warehouses.Drop(context.Background(), sdk.NewAccountObjectIdentifier("COMPUTE_WH"), &snowflake.DropWarehouseOptions{})
+	if err == nil {
+		t.Error("the backend dropped a denied warehouse")
+	}
+	if names := warehouseNames(backend); !slices.Contains(names, "COMPUTE_WH") {
+		t.Errorf("COMPUTE_WH was dropped from %q", names)
+	}
+}
+
+func TestReadOnlyBindings(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	cm := snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client())
+	if err := cm.SetSafeguards(&snowflake.Safeguards{ReadOnly: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	keymap, err := NewKeymap("", map[string]string{"drop": "ctrl-x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewApplication(cm)
+	a.SetKeymap(keymap)
+
+	// the mutating actions are still known to the keymap
+	ctx := context.Background()
+	if err := a.CheckBindings(ctx); err != nil {
+		t.Errorf("checking the bindings of a read-only connection %v", err)
+	}
+
+	for _, binding := range a.bindingsFor(ctx, "main", NewWarehousesView(cm, &WarehousesOptions{})) {
+		if binding.Mutating {
+			t.Errorf("read-only connection has the mutating binding %s", binding.Action())
+		}
+	}
+}
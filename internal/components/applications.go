@@ -6,14 +6,15 @@ import (
 	"strconv"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ApplicationsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ApplicationsOptions
 }
 
@@ -22,45 +23,47 @@ type ApplicationsOptions struct{}
 func NewApplicationsView(connectionManager *snowflake.ConnectionManager, opts *ApplicationsOptions) *ApplicationsView {
 	applications := &ApplicationsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	applications.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return applications
 }
 
-func (v *ApplicationsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating users data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "applications",
+		Aliases:     []string{"application", "app"},
+		Description: "installed native applications",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewApplicationsView(cm, &ApplicationsOptions{})
+		},
+	})
 }
 
-func (t *ApplicationsView) getData(ctx context.Context) (*Table, error) {
-	applications, err := t.connectionManager.GetClient().SDKClient.Applications.Show(ctx, sdk.NewShowApplicationRequest())
+func (t *ApplicationsView) GetData(ctx context.Context) (*registry.Table, error) {
+	applications, err := t.connectionManager.GetClient().Applications.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show applications %w", err)
 	}
 
 	columns := []string{"Name", "Version", "Patch", "Owner"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, application := range applications {
-		rows = append(rows, []string{
-			application.Name,
-			application.Version,
-			strconv.Itoa(application.Patch),
-			application.Owner,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				application.Name,
+				application.Version,
+				strconv.Itoa(application.Patch),
+				application.Owner,
+			},
+			Record:     application,
+			Identifier: sdk.NewAccountObjectIdentifier(application.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "applications",
 		Columns: columns,
 		Rows:    rows,
@@ -74,16 +77,15 @@ func (t *ApplicationsView) GetBindings(ctx context.Context, applicationState *Ap
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				applicationName := t.table.GetCell(r, 0).Text
-				application := sdk.NewAccountObjectIdentifier(
-					applicationName,
-				)
+				application, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeApplication,
 							ObjectIdentifier: application,
@@ -95,7 +97,3 @@ func (t *ApplicationsView) GetBindings(ctx context.Context, applicationState *Ap
 		},
 	}
 }
-
-func (v *ApplicationsView) GetRender() tview.Primitive {
-	return v.table
-}
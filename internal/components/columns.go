@@ -0,0 +1,94 @@
+package components
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+// Columnar is implemented by views whose columns can be picked
+type Columnar interface {
+	SetColumns(columns []string)
+}
+
+// projectColumns keeps the named columns of the table in the given order.
+// Names are case insensitive and may use `_` for spaces, a name that is
+// not a column is looked up in the fields of the records such as
+// `created_on`. The table is returned as is when no name matches.
+func projectColumns(table *registry.Table, names []string) *registry.Table {
+	if len(names) == 0 {
+		return table
+	}
+
+	var fields [][]formatter.Field
+	if len(table.Rows) > 0 && !slices.ContainsFunc(table.Rows, func(row registry.Row) bool { return row.Record == nil }) {
+		fields = make([][]formatter.Field, len(table.Rows))
+		for r, row := range table.Rows {
+			fields[r] = formatter.Fields(row.Record)
+		}
+	}
+
+	columns := make([]string, 0, len(names))
+	cells := make([]func(r int) string, 0, len(names))
+	for _, name := range names {
+		if c := columnIndex(table.Columns, name); c >= 0 {
+			columns = append(columns, table.Columns[c])
+			cells = append(cells, func(r int) string { return table.Rows[r].Cells[c] })
+			continue
+		}
+
+		f := -1
+		if len(fields) > 0 {
+			f = fieldIndex(fields[0], name)
+		}
+		if f < 0 {
+			continue
+		}
+		columns = append(columns, name)
+		cells = append(cells, func(r int) string {
+			if fields[r][f].Null {
+				return ""
+			}
+			return fields[r][f].Text
+		})
+	}
+
+	if len(columns) == 0 {
+		return table
+	}
+
+	projected := *table
+	projected.Columns = columns
+	projected.Rows = make([]registry.Row, len(table.Rows))
+	for r, row := range table.Rows {
+		projected.Rows[r] = registry.Row{
+			Cells:      make([]string, len(cells)),
+			Record:     row.Record,
+			Identifier: row.Identifier,
+		}
+		for c, cell := range cells {
+			projected.Rows[r].Cells[c] = cell(r)
+		}
+	}
+	return &projected
+}
+
+func columnIndex(columns []string, name string) int {
+	for c, column := range columns {
+		if strings.EqualFold(strings.ReplaceAll(column, " ", "_"), strings.ReplaceAll(name, " ", "_")) {
+			return c
+		}
+	}
+	return -1
+}
+
+func fieldIndex(fields []formatter.Field, name string) int {
+	for f, field := range fields {
+		if strings.EqualFold(field.Name, strings.ReplaceAll(name, " ", "_")) {
+			return f
+		}
+	}
+	return -1
+}
@@ -0,0 +1,333 @@
+package components
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+// nameSource lists the names of objects for completion, parent is the
+// database or DB.SCHEMA the objects are in
+type nameSource func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error)
+
+func names[T any](items []T, name func(T) string) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = name(item)
+	}
+	return result
+}
+
+var nameSources = map[string]nameSource{
+	"databases": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		databases, err := client.Databases.Show(ctx)
+		return names(databases, func(d sdk.Database) string { return d.Name }), err
+	},
+	"schemas": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		database, err := snowflake.ParseAccountObjectIdentifier(parent)
+		if err != nil {
+			return nil, err
+		}
+		schemas, err := client.Schemas.Show(ctx, &snowflake.ShowSchemaOptions{Database: &database})
+		return names(schemas, func(s sdk.Schema) string { return s.Name }), err
+	},
+	"services": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		schema, err := snowflake.ParseDatabaseObjectIdentifier(parent)
+		if err != nil {
+			return nil, err
+		}
+		services, err := client.Services.Show(ctx, &snowflake.ShowServiceOptions{Schema: &schema})
+		return names(services, func(s snowflake.Service) string { return s.Name }), err
+	},
+	"tables": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		schema, err := snowflake.ParseDatabaseObjectIdentifier(parent)
+		if err != nil {
+			return nil, err
+		}
+		database := sdk.NewAccountObjectIdentifier(schema.DatabaseName())
+		tables, err := client.Tables.Show(ctx, &snowflake.ShowTableOptions{Database: &database, Schema: &schema})
+		return names(tables, func(t sdk.Table) string { return t.Name }), err
+	},
+	"views": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		schema, err := snowflake.ParseDatabaseObjectIdentifier(parent)
+		if err != nil {
+			return nil, err
+		}
+		database := sdk.NewAccountObjectIdentifier(schema.DatabaseName())
+		views, err := client.Views.Show(ctx, &snowflake.ShowViewOptions{Database: &database, Schema: &schema})
+		return names(views, func(v sdk.View) string { return v.Name }), err
+	},
+	"stages": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		schema, err := snowflake.ParseDatabaseObjectIdentifier(parent)
+		if err != nil {
+			return nil, err
+		}
+		database := sdk.NewAccountObjectIdentifier(schema.DatabaseName())
+		stages, err := client.Stages.Show(ctx, &snowflake.ShowStageOptions{Database: &database, Schema: &schema})
+		return names(stages, func(s sdk.Stage) string { return s.Name }), err
+	},
+	"roles": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		roles, err := client.Roles.Show(ctx)
+		return names(roles, func(r sdk.Role) string { return r.Name }), err
+	},
+	"users": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		users, err := client.Users.Show(ctx)
+		return names(users, func(u sdk.User) string { return u.Name }), err
+	},
+	"warehouses": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		warehouses, err := client.Warehouses.Show(ctx)
+		return names(warehouses, func(w sdk.Warehouse) string { return w.Name }), err
+	},
+	"compute pools": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		computePools, err := client.ComputePools.Show(ctx)
+		return names(computePools, func(c snowflake.ComputePool) string { return c.Name }), err
+	},
+	"applications": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		applications, err := client.Applications.Show(ctx)
+		return names(applications, func(a sdk.Application) string { return a.Name }), err
+	},
+	"application packages": func(ctx context.Context, client *snowflake.Client, parent string) ([]string, error) {
+		applicationPackages, err := client.ApplicationPackages.Show(ctx)
+		return names(applicationPackages, func(a sdk.ApplicationPackage) string { return a.Name }), err
+	},
+}
+
+// objectNames maps grant object types and scope arguments to the source of
+// their names, schema level objects are completed as DB.SCHEMA.NAME
+var objectNames = map[string]string{
+	"role":                "roles",
+	"user":                "users",
+	"warehouse":           "warehouses",
+	"compute-pool":        "compute pools",
+	"application":         "applications",
+	"application-package": "application packages",
+	"service":             "services",
+	"table":               "tables",
+	"view":                "views",
+	"stage":               "stages",
+}
+
+var unquotedIdentifier = regexp.MustCompile(`^[A-Z_][A-Z0-9_$]*$`)
+
+// quoteName quotes object names that would otherwise be upper cased
+func quoteName(name string) string {
+	if unquotedIdentifier.MatchString(name) {
+		return name
+	}
+	return snowflake.QuoteIdentifier(name)
+}
+
+// completer completes command bar entries, object names are fetched in the
+// background and the completions refreshed once they arrive
+type completer struct {
+	connectionManager *snowflake.ConnectionManager
+	// fetch runs a lookup outside of the UI goroutine and hands the result
+	// back on the UI goroutine
+	fetch func(lookup func() ([]string, error), done func(names []string))
+
+	cache   map[string][]string
+	pending map[string]bool
+}
+
+func (c *completer) reset() {
+	c.cache = make(map[string][]string)
+	c.pending = make(map[string]bool)
+}
+
+// objectNames returns the cached names or starts fetching them
+func (c *completer) objectNames(source string, parent string) []string {
+	key := source + "\x00" + parent
+	if names, ok := c.cache[key]; ok {
+		return names
+	}
+	if c.pending[key] {
+		return nil
+	}
+
+	c.pending[key] = true
+	client, release := c.connectionManager.Hold()
+	// names fetched before a reset are dropped with the maps they go to
+	cache, pending := c.cache, c.pending
+	c.fetch(func() ([]string, error) {
+		defer release()
+		return nameSources[source](context.Background(), client, parent)
+	}, func(names []string) {
+		delete(pending, key)
+		cache[key] = names
+	})
+	return nil
+}
+
+// complete returns the completed entries for the text of the command bar
+func (c *completer) complete(text string) []string {
+	entries := resourceCompletions(text)
+
+	words := registry.SplitCommand(text)
+	current := ""
+	if !strings.HasSuffix(text, " ") && len(words) > 0 {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	resource, args := registry.FindCommandResource(registry.ExpandAlias(words))
+	if resource == nil {
+		return entries
+	}
+
+	base := strings.TrimSuffix(text, current)
+	for _, candidate := range c.argumentCandidates(resource, args, current) {
+		// `ot` completes to `"Other db"` as well as `"ot` does
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(current)) ||
+			strings.HasPrefix(strings.ToLower(strings.TrimPrefix(candidate, "\"")), strings.ToLower(current)) {
+			entries = append(entries, base+candidate)
+		}
+	}
+	return entries
+}
+
+func resourceCompletions(text string) []string {
+	entries := make([]string, 0)
+	if len(text) == 0 {
+		return entries
+	}
+
+	// trailing spaces are kept so that `services ` completes arguments only
+	prefix := strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimLeft(text, " ")))
+	for _, resource := range registry.Resources() {
+		for _, name := range append([]string{resource.Name}, resource.Aliases...) {
+			if strings.HasPrefix(registry.NormalizeName(name), prefix) {
+				entries = append(entries, resource.Name)
+				break
+			}
+		}
+	}
+	for _, alias := range registry.Aliases() {
+		if strings.HasPrefix(alias, prefix) {
+			entries = append(entries, alias)
+		}
+	}
+	return entries
+}
+
+func (c *completer) argumentCandidates(resource *registry.Resource, args []string, current string) []string {
+	if resource.Accepts(registry.ScopeObject) {
+		switch len(args) {
+		case 0:
+			return registry.GrantObjectTypes
+		case 1:
+			switch args[0] {
+			case "database":
+				return c.pathCandidates(current, 1, "")
+			case "schema":
+				return c.pathCandidates(current, 2, "")
+			case "service", "table", "view", "stage":
+				return c.pathCandidates(current, 3, objectNames[args[0]])
+			}
+			if source, ok := objectNames[args[0]]; ok {
+				return quoteNames(c.objectNames(source, ""))
+			}
+		}
+		return nil
+	}
+
+	if argument, value, ok := strings.Cut(current, "="); ok {
+		candidates := make([]string, 0)
+		for _, candidate := range c.scopeCandidates(registry.ScopeArgument(argument), value) {
+			candidates = append(candidates, argument+"="+candidate)
+		}
+		return candidates
+	}
+
+	candidates := make([]string, 0)
+	for _, argument := range resource.Scopes {
+		candidates = append(candidates, string(argument)+"=")
+	}
+	depth := 0
+	for _, argument := range resource.Scopes {
+		switch argument {
+		case registry.ScopeDatabase:
+			depth = max(depth, 1)
+		case registry.ScopeSchema:
+			depth = max(depth, 2)
+		case registry.ScopeService:
+			depth = max(depth, 3)
+		}
+	}
+	if depth > 0 {
+		return append(candidates, c.pathCandidates(current, depth, "services")...)
+	}
+	if argument := resource.ScopeForParts(1); argument != "" {
+		return append(candidates, c.scopeCandidates(argument, current)...)
+	}
+	return candidates
+}
+
+func (c *completer) scopeCandidates(argument registry.ScopeArgument, current string) []string {
+	switch argument {
+	case registry.ScopeDatabase:
+		return c.pathCandidates(current, 1, "")
+	case registry.ScopeSchema:
+		return c.pathCandidates(current, 2, "")
+	case registry.ScopeService:
+		return c.pathCandidates(current, 3, "services")
+	case registry.ScopeComputePool:
+		return quoteNames(c.objectNames("compute pools", ""))
+	case registry.ScopeApplicationPackage:
+		return quoteNames(c.objectNames("application packages", ""))
+	}
+	return nil
+}
+
+// pathCandidates completes DB, DB.SCHEMA and DB.SCHEMA.NAME identifiers up
+// to depth parts, leaf is the source of the names at the third level
+func (c *completer) pathCandidates(current string, depth int, leaf string) []string {
+	parts, ok := splitPath(current)
+	if !ok {
+		return nil
+	}
+	switch {
+	case len(parts) <= 1:
+		return quoteNames(c.objectNames("databases", ""))
+	case len(parts) == 2 && depth >= 2:
+		return prefixNames(parts[0]+".", c.objectNames("schemas", parts[0]))
+	case len(parts) == 3 && depth >= 3:
+		return prefixNames(parts[0]+"."+parts[1]+".", c.objectNames(leaf, parts[0]+"."+parts[1]))
+	}
+	return nil
+}
+
+// splitPath splits the identifier being typed, dots inside double quotes
+// are part of names and the last part may still be empty, it is false when
+// an earlier part is
+func splitPath(current string) ([]string, bool) {
+	// an even number of quotes leaves a trailing dot outside of them
+	if parent, ok := strings.CutSuffix(current, "."); ok && strings.Count(current, `"`)%2 == 0 {
+		parts, err := snowflake.SplitIdentifier(parent, 0)
+		if err != nil {
+			return nil, false
+		}
+		return append(parts, ""), true
+	}
+	parts, err := snowflake.SplitIdentifier(current, 0)
+	if err != nil {
+		// only the part being typed is empty
+		return []string{current}, !strings.Contains(current, ".")
+	}
+	return parts, true
+}
+
+func quoteNames(names []string) []string {
+	return prefixNames("", names)
+}
+
+func prefixNames(prefix string, names []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = prefix + quoteName(name)
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/costrouc/snowctl/internal/snowflake/fake"
+)
+
+// testCompleter fetches names on the calling goroutine, the first
+// completion of a level starts the fetch and the next one lists the names
+func testCompleter() *completer {
+	backend := fake.NewBackend(&testFixtures)
+	c := &completer{
+		connectionManager: snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client()),
+		fetch: func(lookup func() ([]string, error), done func(names []string)) {
+			names, err := lookup()
+			if err != nil {
+				names = nil
+			}
+			done(names)
+		},
+	}
+	c.reset()
+	return c
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"services ", []string{"services database=", "services schema=", "services compute-pool=", "services DB", "services OTHER"}},
+		{"services O", []string{"services OTHER"}},
+		{"services DB.", []string{`services DB."my.schema"`}},
+		{`services DB."my`, []string{`services DB."my.schema"`}},
+		{`services DB."my.`, []string{`services DB."my.schema"`}},
+		{`services DB."my.schema".`, nil},
+		{`service instances DB."my.schema".`, []string{`service instances DB."my.schema".SVC`}},
+		{`service instances DB."my.schema".S`, []string{`service instances DB."my.schema".SVC`}},
+		{"service instances OTHER.PUBLIC.", []string{"service instances OTHER.PUBLIC.ELSEWHERE"}},
+		{"services DB..", nil},
+		{"services compute-pool=G", []string{"services compute-pool=GPU_POOL"}},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			c := testCompleter()
+			c.complete(test.text)
+			got := c.complete(test.text)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		current string
+		want    []string
+		ok      bool
+	}{
+		{"", []string{""}, true},
+		{"DB", []string{"DB"}, true},
+		{"DB.", []string{"DB", ""}, true},
+		{`DB."my.schema"`, []string{"DB", `"my.schema"`}, true},
+		{`DB."my.schema".`, []string{"DB", `"my.schema"`, ""}, true},
+		{`DB."my.`, []string{"DB", `"my.`}, true},
+		{`"a.b".SCH.SVC`, []string{`"a.b"`, "SCH", "SVC"}, true},
+		{"DB..", nil, false},
+		{".", nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.current, func(t *testing.T) {
+			got, ok := splitPath(test.current)
+			if ok != test.ok || (ok && !slices.Equal(got, test.want)) {
+				t.Errorf("got %q %t, want %q %t", got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package components
 
 import (
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -10,9 +12,17 @@ type KeyBinding struct {
 	Hidden      bool
 	Rune        rune
 	Callback    func(event *tcell.EventKey) *tcell.EventKey
+
+	// Mutating bindings change objects of the account, they are removed on
+	// read-only connections
+	Mutating bool
 }
 
 func (k *KeyBinding) Name() string {
+	if k.Event.Modifiers() == tcell.ModCtrl && k.Event.Key() >= tcell.KeyCtrlA && k.Event.Key() <= tcell.KeyCtrlZ {
+		return "ctrl-" + string(rune('a'+k.Event.Key()-tcell.KeyCtrlA))
+	}
+
 	name := ""
 	switch k.Event.Modifiers() {
 	case tcell.ModAlt:
@@ -25,14 +35,21 @@ func (k *KeyBinding) Name() string {
 		name = "shift-"
 	}
 
+	if k.Event.Key() == tcell.KeyRune && k.Event.Rune() == ' ' {
+		return name + "space"
+	}
 	if k.Event.Key() == tcell.KeyRune {
 		return name + string(k.Event.Rune())
 	}
 	return name + tcell.KeyNames[k.Event.Key()]
 }
 
-type Table struct {
-	Title   string
-	Columns []string
-	Rows    [][]string
+// openResource opens the view of a registered resource, every registered
+// view is a Component
+func openResource(resource *registry.Resource, cm *snowflake.ConnectionManager, scope *registry.Scope) (Component, error) {
+	view, err := resource.Open(cm, scope)
+	if err != nil {
+		return nil, err
+	}
+	return view.(Component), nil
 }
@@ -4,15 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ComputePoolsView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *ComputePoolsOptions
 }
@@ -22,68 +24,94 @@ type ComputePoolsOptions struct{}
 func NewComputePoolsView(connectionManager *snowflake.ConnectionManager, opts *ComputePoolsOptions) *ComputePoolsView {
 	computePools := &ComputePoolsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	computePools.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return computePools
 }
 
-func (v *ComputePoolsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating users data %w", err)
-	}
-
-	updateTable(v.table, table)
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "compute pools",
+		Aliases:     []string{"compute pool", "cp"},
+		Description: "snowpark container services compute pools",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewComputePoolsView(cm, &ComputePoolsOptions{})
+		},
+		States: []StateRule{
+			{Column: "State", Values: []string{"ACTIVE", "IDLE"}, Style: "ok"},
+			{Column: "State", Values: []string{"STARTING", "RESIZING", "STOPPING"}, Style: "warning"},
+			{Column: "State", Values: []string{"SUSPENDED", "FAILED"}, Style: "bad"},
+		},
+	})
+}
 
-	return nil
+// RefreshInterval is shorter than the default to follow state changes
+func (t *ComputePoolsView) RefreshInterval() time.Duration {
+	return statusRefreshInterval
 }
 
-func (t *ComputePoolsView) getData(ctx context.Context) (*Table, error) {
+func (t *ComputePoolsView) GetData(ctx context.Context) (*registry.Table, error) {
 	computePools, err := t.connectionManager.GetClient().ComputePools.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show compute pools %w", err)
 	}
 
 	columns := []string{"Name", "Owner", "Instance Family", "State", "Application", "Auto Suspend Secs"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, computePool := range computePools {
-		rows = append(rows, []string{
-			computePool.Name,
-			computePool.Owner,
-			computePool.InstanceFamily,
-			computePool.State,
-			computePool.Application.String,
-			strconv.Itoa(computePool.AutoSuspendSecs),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				computePool.Name,
+				computePool.Owner,
+				computePool.InstanceFamily,
+				computePool.State,
+				computePool.Application.String,
+				strconv.Itoa(computePool.AutoSuspendSecs),
+			},
+			Record:     computePool,
+			Identifier: sdk.NewAccountObjectIdentifier(computePool.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "compute pools",
 		Columns: columns,
 		Rows:    rows,
 	}, nil
 }
 
+// Describe fetches the details of the compute pool of a row
+func (v *ComputePoolsView) Describe(ctx context.Context, record any) (any, error) {
+	computePool, ok := record.(snowflake.ComputePool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected compute pool record %T", record)
+	}
+	return v.connectionManager.GetClient().ComputePools.Describe(ctx, sdk.NewAccountObjectIdentifier(computePool.Name))
+}
+
 func (v *ComputePoolsView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{
 		{
 			Description: "Suspend",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				name := v.table.GetCell(r, 0).Text
-				_, err := v.connectionManager.GetClient().SDKClient.GetConn().ExecContext(ctx, fmt.Sprintf("ALTER COMPUTE POOL %s SUSPEND", name))
+				computePool, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
+				err := v.connectionManager.GetClient().ComputePools.AlterState(ctx, computePool, &snowflake.AlterComputePoolStateOptions{
+					StateAction: snowflake.ComputePoolStateActionSuspend,
+				})
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.status.SetMessage(fmt.Sprintf("Suspended compute pool %s", name))
+				applicationState.status.SetMessage(fmt.Sprintf("Suspended compute pool %s", computePool.Name()))
 				return nil
 			},
 		},
@@ -91,15 +119,20 @@ func (v *ComputePoolsView) GetBindings(ctx context.Context, applicationState *Ap
 			Description: "Resume",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				name := v.table.GetCell(r, 0).Text
-				_, err := v.connectionManager.GetClient().SDKClient.GetConn().ExecContext(ctx, fmt.Sprintf("ALTER COMPUTE POOL %s RESUME", name))
+				computePool, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
+				err := v.connectionManager.GetClient().ComputePools.AlterState(ctx, computePool, &snowflake.AlterComputePoolStateOptions{
+					StateAction: snowflake.ComputePoolStateActionResume,
+				})
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.status.SetMessage(fmt.Sprintf("Resumed compute pool %s", name))
+				applicationState.status.SetMessage(fmt.Sprintf("Resumed compute pool %s", computePool.Name()))
 				return nil
 			},
 		},
@@ -107,18 +140,19 @@ func (v *ComputePoolsView) GetBindings(ctx context.Context, applicationState *Ap
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				computePoolName := v.table.GetCell(r, 0).Text
-				computePool := sdk.NewAccountObjectIdentifier(computePoolName)
-
-				message := fmt.Sprintf("Drop compute pool %s?", computePool.FullyQualifiedName())
+				computePool, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "compute pool", computePool, func(action bool) {
 					if action {
 						err := v.connectionManager.GetClient().ComputePools.Drop(ctx, computePool, &snowflake.DropComputePoolOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped compute pool %s", computePool.FullyQualifiedName()))
 					} else {
@@ -131,18 +165,18 @@ func (v *ComputePoolsView) GetBindings(ctx context.Context, applicationState *Ap
 		},
 		{
 			Description: "Services",
-			Event:       tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone),
-			Hidden:      true,
+			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				cell := v.table.GetCell(r, 0)
-
-				computePool := sdk.NewAccountObjectIdentifier(cell.Text)
+				computePool, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewServicesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ServicesOptions{
 							ComputePool: &computePool,
 						},
@@ -156,16 +190,15 @@ func (v *ComputePoolsView) GetBindings(ctx context.Context, applicationState *Ap
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				computePoolName := v.table.GetCell(r, 0).Text
-				computePool := sdk.NewAccountObjectIdentifier(
-					computePoolName,
-				)
+				computePool, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeComputePool,
 							ObjectIdentifier: computePool,
@@ -177,7 +210,3 @@ func (v *ComputePoolsView) GetBindings(ctx context.Context, applicationState *Ap
 		},
 	}
 }
-
-func (v *ComputePoolsView) GetRender() tview.Primitive {
-	return v.table
-}
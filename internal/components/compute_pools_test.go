@@ -0,0 +1,45 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/costrouc/snowctl/internal/snowflake/fake"
+	"github.com/gdamore/tcell/v2"
+)
+
+func computePoolState(backend *fake.Backend, name string) string {
+	for _, computePool := range backend.Fixtures().ComputePools {
+		if computePool.Name == name {
+			return computePool.State
+		}
+	}
+	return ""
+}
+
+func TestComputePoolsSuspendResume(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	backend.Seed(func(fixtures *fake.Fixtures) {
+		fixtures.ComputePools[0].State = "ACTIVE"
+	})
+	a := startApplication(t, snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client()), "compute pools")
+	a.waitForRows(t)
+
+	tests := []struct {
+		key    rune
+		state  string
+		status string
+	}{
+		{'s', "SUSPENDED", "Suspended compute pool POOL"},
+		{'r', "IDLE", "Resumed compute pool POOL"},
+	}
+	for _, test := range tests {
+		a.press(tcell.KeyRune, test.key, tcell.ModNone)
+		a.eventually(t, test.status, func() bool {
+			return a.ApplicationState.status.view.GetText(true) == test.status
+		})
+		if got := computePoolState(backend, "POOL"); got != test.state {
+			t.Errorf("got state %s after %c, want %s", got, test.key, test.state)
+		}
+	}
+}
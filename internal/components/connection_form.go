@@ -0,0 +1,141 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/rivo/tview"
+)
+
+// connectionKinds are the configuration formats a new connection can be
+// written to, connections.toml and the snowsql configuration
+var connectionKinds = []string{"snowflake", "snowsql"}
+
+// ConnectionForm adds or edits a connection, only the keys changed in the
+// form are written back to its file
+type ConnectionForm struct {
+	form *tview.Form
+
+	// name is the edited connection, snowflake.NAME or snowsql.NAME, empty
+	// when adding one of kind
+	name   string
+	kind   string
+	fields []snowflake.ConnectionField
+}
+
+func NewConnectionForm() *ConnectionForm {
+	form := tview.NewForm()
+	form.SetBorder(true)
+
+	return &ConnectionForm{
+		form: form,
+	}
+}
+
+// Edit fills the form with the keys of the named connection, an empty name
+// adds a connection
+func (f *ConnectionForm) Edit(ctx context.Context, applicationState *ApplicationState, name string) error {
+	f.name = name
+	f.kind = connectionKinds[0]
+	return f.build(ctx, applicationState, "")
+}
+
+// build adds the fields of the connection kind to the form, newName is the
+// name typed so far when the kind of a new connection changes
+func (f *ConnectionForm) build(ctx context.Context, applicationState *ApplicationState, newName string) error {
+	name := f.name
+	if name == "" {
+		name = f.kind + "."
+	}
+	fields, err := applicationState.ConnectionManager().ConnectionFields(name)
+	if err != nil {
+		return err
+	}
+	f.fields = fields
+
+	f.form.Clear(true)
+	if f.name == "" {
+		f.form.SetTitle(" new connection ")
+		f.form.AddDropDown("type", connectionKinds, slices.Index(connectionKinds, f.kind), func(option string, index int) {
+			if option == f.kind {
+				return
+			}
+			f.kind = option
+			typed := f.form.GetFormItemByLabel("name").(*tview.InputField).GetText()
+			// the form is rebuilt once the drop down is done with the event
+			applicationState.Application.QueueUpdateDraw(func() {
+				if err := f.build(ctx, applicationState, typed); err != nil {
+					applicationState.status.SetError(err)
+				}
+			})
+		})
+		f.form.AddInputField("name", newName, 0, nil, nil)
+	} else {
+		f.form.SetTitle(fmt.Sprintf(" connection %s ", f.name))
+	}
+
+	for _, field := range fields {
+		input := tview.NewInputField().SetLabel(field.Key).SetText(field.Value)
+		if field.Secret {
+			input.SetMaskCharacter('*')
+		}
+		f.form.AddFormItem(input)
+	}
+
+	f.form.AddButton("Save", func() {
+		f.save(ctx, applicationState)
+	})
+	f.form.AddButton("Cancel", func() {
+		applicationState.Pages.SwitchToPage("main")
+		applicationState.UpdateView(ctx, false)
+	})
+	f.form.SetFocus(0)
+
+	return nil
+}
+
+// save writes the changed keys, the form stays open on errors
+func (f *ConnectionForm) save(ctx context.Context, applicationState *ApplicationState) {
+	name := f.name
+	if name == "" {
+		newName := strings.TrimSpace(f.form.GetFormItemByLabel("name").(*tview.InputField).GetText())
+		if newName == "" {
+			applicationState.status.SetError(fmt.Errorf("a new connection needs a name"))
+			return
+		}
+		name = f.kind + "." + newName
+		if applicationState.ConnectionManager().HasConnection(name) {
+			applicationState.status.SetError(fmt.Errorf("connection %s already exists", name))
+			return
+		}
+	}
+
+	changed := make(map[string]string)
+	for _, field := range f.fields {
+		value := f.form.GetFormItemByLabel(field.Key).(*tview.InputField).GetText()
+		if value != field.Value {
+			changed[field.Key] = value
+		}
+	}
+
+	if len(changed) == 0 && f.name != "" {
+		applicationState.status.SetMessage(fmt.Sprintf("No changes to connection %s", name))
+	} else {
+		path, err := applicationState.ConnectionManager().SaveConnection(name, changed)
+		if err != nil {
+			applicationState.status.SetError(err)
+			return
+		}
+		applicationState.status.SetMessage(fmt.Sprintf("Saved connection %s to %s", name, path))
+	}
+
+	applicationState.Pages.SwitchToPage("main")
+	applicationState.UpdateView(ctx, false)
+}
+
+func (f *ConnectionForm) GetRender() *tview.Form {
+	return f.form
+}
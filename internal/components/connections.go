@@ -1,18 +1,26 @@
 package components
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ConnectionsView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *ConnectionsOptions
+
+	// recheck is set by the check binding to bypass the cached health of
+	// the next load, it is cleared on the load goroutine
+	recheck atomic.Bool
 }
 
 type ConnectionsOptions struct {
@@ -21,41 +29,103 @@ type ConnectionsOptions struct {
 func NewConnectionsView(connectionManager *snowflake.ConnectionManager, opts *ConnectionsOptions) *ConnectionsView {
 	connections := &ConnectionsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	connections.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return connections
 }
 
-func (v *ConnectionsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating users data %w", err)
-	}
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "connections",
+		Aliases:     []string{"connection"},
+		Description: "configured snowflake connections",
+		// listing them logs into every account to check its health
+		Interactive: true,
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewConnectionsView(cm, &ConnectionsOptions{})
+		},
+		States: []StateRule{
+			{Column: "Status", Values: []string{"reachable"}, Style: "ok"},
+			{Column: "Status", Values: []string{"interactive"}, Style: "warning"},
+			{Column: "Status", Values: []string{"unreachable"}, Style: "bad"},
+		},
+	})
+}
 
-	updateTable(v.table, table)
+// RefreshInterval is zero as connections are read from local configuration
+func (t *ConnectionsView) RefreshInterval() time.Duration {
+	return 0
+}
 
-	return nil
+// connectionRecord is the record of a connection row, the connection
+// settings are left out as they hold credentials
+type connectionRecord struct {
+	Connection    string
+	Status        string
+	Latency       string
+	Authenticator string
+	Account       string
+	Locator       string
+	Region        string
+	User          string
+	Role          string
+	File          string
+	Error         string
 }
 
-func (t *ConnectionsView) getData(ctx context.Context) (*Table, error) {
-	columns := []string{"Connection", "Account", "Region", "User", "Role"}
-	rows := make([][]string, 0)
-
-	for name, connection := range t.connectionManager.AvailableClients() {
-		rows = append(rows, []string{
-			name,
-			connection.Account,
-			connection.Region,
-			connection.User,
-			connection.Role,
+// GetData checks every connection concurrently, the results are cached by
+// the connection manager until rechecked
+func (t *ConnectionsView) GetData(ctx context.Context) (*registry.Table, error) {
+	columns := []string{"Connection", "Status", "Latency", "Auth", "Account", "Locator", "Region", "User", "Role", "File"}
+	rows := make([]registry.Row, 0)
+
+	connections := t.connectionManager.Connections()
+	health := t.connectionManager.CheckConnections(ctx, connections, t.recheck.Swap(false))
+
+	for _, connection := range connections {
+		record := connectionRecord{
+			Connection:    connection.Name,
+			Authenticator: connection.Authenticator,
+			Account:       connection.Settings.Account,
+			Region:        connection.Settings.Region,
+			User:          connection.Settings.User,
+			Role:          connection.Settings.Role,
+			File:          connection.File,
+		}
+		switch result := health[connection.Name]; {
+		case result == nil:
+		case result.Skipped:
+			record.Status = "interactive"
+		case result.Err != nil:
+			record.Status = "unreachable"
+			record.Error = result.Err.Error()
+		default:
+			record.Status = "reachable"
+			record.Latency = result.Check.Latency.Round(time.Millisecond).String()
+			record.Locator = result.Check.Session.Account
+			record.Region = cmp.Or(result.Check.Session.Region, record.Region)
+		}
+
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				record.Connection,
+				record.Status,
+				record.Latency,
+				record.Authenticator,
+				record.Account,
+				record.Locator,
+				record.Region,
+				record.User,
+				record.Role,
+				record.File,
+			},
+			Record: record,
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "connections",
 		Columns: columns,
 		Rows:    rows,
@@ -69,20 +139,130 @@ func (t *ConnectionsView) GetBindings(ctx context.Context, applicationState *App
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				cell := t.table.GetCell(r, 0)
-				err := t.connectionManager.SetClient(cell.Text)
+				connection, ok := selectedRecord[connectionRecord](t.TableView)
+				if !ok {
+					return nil
+				}
+				if err := applicationState.useConnection(ctx, connection.Connection); err != nil {
+					applicationState.status.SetError(err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: "Open Tab",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'o', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				connection, ok := selectedRecord[connectionRecord](t.TableView)
+				if !ok {
+					return nil
+				}
+				if err := applicationState.openTab(ctx, connection.Connection); err != nil {
+					applicationState.status.SetError(err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: "Add",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				if err := applicationState.editConnection(ctx, ""); err != nil {
+					applicationState.status.SetError(err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: "Edit",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				connection, ok := selectedRecord[connectionRecord](t.TableView)
+				if !ok {
+					return nil
+				}
+				if err := applicationState.editConnection(ctx, connection.Connection); err != nil {
+					applicationState.status.SetError(err)
+				}
+				return nil
+			},
+		},
+		{
+			Description: "Test",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				connection, ok := selectedRecord[connectionRecord](t.TableView)
+				if !ok {
+					return nil
+				}
+				config, err := t.connectionManager.ConnectionConfig(connection.Connection)
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.context.Update(ctx)
+
+				// the session is opened outside of the UI goroutine and the
+				// current client is left as is
+				applicationState.status.SetMessage(fmt.Sprintf("Testing connection %s", connection.Connection))
+				go func() {
+					check, err := snowflake.TestConnection(ctx, config)
+					t.connectionManager.SetConnectionHealth(connection.Connection, check, err)
+					applicationState.Application.QueueUpdateDraw(func() {
+						if err != nil {
+							applicationState.status.SetError(fmt.Errorf("testing connection %s %w", connection.Connection, err))
+						} else {
+							applicationState.status.SetMessage(fmt.Sprintf("Connection %s ok in %s", connection.Connection, check.Latency.Round(time.Millisecond)))
+						}
+						if applicationState.tab.history[len(applicationState.tab.history)-1] == Component(t) {
+							applicationState.UpdateView(ctx, false)
+						}
+					})
+				}()
 				return nil
 			},
 		},
-	}
-}
+		{
+			Description: "Check",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				t.recheck.Store(true)
+				applicationState.status.SetMessage("Checking connections")
+				applicationState.UpdateView(ctx, false)
+				return nil
+			},
+		},
+		{
+			Description: "Delete",
+			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				connection, ok := selectedRecord[connectionRecord](t.TableView)
+				if !ok {
+					return nil
+				}
+
+				message := fmt.Sprintf("Delete connection %s from its configuration file?", connection.Connection)
+
+				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+					if action {
+						path, err := t.connectionManager.DeleteConnection(connection.Connection)
+						if err != nil {
+							applicationState.status.SetError(err)
+							return
+						}
+						applicationState.status.SetMessage(fmt.Sprintf("Deleted connection %s from %s", connection.Connection, path))
+					} else {
+						applicationState.status.SetMessage(fmt.Sprintf("Canceled delete connection %s", connection.Connection))
+					}
+				})
 
-func (v *ConnectionsView) GetRender() tview.Primitive {
-	return v.table
+				return nil
+			},
+		},
+	}
 }
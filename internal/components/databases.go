@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type DatabasesView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *DatabasesOptions
 }
@@ -20,46 +21,48 @@ type DatabasesOptions struct{}
 
 func NewDatabasesView(connectionManager *snowflake.ConnectionManager, opts *DatabasesOptions) *DatabasesView {
 	databases := &DatabasesView{
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		connectionManager: connectionManager,
 		options:           opts,
 	}
 
-	databases.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return databases
 }
 
-func (v *DatabasesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		fmt.Errorf("updating users data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "databases",
+		Aliases:     []string{"database", "db"},
+		Description: "databases of the account",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewDatabasesView(cm, &DatabasesOptions{})
+		},
+	})
 }
 
-func (v *DatabasesView) getData(ctx context.Context) (*Table, error) {
-	databases, err := v.connectionManager.GetClient().SDKClient.Databases.Show(ctx, &sdk.ShowDatabasesOptions{})
+func (v *DatabasesView) GetData(ctx context.Context) (*registry.Table, error) {
+	databases, err := v.connectionManager.GetClient().Databases.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show databases %w", err)
 	}
 
 	columns := []string{"Name", "Owner", "Kind", "Comment"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, database := range databases {
-		rows = append(rows, []string{
-			database.Name,
-			database.Owner,
-			database.Kind,
-			database.Comment,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				database.Name,
+				database.Owner,
+				database.Kind,
+				database.Comment,
+			},
+			Record:     database,
+			Identifier: sdk.NewAccountObjectIdentifier(database.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "databases",
 		Columns: columns,
 		Rows:    rows,
@@ -72,15 +75,18 @@ func (v *DatabasesView) GetBindings(ctx context.Context, applicationState *Appli
 			Description: "Use Database",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				cell := v.table.GetCell(r, 0)
-				_, err := v.connectionManager.GetClient().SDKClient.GetConn().ExecContext(ctx, fmt.Sprintf("USE DATABASE %s", cell.Text))
+				database, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
+				err := v.connectionManager.GetClient().Sessions.UseDatabase(ctx, database)
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.context.Update(ctx)
+				applicationState.updateContext(ctx)
 				return nil
 			},
 		},
@@ -89,15 +95,17 @@ func (v *DatabasesView) GetBindings(ctx context.Context, applicationState *Appli
 			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				name := v.table.GetCell(r, 0).Text
+				database, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewSchemasView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&SchemasOptions{
-							Database: sdk.String(name),
+							Database: sdk.String(database.Name()),
 						},
 					),
 				)
@@ -109,16 +117,15 @@ func (v *DatabasesView) GetBindings(ctx context.Context, applicationState *Appli
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				database := sdk.NewAccountObjectIdentifier(
-					databaseName,
-				)
+				database, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewNetworkRulesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&NetworkRulesOptions{
 							Database: &database,
 						},
@@ -132,16 +139,15 @@ func (v *DatabasesView) GetBindings(ctx context.Context, applicationState *Appli
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				database := sdk.NewAccountObjectIdentifier(
-					databaseName,
-				)
+				database, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeDatabase,
 							ObjectIdentifier: database,
@@ -155,18 +161,19 @@ func (v *DatabasesView) GetBindings(ctx context.Context, applicationState *Appli
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				database := sdk.NewAccountObjectIdentifier(databaseName)
-
-				message := fmt.Sprintf("Drop database %s?", database.FullyQualifiedName())
+				database, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "database", database, func(action bool) {
 					if action {
-						err := v.connectionManager.GetClient().SDKClient.Databases.Drop(ctx, database, &sdk.DropDatabaseOptions{})
+						err := v.connectionManager.GetClient().Databases.Drop(ctx, database, &snowflake.DropDatabaseOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped database %s", database.FullyQualifiedName()))
 					} else {
@@ -179,7 +186,3 @@ func (v *DatabasesView) GetBindings(ctx context.Context, applicationState *Appli
 		},
 	}
 }
-
-func (v *DatabasesView) GetRender() tview.Primitive {
-	return v.table
-}
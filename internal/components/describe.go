@@ -0,0 +1,163 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/costrouc/snowctl/internal/clipboard"
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Describer is implemented by views whose objects have a DESCRIBE with more
+// details than the SHOW record of the row
+type Describer interface {
+	Describe(ctx context.Context, record any) (any, error)
+}
+
+// recordSelector is implemented by views keeping the records of their rows
+type recordSelector interface {
+	SelectedRecord() (any, string)
+}
+
+// DescribeView shows every field of a single object, one per row
+type DescribeView struct {
+	*TableView
+
+	options *DescribeOptions
+}
+
+type DescribeOptions struct {
+	// Name is shown in the title
+	Name   string
+	Record any
+	// Describe fetches the details of the record, the record itself is
+	// shown when nil
+	Describe func(ctx context.Context) (any, error)
+}
+
+func NewDescribeView(opts *DescribeOptions) *DescribeView {
+	describe := &DescribeView{
+		TableView: NewTableView(),
+		options:   opts,
+	}
+
+	return describe
+}
+
+// RefreshInterval is zero as the row the record was taken from is not
+// refreshed either
+func (v *DescribeView) RefreshInterval() time.Duration {
+	return 0
+}
+
+func (v *DescribeView) GetData(ctx context.Context) (*registry.Table, error) {
+	value := v.options.Record
+	if v.options.Describe != nil {
+		details, err := v.options.Describe(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("calling snowflake describe %s %w", v.options.Name, err)
+		}
+		value = details
+	}
+
+	columns := []string{"Field", "Value"}
+	rows := make([]registry.Row, 0)
+
+	fields := formatter.Fields(value)
+	for _, field := range fields {
+		text := field.Text
+		if field.Null {
+			text = "NULL"
+		}
+		rows = append(rows, registry.Row{
+			Cells:  []string{field.Name, text},
+			Record: field,
+		})
+	}
+
+	return &registry.Table{
+		Title:   scopedTitle("describe", v.options.Name),
+		Columns: columns,
+		Rows:    rows,
+	}, nil
+}
+
+func (v *DescribeView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
+	return []*KeyBinding{
+		{
+			Description: "Copy",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				field, ok := selectedRecord[formatter.Field](v.TableView)
+				if !ok {
+					return nil
+				}
+
+				if err := clipboard.Copy(field.Text); err != nil {
+					applicationState.status.SetError(err)
+					return nil
+				}
+				applicationState.status.SetMessage(fmt.Sprintf("Copied %s", field.Name))
+				return nil
+			},
+		},
+		{
+			Description: "Copy All",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'C', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				table := v.GetTable()
+				if table == nil {
+					return nil
+				}
+
+				var text strings.Builder
+				for _, row := range table.Rows {
+					field := row.Record.(formatter.Field)
+					fmt.Fprintf(&text, "%s: %s\n", field.Name, field.Text)
+				}
+
+				if err := clipboard.Copy(text.String()); err != nil {
+					applicationState.status.SetError(err)
+					return nil
+				}
+				applicationState.status.SetMessage(fmt.Sprintf("Copied %d fields of %s", len(table.Rows), v.options.Name))
+				return nil
+			},
+		},
+	}
+}
+
+// describeBindings open the selected row of the component in a describe
+// view, using the DESCRIBE of the object when the component is a Describer
+func describeBindings(ctx context.Context, applicationState *ApplicationState, selector recordSelector) []*KeyBinding {
+	return []*KeyBinding{
+		{
+			Description: "Describe",
+			Event:       tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				record, name := selector.SelectedRecord()
+				if record == nil {
+					applicationState.status.SetError(fmt.Errorf("nothing to describe in the current view"))
+					return nil
+				}
+
+				opts := &DescribeOptions{Name: name, Record: record}
+				if describer, ok := selector.(Describer); ok {
+					opts.Describe = func(ctx context.Context) (any, error) {
+						return describer.Describe(ctx, record)
+					}
+				}
+
+				applicationState.Push(ctx, NewDescribeView(opts))
+				return nil
+			},
+		},
+	}
+}
@@ -6,15 +6,16 @@ import (
 	"strconv"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
 	"github.com/pkg/browser"
-	"github.com/rivo/tview"
 )
 
 type EndpointsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *EndpointsOptions
 }
 
@@ -25,46 +26,51 @@ type EndpointsOptions struct {
 func NewEndpointsView(connectionManager *snowflake.ConnectionManager, opts *EndpointsOptions) *EndpointsView {
 	endpoints := &EndpointsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	endpoints.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return endpoints
 }
 
-func (v *EndpointsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating endpoints data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "endpoints",
+		Aliases:     []string{"endpoint"},
+		Description: "public and internal endpoints of a service",
+		Scopes:      []registry.ScopeArgument{registry.ScopeService},
+		Required:    []registry.ScopeArgument{registry.ScopeService},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewEndpointsView(cm, &EndpointsOptions{Service: scope.Service})
+		},
+	})
 }
 
-func (t *EndpointsView) getData(ctx context.Context, opts *EndpointsOptions) (*Table, error) {
+func (t *EndpointsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	tables, err := t.connectionManager.GetClient().Endpoints.Show(ctx, opts.Service)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show endpoints %w", err)
 	}
 
 	columns := []string{"Name", "Port", "Is Public", "Ingress URL"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, table := range tables {
-		rows = append(rows, []string{
-			table.Name,
-			table.Port,
-			strconv.FormatBool(table.IsPublic),
-			table.IngressUrl,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				table.Name,
+				table.Port,
+				strconv.FormatBool(table.IsPublic),
+				table.IngressUrl,
+			},
+			Record: table,
 		})
 	}
 
-	return &Table{
-		Title:   fmt.Sprintf("endpoints([pink]%s[blue])", opts.Service.FullyQualifiedName()),
+	return &registry.Table{
+		Title:   scopedTitle("endpoints", opts.Service.FullyQualifiedName()),
 		Columns: columns,
 		Rows:    rows,
 	}, nil
@@ -77,9 +83,11 @@ func (v *EndpointsView) GetBindings(ctx context.Context, applicationState *Appli
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'o', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				cell := v.table.GetCell(r, 3)
-				url := fmt.Sprintf("https://%s", cell.Text)
+				endpoint, ok := selectedRecord[snowflake.Endpoint](v.TableView)
+				if !ok {
+					return nil
+				}
+				url := fmt.Sprintf("https://%s", endpoint.IngressUrl)
 
 				err := browser.OpenURL(url)
 				if err != nil {
@@ -92,7 +100,3 @@ func (v *EndpointsView) GetBindings(ctx context.Context, applicationState *Appli
 		},
 	}
 }
-
-func (v *EndpointsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Exportable is implemented by components that render a Table
+type Exportable interface {
+	GetTable() *registry.Table
+}
+
+// Export asks for a file path and writes the current view to it in the
+// format given by the file extension
+type Export struct {
+	inputField *tview.InputField
+}
+
+func NewExport() *Export {
+	return &Export{
+		inputField: tview.NewInputField().
+			SetLabel("export to ").
+			SetPlaceholder("path ending in .json, .yaml, .csv or .txt").
+			SetFieldWidth(0),
+	}
+}
+
+func (e *Export) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
+	return []*KeyBinding{
+		{
+			Description: "Save",
+			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				path := e.Value()
+
+				applicationState.Pages.SwitchToPage("main")
+				defer applicationState.UpdateView(ctx, false)
+
+				component, ok := applicationState.tab.history[len(applicationState.tab.history)-1].(Exportable)
+				if !ok || component.GetTable() == nil {
+					applicationState.status.SetError(fmt.Errorf("current view cannot be exported"))
+					return nil
+				}
+
+				table := component.GetTable()
+				if err := exportTable(path, table); err != nil {
+					applicationState.status.SetError(err)
+					return nil
+				}
+
+				applicationState.status.SetMessage(fmt.Sprintf("Exported %d rows to %s", len(table.Rows), path))
+				return nil
+			},
+		},
+	}
+}
+
+func exportTable(path string, table *registry.Table) error {
+	format, err := formatter.FormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating export file %w", err)
+	}
+	defer f.Close()
+
+	err = formatter.Write(f, format, table.Data())
+	if err != nil {
+		return fmt.Errorf("writing export file %w", err)
+	}
+
+	return f.Close()
+}
+
+// Reset proposes a csv file named after the view
+func (e *Export) Reset(table *registry.Table) {
+	name := "export"
+	if table != nil {
+		name, _, _ = strings.Cut(table.Title, "(")
+		name = strings.ReplaceAll(strings.TrimSpace(name), " ", "_")
+	}
+	e.inputField.SetText(name + ".csv")
+}
+
+func (e *Export) Value() string {
+	return e.inputField.GetText()
+}
+
+func (e *Export) GetRender() *tview.InputField {
+	return e.inputField
+}
@@ -0,0 +1,225 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+type FilterMode int
+
+const (
+	SubstringFilter FilterMode = iota
+	RegexFilter
+	FuzzyFilter
+)
+
+var filterModes = []FilterMode{SubstringFilter, RegexFilter, FuzzyFilter}
+
+func (m FilterMode) String() string {
+	switch m {
+	case RegexFilter:
+		return "regex"
+	case FuzzyFilter:
+		return "fuzzy"
+	}
+	return "substring"
+}
+
+// Filterable is implemented by views that narrow their rows with a filter
+type Filterable interface {
+	SetFilter(query string, mode FilterMode) error
+	GetFilter() (string, FilterMode)
+}
+
+// filterTerm is a single word of a filter, the rows must match every term
+type filterTerm struct {
+	// column is the index of the column the term is scoped to, -1 for any
+	column int
+	negate bool
+	match  func(text string) bool
+}
+
+// parseFilter splits the query on spaces into terms. A term prefixed with
+// `!` excludes the rows it matches and a `column:` prefix scopes it to a
+// column, column names are case insensitive and may use `_` for spaces.
+func parseFilter(query string, mode FilterMode, columns []string) ([]filterTerm, error) {
+	terms := make([]filterTerm, 0)
+	for _, word := range strings.Fields(query) {
+		term := filterTerm{column: -1}
+
+		if rest, ok := strings.CutPrefix(word, "!"); ok {
+			term.negate = true
+			word = rest
+		}
+
+		if name, pattern, ok := strings.Cut(word, ":"); ok {
+			for c, column := range columns {
+				if strings.EqualFold(strings.ReplaceAll(column, " ", "_"), name) {
+					term.column = c
+					word = pattern
+					break
+				}
+			}
+		}
+
+		if word == "" {
+			continue
+		}
+
+		match, err := filterMatcher(word, mode)
+		if err != nil {
+			return nil, err
+		}
+		term.match = match
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+func filterMatcher(pattern string, mode FilterMode) (func(text string) bool, error) {
+	switch mode {
+	case RegexFilter:
+		expression, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("parsing filter %w", err)
+		}
+		return expression.MatchString, nil
+	case FuzzyFilter:
+		pattern = strings.ToLower(pattern)
+		return func(text string) bool {
+			return fuzzyMatch(pattern, strings.ToLower(text))
+		}, nil
+	}
+
+	pattern = strings.ToLower(pattern)
+	return func(text string) bool {
+		return strings.Contains(strings.ToLower(text), pattern)
+	}, nil
+}
+
+// fuzzyMatch reports whether the characters of the pattern appear in order
+// in the text
+func fuzzyMatch(pattern string, text string) bool {
+	for _, r := range pattern {
+		index := strings.IndexRune(text, r)
+		if index < 0 {
+			return false
+		}
+		text = text[index+len(string(r)):]
+	}
+	return true
+}
+
+func (t filterTerm) matches(row []string) bool {
+	matched := false
+	for c, cell := range row {
+		if (t.column < 0 || t.column == c) && t.match(cell) {
+			matched = true
+			break
+		}
+	}
+	return matched != t.negate
+}
+
+// filterRows returns the indices of the table rows matching every term
+func filterRows(table *registry.Table, terms []filterTerm) []int {
+	order := make([]int, 0, len(table.Rows))
+
+rows:
+	for r, row := range table.Rows {
+		for _, term := range terms {
+			if !term.matches(row.Cells) {
+				continue rows
+			}
+		}
+		order = append(order, r)
+	}
+	return order
+}
+
+// Filter is the input bar narrowing the rows of the current view as the
+// query is typed
+type Filter struct {
+	inputField *tview.InputField
+	mode       FilterMode
+	target     Filterable
+}
+
+func NewFilter() *Filter {
+	filter := &Filter{
+		inputField: tview.NewInputField().
+			SetPlaceholder("text, column:text, !text").
+			SetFieldWidth(0),
+	}
+
+	filter.inputField.SetChangedFunc(func(text string) {
+		filter.apply()
+	})
+
+	return filter
+}
+
+func (f *Filter) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
+	return []*KeyBinding{
+		{
+			Description: "Apply",
+			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				applicationState.Pages.SwitchToPage("main")
+				applicationState.UpdateView(ctx, false)
+				return nil
+			},
+		},
+		{
+			Description: "Filter Mode",
+			Event:       tcell.NewEventKey(tcell.KeyCtrlO, 0, tcell.ModCtrl),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				f.mode = filterModes[(int(f.mode)+1)%len(filterModes)]
+				f.apply()
+				return nil
+			},
+		},
+	}
+}
+
+// Reset starts filtering the target from its current filter
+func (f *Filter) Reset(target Filterable) {
+	f.target = target
+	query, mode := target.GetFilter()
+	f.mode = mode
+	f.inputField.SetText(query)
+	f.apply()
+}
+
+// Clear removes the filter from the target
+func (f *Filter) Clear() {
+	f.inputField.SetText("")
+}
+
+// apply filters the target, an invalid query is shown in red and leaves
+// the rows filtered by the last valid query
+func (f *Filter) apply() {
+	f.inputField.SetLabel(fmt.Sprintf("/%s ", f.mode))
+	if f.target == nil {
+		return
+	}
+
+	color := currentTheme.Text
+	if err := f.target.SetFilter(f.inputField.GetText(), f.mode); err != nil {
+		color = currentTheme.Error
+	}
+	f.inputField.SetFieldTextColor(color)
+}
+
+func (f *Filter) GetRender() *tview.InputField {
+	return f.inputField
+}
@@ -0,0 +1,95 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+// testGrants is a table with a column name holding a space
+func testGrants() *registry.Table {
+	return &registry.Table{
+		Columns: []string{"Name", "Granted To", "Owner"},
+		Rows: []registry.Row{
+			{Cells: []string{"ANALYTICS_DB", "ROLE", "SYSADMIN"}},
+			{Cells: []string{"RAW_DB", "ROLE", "ACCOUNTADMIN"}},
+			{Cells: []string{"REPORTING", "USER", "SYSADMIN"}},
+			{Cells: []string{"sysadmin_notes", "ROLE", "ALICE"}},
+		},
+	}
+}
+
+func TestFilterRows(t *testing.T) {
+	tests := []struct {
+		query string
+		mode  FilterMode
+		want  []int
+	}{
+		{"", SubstringFilter, []int{0, 1, 2, 3}},
+		{"sysadmin", SubstringFilter, []int{0, 2, 3}},
+		{"owner:sysadmin", SubstringFilter, []int{0, 2}},
+		{"OWNER:SYSADMIN", SubstringFilter, []int{0, 2}},
+		{"granted_to:user", SubstringFilter, []int{2}},
+		{"!sysadmin", SubstringFilter, []int{1}},
+		{"!owner:sysadmin", SubstringFilter, []int{1, 3}},
+		{"db owner:sysadmin", SubstringFilter, []int{0}},
+		{"  db   !raw  ", SubstringFilter, []int{0}},
+		// an unknown column is part of the text
+		{"missing:db", SubstringFilter, []int{}},
+		// a column alone matches every row
+		{"owner:", SubstringFilter, []int{0, 1, 2, 3}},
+		{"^r", RegexFilter, []int{0, 1, 2, 3}},
+		{"name:^r", RegexFilter, []int{1, 2}},
+		{"name:_db$", RegexFilter, []int{0, 1}},
+		{"!name:_db$", RegexFilter, []int{2, 3}},
+		{"rptg", FuzzyFilter, []int{2}},
+		{"name:adb", FuzzyFilter, []int{0, 1}},
+		{"!owner:sdmn", FuzzyFilter, []int{1, 3}},
+		{"name:ba", FuzzyFilter, []int{}},
+	}
+	for _, test := range tests {
+		t.Run(test.mode.String()+" "+test.query, func(t *testing.T) {
+			table := testGrants()
+			terms, err := parseFilter(test.query, test.mode, table.Columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filterRows(table, terms); !slices.Equal(got, test.want) {
+				t.Errorf("got rows %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterInvalidRegex(t *testing.T) {
+	for _, query := range []string{"(", "name:[a", "!*"} {
+		if _, err := parseFilter(query, RegexFilter, []string{"Name"}); err == nil {
+			t.Errorf("parsed the invalid regex %q", query)
+		}
+		// the other modes match the text as typed
+		if _, err := parseFilter(query, SubstringFilter, []string{"Name"}); err != nil {
+			t.Errorf("substring filter %q %v", query, err)
+		}
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"abc", "abc", true},
+		{"ac", "abc", true},
+		{"ca", "abc", false},
+		{"aa", "a", false},
+		{"éb", "xéyb", true},
+	}
+	for _, test := range tests {
+		if got := fuzzyMatch(test.pattern, test.text); got != test.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %t, want %t", test.pattern, test.text, got, test.want)
+		}
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type GrantsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *GrantsOptions
 }
 
@@ -23,66 +24,71 @@ type GrantsOptions struct {
 func NewGrantsView(connectionManager *snowflake.ConnectionManager, opts *GrantsOptions) *GrantsView {
 	grants := &GrantsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	grants.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return grants
 }
 
-func (v *GrantsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating service instances data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "grants",
+		Aliases:     []string{"grant"},
+		Description: "grants to a role or on an object",
+		Scopes:      []registry.ScopeArgument{registry.ScopeObject},
+		Required:    []registry.ScopeArgument{registry.ScopeObject},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewGrantsView(cm, &GrantsOptions{
+				ObjectType:       scope.ObjectType,
+				ObjectIdentifier: scope.Object,
+			})
+		},
+	})
 }
 
-func (t *GrantsView) getData(ctx context.Context, opts *GrantsOptions) (*Table, error) {
-	title := fmt.Sprintf("grants([pink]%s[blue])", opts.ObjectIdentifier.FullyQualifiedName())
+func (t *GrantsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
 
-	var snowflakeOpts sdk.ShowGrantOptions
+	title := scopedTitle("grants", opts.ObjectIdentifier.FullyQualifiedName())
+
+	var snowflakeOpts snowflake.ShowGrantOptions
 	switch opts.ObjectType {
 	case sdk.ObjectTypeRole:
-		snowflakeOpts = sdk.ShowGrantOptions{
-			To: &sdk.ShowGrantsTo{
-				Role: sdk.NewAccountObjectIdentifier(opts.ObjectIdentifier.Name()),
-			},
+		role := sdk.NewAccountObjectIdentifier(opts.ObjectIdentifier.Name())
+		snowflakeOpts = snowflake.ShowGrantOptions{
+			To: &role,
 		}
 	default:
-		snowflakeOpts = sdk.ShowGrantOptions{
-			On: &sdk.ShowGrantsOn{
-				Object: &sdk.Object{
-					ObjectType: opts.ObjectType,
-					Name:       opts.ObjectIdentifier,
-				},
+		snowflakeOpts = snowflake.ShowGrantOptions{
+			On: &sdk.Object{
+				ObjectType: opts.ObjectType,
+				Name:       opts.ObjectIdentifier,
 			},
 		}
 	}
 
-	grants, err := t.connectionManager.GetClient().SDKClient.Grants.Show(ctx, &snowflakeOpts)
+	grants, err := t.connectionManager.GetClient().Grants.Show(ctx, &snowflakeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show grants instances %w", err)
 	}
 
 	columns := []string{"Grant On", "Privilege", "Grant To", "Name"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, grant := range grants {
-		rows = append(rows, []string{
-			string(grant.GrantedOn),
-			grant.Privilege,
-			string(grant.GrantedTo),
-			grant.GranteeName.FullyQualifiedName(),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				string(grant.GrantedOn),
+				grant.Privilege,
+				string(grant.GrantedTo),
+				grant.GranteeName.FullyQualifiedName(),
+			},
+			Record: grant,
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -92,7 +98,3 @@ func (t *GrantsView) getData(ctx context.Context, opts *GrantsOptions) (*Table,
 func (v *GrantsView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{}
 }
-
-func (v *GrantsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -0,0 +1,117 @@
+package components
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/gdamore/tcell/v2"
+)
+
+// HelpView lists the registered resources
+type HelpView struct {
+	*TableView
+
+	connectionManager *snowflake.ConnectionManager
+}
+
+func NewHelpView(connectionManager *snowflake.ConnectionManager) *HelpView {
+	help := &HelpView{
+		connectionManager: connectionManager,
+		TableView:         NewTableView(),
+	}
+
+	return help
+}
+
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "help",
+		Description: "this list of resources",
+		Interactive: true,
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewHelpView(cm)
+		},
+	})
+}
+
+// RefreshInterval is zero as the resources never change
+func (v *HelpView) RefreshInterval() time.Duration {
+	return 0
+}
+
+// helpRecord is the record of a resource row
+type helpRecord struct {
+	Resource    string
+	Aliases     []string
+	Scopes      []string
+	Description string
+}
+
+func (v *HelpView) GetData(ctx context.Context) (*registry.Table, error) {
+	columns := []string{"Resource", "Aliases", "Scope", "Description"}
+	rows := make([]registry.Row, 0)
+
+	for _, resource := range registry.Resources() {
+		scopes := make([]string, 0)
+		for _, argument := range resource.Scopes {
+			scope := string(argument)
+			if slices.Contains(resource.Required, argument) {
+				scope += " (required)"
+			}
+			scopes = append(scopes, scope)
+		}
+
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				resource.Name,
+				strings.Join(resource.Aliases, ", "),
+				strings.Join(scopes, ", "),
+				resource.Description,
+			},
+			Record: helpRecord{
+				Resource:    resource.Name,
+				Aliases:     resource.Aliases,
+				Scopes:      scopes,
+				Description: resource.Description,
+			},
+		})
+	}
+
+	return &registry.Table{
+		Title:   "help",
+		Columns: columns,
+		Rows:    rows,
+	}, nil
+}
+
+func (v *HelpView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
+	return []*KeyBinding{
+		{
+			Description: "Open",
+			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				record, ok := selectedRecord[helpRecord](v.TableView)
+				if !ok {
+					return nil
+				}
+				resource := registry.FindResource(record.Resource)
+				if resource == nil {
+					return nil
+				}
+
+				component, err := openResource(resource, v.connectionManager, &registry.Scope{})
+				if err != nil {
+					applicationState.status.SetError(err)
+					return nil
+				}
+				applicationState.Push(ctx, component)
+				return nil
+			},
+		},
+	}
+}
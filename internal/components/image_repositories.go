@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ImageRepositoriesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ImageRepositoriesOptions
 }
 
@@ -24,36 +25,40 @@ type ImageRepositoriesOptions struct {
 func NewImageRepositoriesView(connectionManager *snowflake.ConnectionManager, opts *ImageRepositoriesOptions) *ImageRepositoriesView {
 	imageRepositories := &ImageRepositoriesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	imageRepositories.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return imageRepositories
 }
 
-func (v *ImageRepositoriesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating service instances data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "image repositories",
+		Aliases:     []string{"image repository", "ir"},
+		Description: "image repositories for container images",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewImageRepositoriesView(cm, &ImageRepositoriesOptions{
+				Database: scope.Database,
+				Schema:   scope.Schema,
+			})
+		},
+	})
 }
 
-func (t *ImageRepositoriesView) getData(ctx context.Context, opts *ImageRepositoriesOptions) (*Table, error) {
+func (t *ImageRepositoriesView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	title := "image repositories"
 	snowflakeOpts := snowflake.ShowImageRepositoryOptions{}
 	if opts.Database != nil {
 		snowflakeOpts.Database = opts.Database
-		title = fmt.Sprintf("image repositories([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		title = scopedTitle("image repositories", opts.Database.FullyQualifiedName())
 	}
 	if opts.Schema != nil {
 		snowflakeOpts.Schema = opts.Schema
-		title = fmt.Sprintf("image repositories([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		title = scopedTitle("image repositories", opts.Schema.FullyQualifiedName())
 	}
 
 	imagerepositories, err := t.connectionManager.GetClient().ImageRepositories.Show(ctx, &snowflakeOpts)
@@ -62,18 +67,22 @@ func (t *ImageRepositoriesView) getData(ctx context.Context, opts *ImageReposito
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Repository URL"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, serviceInstance := range imagerepositories {
-		rows = append(rows, []string{
-			serviceInstance.DatabaseName,
-			serviceInstance.SchemaName,
-			serviceInstance.Name,
-			serviceInstance.RepositoryURL,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				serviceInstance.DatabaseName,
+				serviceInstance.SchemaName,
+				serviceInstance.Name,
+				serviceInstance.RepositoryURL,
+			},
+			Record:     serviceInstance,
+			Identifier: sdk.NewSchemaObjectIdentifier(serviceInstance.DatabaseName, serviceInstance.SchemaName, serviceInstance.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -87,18 +96,15 @@ func (v *ImageRepositoriesView) GetBindings(ctx context.Context, applicationStat
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				name := v.table.GetCell(r, 2).Text
-				imageRepository := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, name,
-				)
+				imageRepository, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeImageRepository,
 							ObjectIdentifier: imageRepository,
@@ -110,7 +116,3 @@ func (v *ImageRepositoriesView) GetBindings(ctx context.Context, applicationStat
 		},
 	}
 }
-
-func (v *ImageRepositoriesView) GetRender() tview.Primitive {
-	return v.table
-}
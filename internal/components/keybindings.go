@@ -34,7 +34,7 @@ func (k *KeyBindings) Update() {
 			}
 
 			binding := k.Bindings[currentIndex]
-			k.view.SetCell(r, c, tview.NewTableCell(fmt.Sprintf("[blue]<%s>: [grey]%s", binding.Name(), binding.Description)))
+			k.view.SetCell(r, c, tview.NewTableCell(fmt.Sprintf("%s<%s>: %s%s", colorTag(currentTheme.Key), binding.Name(), colorTag(currentTheme.Muted), binding.Description)))
 			currentIndex++
 		}
 	}
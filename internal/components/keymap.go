@@ -0,0 +1,185 @@
+package components
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+// Action is the ID of the binding in the keymap, its description in lower
+// case with dashes, `Release Directives` is release-directives
+func (k *KeyBinding) Action() string {
+	return strings.ToLower(strings.NewReplacer(" ", "-", "_", "-").Replace(k.Description))
+}
+
+// Keymap remaps key bindings by action ID. An action ID prefixed by a
+// resource name and a dot, `compute pools.services`, is only remapped in
+// the views of that resource.
+type Keymap map[string]*tcell.EventKey
+
+// keyPresets are keymaps to start from, emacs extends the default emacs
+// navigation keys and vim replaces them, moving the views bound to l
+var keyPresets = map[string]map[string]string{
+	"default": {},
+	"emacs": {
+		"top":    "alt-<",
+		"bottom": "alt->",
+		"search": "alt-x",
+	},
+	"vim": {
+		"down":       "j",
+		"up":         "k",
+		"left":       "h",
+		"right":      "l",
+		"bottom":     "G",
+		"page-down":  "ctrl-f",
+		"page-up":    "ctrl-b",
+		"streamlits": "L",
+		"logs":       "L",
+	},
+}
+
+// NewKeymap builds the keymap of a preset, default when empty, with the
+// keys given by action ID on top
+func NewKeymap(preset string, keys map[string]string) (Keymap, error) {
+	presetKeys, ok := keyPresets[cmp.Or(preset, "default")]
+	if !ok {
+		return nil, fmt.Errorf("unknown key preset %s, expected one of default, emacs, vim", preset)
+	}
+
+	keymap := make(Keymap)
+	for _, source := range []map[string]string{presetKeys, keys} {
+		for action, name := range source {
+			event, err := ParseKey(name)
+			if err != nil {
+				return nil, fmt.Errorf("parsing key of %s %w", action, err)
+			}
+
+			if resourceName, id, ok := strings.Cut(action, "."); ok {
+				resource := registry.FindResource(resourceName)
+				if resource == nil {
+					return nil, fmt.Errorf("unknown resource %s in key of %s", resourceName, action)
+				}
+				action = resource.Name + "." + id
+			}
+			keymap[action] = event
+		}
+	}
+	return keymap, nil
+}
+
+// ParseKey parses a key as shown in the key bindings bar, `s`, `C`,
+// `ctrl-d`, `alt-v`, `Enter` or `Esc`
+func ParseKey(name string) (*tcell.EventKey, error) {
+	key := name
+	modifiers := tcell.ModNone
+	for {
+		prefix, rest, ok := strings.Cut(key, "-")
+		if !ok || rest == "" {
+			break
+		}
+		switch strings.ToLower(prefix) {
+		case "ctrl":
+			modifiers |= tcell.ModCtrl
+		case "alt":
+			modifiers |= tcell.ModAlt
+		case "meta":
+			modifiers |= tcell.ModMeta
+		case "shift":
+			modifiers |= tcell.ModShift
+		default:
+			return nil, fmt.Errorf("unknown modifier %s in key %s", prefix, name)
+		}
+		key = rest
+	}
+
+	if strings.EqualFold(key, "space") {
+		key = " "
+	}
+
+	if utf8.RuneCountInString(key) == 1 {
+		r, _ := utf8.DecodeRuneInString(key)
+		if modifiers == tcell.ModCtrl {
+			lower := r | 0x20
+			if lower < 'a' || lower > 'z' {
+				return nil, fmt.Errorf("unsupported key %s, ctrl only combines with letters", name)
+			}
+			return tcell.NewEventKey(tcell.KeyCtrlA+tcell.Key(lower-'a'), 0, tcell.ModCtrl), nil
+		}
+		return tcell.NewEventKey(tcell.KeyRune, r, modifiers), nil
+	}
+
+	for k, keyName := range tcell.KeyNames {
+		if strings.EqualFold(keyName, key) && !strings.HasPrefix(keyName, "Ctrl-") {
+			return tcell.NewEventKey(k, 0, modifiers), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %s", name)
+}
+
+// apply sets the keys of the bindings remapped for the views of the
+// resource, empty outside of a resource view
+func (k Keymap) apply(resource string, bindings []*KeyBinding) {
+	for _, binding := range bindings {
+		if event, ok := k[resource+"."+binding.Action()]; ok && resource != "" {
+			binding.Event = event
+		} else if event, ok := k[binding.Action()]; ok {
+			binding.Event = event
+		}
+	}
+}
+
+// isTextKey reports whether the key is typed as text in an input field
+func isTextKey(event *tcell.EventKey) bool {
+	return event.Key() == tcell.KeyRune && event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl|tcell.ModMeta) == 0
+}
+
+// CheckBindings reports the keys bound to several actions in a view, where
+// only the first one can be triggered, and the keymap actions no view has
+func (a *ApplicationState) CheckBindings(ctx context.Context) error {
+	errs := make([]error, 0)
+	actions := make(map[string]bool)
+
+	check := func(view string, bindings []*KeyBinding) {
+		seen := make(map[string]*KeyBinding)
+		for _, binding := range bindings {
+			actions[binding.Action()] = true
+			if other, ok := seen[binding.Event.Name()]; ok {
+				errs = append(errs, fmt.Errorf("%s: %s is bound to both %s and %s", view, binding.Name(), other.Action(), binding.Action()))
+				continue
+			}
+			seen[binding.Event.Name()] = binding
+		}
+	}
+
+	for _, page := range []string{"search", "export", "filter", "connection", "confirm"} {
+		check(page, a.allBindingsFor(ctx, page, nil))
+	}
+	for _, resource := range registry.Resources() {
+		check(resource.Name, a.allBindingsFor(ctx, "main", resource.New(a.ConnectionManager(), registry.CompleteScope()).(Component)))
+	}
+	check("describe", a.allBindingsFor(ctx, "main", NewDescribeView(&DescribeOptions{})))
+
+	keymapActions := make([]string, 0, len(a.keymap))
+	for action := range a.keymap {
+		keymapActions = append(keymapActions, action)
+	}
+	slices.Sort(keymapActions)
+	for _, action := range keymapActions {
+		if _, id, ok := strings.Cut(action, "."); ok {
+			action = id
+		}
+		if !actions[action] {
+			errs = append(errs, fmt.Errorf("unknown action %s in keymap", action))
+		}
+	}
+	return errors.Join(errs...)
+}
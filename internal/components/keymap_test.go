@@ -0,0 +1,87 @@
+package components
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/costrouc/snowctl/internal/snowflake/fake"
+	"github.com/gdamore/tcell/v2"
+)
+
+// keymapApplication returns an application with the keymap, its views are
+// built but never run
+func keymapApplication(t *testing.T, keymap Keymap) *ApplicationState {
+	t.Helper()
+	backend := fake.NewBackend(&testFixtures)
+	a := NewApplication(snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client()))
+	a.SetKeymap(keymap)
+	return a
+}
+
+func TestKeyPresets(t *testing.T) {
+	for preset := range keyPresets {
+		t.Run(preset, func(t *testing.T) {
+			keymap, err := NewKeymap(preset, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := keymapApplication(t, keymap).CheckBindings(context.Background()); err != nil {
+				t.Errorf("preset %s has conflicting bindings\n%v", preset, err)
+			}
+		})
+	}
+}
+
+func TestCheckBindingsConflict(t *testing.T) {
+	// q already quits every view, only the views that drop conflict
+	keymap, err := NewKeymap("vim", map[string]string{"drop": "q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = keymapApplication(t, keymap).CheckBindings(context.Background())
+	if err == nil {
+		t.Fatal("conflicting bindings were not reported")
+	}
+	if !strings.Contains(err.Error(), "warehouses: q is bound to both quit and drop") {
+		t.Errorf("the warehouses conflict is missing from\n%v", err)
+	}
+	if strings.Contains(err.Error(), "roles:") {
+		t.Errorf("roles which cannot be dropped conflict\n%v", err)
+	}
+}
+
+func TestCheckBindingsUnknownAction(t *testing.T) {
+	keymap, err := NewKeymap("", map[string]string{"dorp": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = keymapApplication(t, keymap).CheckBindings(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unknown action dorp") {
+		t.Errorf("got %v, want the unknown action dorp", err)
+	}
+}
+
+func TestNewKeymap(t *testing.T) {
+	keymap, err := NewKeymap("vim", map[string]string{"down": "n", "compute pools.services": "S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the given keys take precedence over the preset
+	if got := keymap["down"].Name(); got != tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone).Name() {
+		t.Errorf("got down %s", got)
+	}
+	if _, ok := keymap["compute pools.services"]; !ok {
+		t.Errorf("the compute pools services key is missing from %v", keymap)
+	}
+
+	for _, keys := range []map[string]string{{"down": "ctrl-1"}, {"down": "hyper-j"}, {"missing.down": "j"}} {
+		if _, err := NewKeymap("", keys); err == nil {
+			t.Errorf("built the keymap of %v", keys)
+		}
+	}
+	if _, err := NewKeymap("nano", nil); err == nil {
+		t.Error("built the keymap of an unknown preset")
+	}
+}
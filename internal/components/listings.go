@@ -5,15 +5,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
 	"github.com/pkg/browser"
-	"github.com/rivo/tview"
 )
 
 type ListingsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ListingsOptions
 }
 
@@ -23,53 +25,70 @@ type ListingsOptions struct {
 func NewListingsView(connectionManager *snowflake.ConnectionManager, opts *ListingsOptions) *ListingsView {
 	listings := &ListingsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	listings.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return listings
 }
 
-func (v *ListingsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating listings data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "listings",
+		Aliases:     []string{"listing"},
+		Description: "marketplace listings",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewListingsView(cm, &ListingsOptions{})
+		},
+		States: []StateRule{
+			{Column: "review_state", Values: []string{"REJECTED"}, Style: "bad"},
+			{Column: "review_state", Values: []string{"PENDING"}, Style: "warning"},
+			{Column: "State", Values: []string{"PUBLISHED"}, Style: "ok"},
+			{Column: "State", Values: []string{"DRAFT", "UNPUBLISHED"}, Style: "warning"},
+		},
+	})
 }
 
-func (v *ListingsView) getData(ctx context.Context) (*Table, error) {
+func (v *ListingsView) GetData(ctx context.Context) (*registry.Table, error) {
 	listings, err := v.connectionManager.GetClient().Listings.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show listings %w", err)
 	}
 
 	columns := []string{"Name", "Global Name", "State", "Title", "Owner", "Profile"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, listing := range listings {
-		rows = append(rows, []string{
-			listing.Name,
-			listing.GlobalName,
-			listing.State,
-			listing.Title,
-			listing.Owner,
-			listing.Profile,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				listing.Name,
+				listing.GlobalName,
+				listing.State,
+				listing.Title,
+				listing.Owner,
+				listing.Profile,
+			},
+			Record:     listing,
+			Identifier: sdk.NewAccountObjectIdentifier(listing.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "listings",
 		Columns: columns,
 		Rows:    rows,
 	}, nil
 }
 
+// Describe fetches the details of the listing of a row
+func (v *ListingsView) Describe(ctx context.Context, record any) (any, error) {
+	listing, ok := record.(snowflake.Listing)
+	if !ok {
+		return nil, fmt.Errorf("unexpected listing record %T", record)
+	}
+	return v.connectionManager.GetClient().Listings.Describe(ctx, sdk.NewAccountObjectIdentifier(listing.Name))
+}
+
 func (v *ListingsView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{
 		{
@@ -77,13 +96,15 @@ func (v *ListingsView) GetBindings(ctx context.Context, applicationState *Applic
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'o', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				cell := v.table.GetCell(r, 1)
+				listing, ok := selectedRecord[snowflake.Listing](v.TableView)
+				if !ok {
+					return nil
+				}
 				url := fmt.Sprintf(
 					"https://app.snowflake.com/%s/%s/#/data/provider-studio/provider/listing/%s",
-					strings.ToLower(applicationState.context.OrganizationName),
-					strings.ToLower(applicationState.context.AccountName),
-					cell.Text,
+					strings.ToLower(applicationState.tab.context.OrganizationName),
+					strings.ToLower(applicationState.tab.context.AccountName),
+					listing.GlobalName,
 				)
 				err := browser.OpenURL(url)
 				if err != nil {
@@ -99,9 +120,11 @@ func (v *ListingsView) GetBindings(ctx context.Context, applicationState *Applic
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				cell := v.table.GetCell(r, 1)
-				err := browser.OpenURL(fmt.Sprintf("https://app.snowflake.com/marketplace/listing/%s", cell.Text))
+				listing, ok := selectedRecord[snowflake.Listing](v.TableView)
+				if !ok {
+					return nil
+				}
+				err := browser.OpenURL(fmt.Sprintf("https://app.snowflake.com/marketplace/listing/%s", listing.GlobalName))
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
@@ -112,7 +135,3 @@ func (v *ListingsView) GetBindings(ctx context.Context, applicationState *Applic
 		},
 	}
 }
-
-func (v *ListingsView) GetRender() tview.Primitive {
-	return v.table
-}
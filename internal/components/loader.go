@@ -0,0 +1,108 @@
+package components
+
+import (
+	"context"
+	"time"
+)
+
+// spinnerFrames are shown in the title of a view while its data is fetched
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// load is a fetch of the data of a component running outside of the UI
+// goroutine
+type load struct {
+	component Component
+	cancel    context.CancelFunc
+}
+
+// load fetches the data of the component in the background and renders it
+// on the UI goroutine, a load already in flight is cancelled first. The
+// spinner is left out for auto refreshes.
+func (a *ApplicationState) load(ctx context.Context, component Component, spinner bool) {
+	a.cancelLoad()
+	a.stopRefresh()
+
+	loadCtx, cancel := context.WithCancel(ctx)
+	current := &load{component: component, cancel: cancel}
+	a.loading = current
+
+	if spinner {
+		component.SetLoading(spinnerFrames[0])
+		go a.spin(loadCtx, current)
+	}
+	// a connection switched meanwhile closes its client once the load is done
+	_, release := a.ConnectionManager().Hold()
+	go func() {
+		table, err := component.GetData(loadCtx)
+		release()
+		a.Application.QueueUpdateDraw(func() {
+			// superseded by another load or cancelled
+			if a.loading != current {
+				return
+			}
+			a.loading = nil
+			cancel()
+
+			defer a.scheduleRefresh(ctx, component)
+
+			component.SetLoading("")
+			if err != nil {
+				a.status.SetError(err)
+				return
+			}
+			component.Render(table)
+		})
+	}()
+}
+
+func (a *ApplicationState) spin(ctx context.Context, current *load) {
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 1; ; frame++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			text := spinnerFrames[frame%len(spinnerFrames)]
+			a.Application.QueueUpdateDraw(func() {
+				if a.loading == current {
+					current.component.SetLoading(text)
+				}
+			})
+		}
+	}
+}
+
+// cancelLoad aborts the load in flight if any and reports whether there was one
+func (a *ApplicationState) cancelLoad() bool {
+	if a.loading == nil {
+		return false
+	}
+
+	a.loading.cancel()
+	a.loading.component.SetLoading("")
+	a.loading = nil
+	return true
+}
+
+// updateContext refreshes the current role, warehouse, database and schema
+// in the background
+func (a *ApplicationState) updateContext(ctx context.Context) {
+	// the tab may be switched before the session details arrive
+	snowflakeContext := a.tab.context
+	_, release := a.ConnectionManager().Hold()
+	go func() {
+		session, err := snowflakeContext.GetData(ctx)
+		release()
+		a.Application.QueueUpdateDraw(func() {
+			if err != nil {
+				a.status.SetError(err)
+				return
+			}
+			snowflakeContext.Render(session)
+		})
+	}()
+}
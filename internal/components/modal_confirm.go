@@ -2,12 +2,19 @@ package components
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/rivo/tview"
 )
 
 type ConfirmModal struct {
 	modal *tview.Modal
+
+	// form asks for the name of the object instead of a button on the
+	// protected environments, cancel closes it
+	form   *tview.Form
+	frame  *tview.Flex
+	cancel func()
 }
 
 func NewConfirmModal() *ConfirmModal {
@@ -16,8 +23,22 @@ func NewConfirmModal() *ConfirmModal {
 		AddButtons([]string{"Cancel", "Confirm"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {})
 
+	form := tview.NewForm()
+	form.SetBorder(true)
+
+	// the form is centered as the modal is
+	frame := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 9, 0, true).
+			AddItem(nil, 0, 1, false), 0, 2, true).
+		AddItem(nil, 0, 1, false)
+
 	return &ConfirmModal{
 		modal: modal,
+		form:  form,
+		frame: frame,
 	}
 }
 
@@ -32,6 +53,46 @@ func (m *ConfirmModal) Prompt(ctx context.Context, applicationState *Application
 	applicationState.UpdateView(ctx, false)
 }
 
+// PromptName confirms once the name is typed, the form stays open while
+// the name typed does not match
+func (m *ConfirmModal) PromptName(ctx context.Context, applicationState *ApplicationState, message string, name string, confirm func(action bool)) {
+	done := func(action bool) {
+		confirm(action)
+		applicationState.Pages.SwitchToPage("main")
+		applicationState.UpdateView(ctx, false)
+	}
+	m.cancel = func() { done(false) }
+
+	m.form.Clear(true)
+	m.form.SetTitle(fmt.Sprintf(" %s ", message))
+	m.form.AddInputField(fmt.Sprintf("type %s to confirm", name), "", 0, nil, nil)
+	m.form.AddButton("Confirm", func() {
+		typed := m.form.GetFormItem(0).(*tview.InputField).GetText()
+		if typed != name {
+			applicationState.status.SetError(fmt.Errorf("typed name %s does not match %s", typed, name))
+			return
+		}
+		done(true)
+	})
+	m.form.AddButton("Cancel", m.cancel)
+	m.form.SetFocus(0)
+
+	applicationState.Pages.SwitchToPage("confirm")
+	applicationState.UpdateView(ctx, false)
+}
+
+// Cancel closes the name form as its Cancel button does
+func (m *ConfirmModal) Cancel() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
 func (m *ConfirmModal) GetRender() *tview.Modal {
 	return m.modal
 }
+
+// GetNameRender returns the name form centered on the screen
+func (m *ConfirmModal) GetNameRender() *tview.Flex {
+	return m.frame
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type NetworkPoliciesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *NetworkPoliciesOptions
 }
 
@@ -21,43 +22,45 @@ type NetworkPoliciesOptions struct {
 func NewNetworkPoliciesView(connectionManager *snowflake.ConnectionManager, opts *NetworkPoliciesOptions) *NetworkPoliciesView {
 	networkPolicies := &NetworkPoliciesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	networkPolicies.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return networkPolicies
 }
 
-func (v *NetworkPoliciesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating network rules data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "network policies",
+		Aliases:     []string{"network policy"},
+		Description: "network policies of the account",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewNetworkPoliciesView(cm, &NetworkPoliciesOptions{})
+		},
+	})
 }
 
-func (t *NetworkPoliciesView) getData(ctx context.Context, opts *NetworkPoliciesOptions) (*Table, error) {
+func (t *NetworkPoliciesView) GetData(ctx context.Context) (*registry.Table, error) {
 	title := "network policies"
-	networkPolicies, err := t.connectionManager.GetClient().SDKClient.NetworkPolicies.Show(ctx, sdk.NewShowNetworkPolicyRequest())
+	networkPolicies, err := t.connectionManager.GetClient().NetworkPolicies.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show network policies %w", err)
 	}
 
 	columns := []string{"Name"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, networkPolicy := range networkPolicies {
-		rows = append(rows, []string{
-			networkPolicy.Name,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				networkPolicy.Name,
+			},
+			Record:     networkPolicy,
+			Identifier: sdk.NewAccountObjectIdentifier(networkPolicy.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -67,7 +70,3 @@ func (t *NetworkPoliciesView) getData(ctx context.Context, opts *NetworkPolicies
 func (v *NetworkPoliciesView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{}
 }
-
-func (v *NetworkPoliciesView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type NetworkRulesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *NetworkRulesOptions
 }
 
@@ -23,57 +24,65 @@ type NetworkRulesOptions struct {
 func NewNetworkRulesView(connectionManager *snowflake.ConnectionManager, opts *NetworkRulesOptions) *NetworkRulesView {
 	networkRules := &NetworkRulesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	networkRules.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return networkRules
 }
 
-func (v *NetworkRulesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating network rules data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "network rules",
+		Aliases:     []string{"network rule"},
+		Description: "network rules",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewNetworkRulesView(cm, &NetworkRulesOptions{
+				Database: scope.Database,
+				Schema:   scope.Schema,
+			})
+		},
+	})
 }
 
-func (t *NetworkRulesView) getData(ctx context.Context, opts *NetworkRulesOptions) (*Table, error) {
+func (t *NetworkRulesView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	title := "network rules"
-	snowflakeOpts := sdk.NewShowNetworkRuleRequest()
+	snowflakeOpts := &snowflake.ShowNetworkRuleOptions{}
 	if opts.Database != nil {
-		snowflakeOpts.WithIn(&sdk.In{Database: *opts.Database})
-		title = fmt.Sprintf("network rules([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		snowflakeOpts.Database = opts.Database
+		title = scopedTitle("network rules", opts.Database.FullyQualifiedName())
 	}
 	if opts.Schema != nil {
-		snowflakeOpts.WithIn(&sdk.In{Schema: *opts.Schema})
-		title = fmt.Sprintf("network rules([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		snowflakeOpts.Schema = opts.Schema
+		title = scopedTitle("network rules", opts.Schema.FullyQualifiedName())
 	}
 
-	networkRules, err := t.connectionManager.GetClient().SDKClient.NetworkRules.Show(ctx, snowflakeOpts)
+	networkRules, err := t.connectionManager.GetClient().NetworkRules.Show(ctx, snowflakeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show service instances %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Type", "Mode"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, networkRule := range networkRules {
-		rows = append(rows, []string{
-			networkRule.DatabaseName,
-			networkRule.SchemaName,
-			networkRule.Name,
-			string(networkRule.Type),
-			string(networkRule.Mode),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				networkRule.DatabaseName,
+				networkRule.SchemaName,
+				networkRule.Name,
+				string(networkRule.Type),
+				string(networkRule.Mode),
+			},
+			Record:     networkRule,
+			Identifier: sdk.NewSchemaObjectIdentifier(networkRule.DatabaseName, networkRule.SchemaName, networkRule.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -83,7 +92,3 @@ func (t *NetworkRulesView) getData(ctx context.Context, opts *NetworkRulesOption
 func (v *NetworkRulesView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{}
 }
-
-func (v *NetworkRulesView) GetRender() tview.Primitive {
-	return v.table
-}
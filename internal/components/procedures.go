@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ProceduresView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ProceduresOptions
 }
 
@@ -24,56 +25,64 @@ type ProceduresOptions struct {
 func NewProceduresView(connectionManager *snowflake.ConnectionManager, opts *ProceduresOptions) *ProceduresView {
 	Procedures := &ProceduresView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	Procedures.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return Procedures
 }
 
-func (v *ProceduresView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating procedures data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "procedures",
+		Aliases:     []string{"procedure"},
+		Description: "stored procedures",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewProceduresView(cm, &ProceduresOptions{
+				Database: scope.Database,
+				Schema:   scope.Schema,
+			})
+		},
+	})
 }
 
-func (t *ProceduresView) getData(ctx context.Context, opts *ProceduresOptions) (*Table, error) {
+func (t *ProceduresView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	title := "procedures"
-	snowflakeOpts := sdk.NewShowProcedureRequest()
+	snowflakeOpts := &snowflake.ShowProcedureOptions{}
 	if opts.Database != nil {
-		snowflakeOpts.WithIn(&sdk.In{Database: *opts.Database})
-		title = fmt.Sprintf("procedures([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		snowflakeOpts.Database = opts.Database
+		title = scopedTitle("procedures", opts.Database.FullyQualifiedName())
 	}
 	if opts.Schema != nil {
-		snowflakeOpts.WithIn(&sdk.In{Schema: *opts.Schema})
-		title = fmt.Sprintf("procedures([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		snowflakeOpts.Schema = opts.Schema
+		title = scopedTitle("procedures", opts.Schema.FullyQualifiedName())
 	}
 
-	procedures, err := t.connectionManager.GetClient().SDKClient.Procedures.Show(ctx, snowflakeOpts)
+	procedures, err := t.connectionManager.GetClient().Procedures.Show(ctx, snowflakeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show procedures %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Arguments"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, procedure := range procedures {
-		rows = append(rows, []string{
-			procedure.CatalogName,
-			procedure.SchemaName,
-			procedure.Name,
-			procedure.Arguments,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				procedure.CatalogName,
+				procedure.SchemaName,
+				procedure.Name,
+				procedure.Arguments,
+			},
+			Record:     procedure,
+			Identifier: sdk.NewSchemaObjectIdentifier(procedure.CatalogName, procedure.SchemaName, procedure.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -87,18 +96,15 @@ func (v *ProceduresView) GetBindings(ctx context.Context, applicationState *Appl
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				procedureName := v.table.GetCell(r, 2).Text
-				procedure := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, procedureName,
-				)
+				procedure, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeProcedure,
 							ObjectIdentifier: procedure,
@@ -110,7 +116,3 @@ func (v *ProceduresView) GetBindings(ctx context.Context, applicationState *Appl
 		},
 	}
 }
-
-func (v *ProceduresView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -0,0 +1,94 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+// DefaultRefreshInterval is how often the current view is refreshed unless
+// it sets its own interval
+const DefaultRefreshInterval = 30 * time.Second
+
+// statusRefreshInterval is used by the views following the state of compute
+// pools and services
+const statusRefreshInterval = 5 * time.Second
+
+// Refresher is implemented by views that refresh at their own interval,
+// zero disables the refresh of the view
+type Refresher interface {
+	RefreshInterval() time.Duration
+}
+
+// SetRefreshInterval sets the default interval, zero disables auto refresh
+// for the views without their own interval
+func (a *ApplicationState) SetRefreshInterval(interval time.Duration) {
+	a.refreshInterval = interval
+}
+
+// SetViewRefreshInterval sets the interval of the views of a resource, it
+// takes precedence over the interval of the view itself
+func (a *ApplicationState) SetViewRefreshInterval(name string, interval time.Duration) error {
+	resource := registry.FindResource(name)
+	if resource == nil {
+		return fmt.Errorf("unknown resource %s", name)
+	}
+	a.viewRefreshIntervals[resource.Name] = interval
+	return nil
+}
+
+func (a *ApplicationState) refreshIntervalFor(component Component) time.Duration {
+	if resource := registry.ResourceOf(component); resource != nil {
+		if interval, ok := a.viewRefreshIntervals[resource.Name]; ok {
+			return interval
+		}
+	}
+	if refresher, ok := component.(Refresher); ok {
+		return refresher.RefreshInterval()
+	}
+	return a.refreshInterval
+}
+
+// scheduleRefresh reloads the component after its interval if it is still
+// the current view and auto refresh is not paused
+func (a *ApplicationState) scheduleRefresh(ctx context.Context, component Component) {
+	a.stopRefresh()
+
+	interval := a.refreshIntervalFor(component)
+	if interval <= 0 || a.refreshPaused {
+		return
+	}
+
+	a.refreshTimer = time.AfterFunc(interval, func() {
+		a.Application.QueueUpdateDraw(func() {
+			if a.refreshPaused || a.loading != nil || a.tab.history[len(a.tab.history)-1] != component {
+				return
+			}
+			a.load(ctx, component, false)
+		})
+	})
+}
+
+func (a *ApplicationState) stopRefresh() {
+	if a.refreshTimer != nil {
+		a.refreshTimer.Stop()
+		a.refreshTimer = nil
+	}
+}
+
+// toggleRefresh pauses or resumes the auto refresh of the current view
+func (a *ApplicationState) toggleRefresh(ctx context.Context) {
+	a.refreshPaused = !a.refreshPaused
+	if a.refreshPaused {
+		a.stopRefresh()
+		a.status.SetMessage("Paused auto refresh")
+		return
+	}
+
+	if a.loading == nil {
+		a.scheduleRefresh(ctx, a.tab.history[len(a.tab.history)-1])
+	}
+	a.status.SetMessage("Resumed auto refresh")
+}
@@ -6,13 +6,14 @@ import (
 	"strconv"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type ReleaseDirectivesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ReleaseDirectivesOptions
 }
 
@@ -23,46 +24,51 @@ type ReleaseDirectivesOptions struct {
 func NewReleaseDirectivesView(connectionManager *snowflake.ConnectionManager, opts *ReleaseDirectivesOptions) *ReleaseDirectivesView {
 	releaseDirectives := &ReleaseDirectivesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	releaseDirectives.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return releaseDirectives
 }
 
-func (v *ReleaseDirectivesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating network rules data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "release directives",
+		Aliases:     []string{"release directive"},
+		Description: "release directives of an application package",
+		Scopes:      []registry.ScopeArgument{registry.ScopeApplicationPackage},
+		Required:    []registry.ScopeArgument{registry.ScopeApplicationPackage},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewReleaseDirectivesView(cm, &ReleaseDirectivesOptions{ApplicationPackage: scope.ApplicationPackage})
+		},
+	})
 }
 
-func (t *ReleaseDirectivesView) getData(ctx context.Context, opts *ReleaseDirectivesOptions) (*Table, error) {
+func (t *ReleaseDirectivesView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	releaseDirectives, err := t.connectionManager.GetClient().ReleaseDirectives.Show(ctx, *opts.ApplicationPackage)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show release directives %w", err)
 	}
 
 	columns := []string{"Name", "Version", "Patch", "Target Type"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, releaseDirective := range releaseDirectives {
-		rows = append(rows, []string{
-			releaseDirective.Name,
-			releaseDirective.Version,
-			strconv.Itoa(releaseDirective.Patch),
-			releaseDirective.TargetType.String,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				releaseDirective.Name,
+				releaseDirective.Version,
+				strconv.Itoa(releaseDirective.Patch),
+				releaseDirective.TargetType.String,
+			},
+			Record: releaseDirective,
 		})
 	}
 
-	return &Table{
-		Title:   fmt.Sprintf("release directives([pink]%s[blue])", opts.ApplicationPackage.FullyQualifiedName()),
+	return &registry.Table{
+		Title:   scopedTitle("release directives", opts.ApplicationPackage.FullyQualifiedName()),
 		Columns: columns,
 		Rows:    rows,
 	}, nil
@@ -71,7 +77,3 @@ func (t *ReleaseDirectivesView) getData(ctx context.Context, opts *ReleaseDirect
 func (v *ReleaseDirectivesView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{}
 }
-
-func (v *ReleaseDirectivesView) GetRender() tview.Primitive {
-	return v.table
-}
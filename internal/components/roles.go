@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type RolesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *RolesOptions
 }
 
@@ -21,42 +22,44 @@ type RolesOptions struct{}
 func NewRolesView(connectionManager *snowflake.ConnectionManager, opts *RolesOptions) *RolesView {
 	roles := &RolesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 	}
 
-	roles.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return roles
 }
 
-func (v *RolesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating roles data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "roles",
+		Aliases:     []string{"role"},
+		Description: "roles of the account",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewRolesView(cm, &RolesOptions{})
+		},
+	})
 }
 
-func (t *RolesView) getData(ctx context.Context) (*Table, error) {
-	roles, err := t.connectionManager.GetClient().SDKClient.Roles.Show(ctx, sdk.NewShowRoleRequest())
+func (t *RolesView) GetData(ctx context.Context) (*registry.Table, error) {
+	roles, err := t.connectionManager.GetClient().Roles.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show roles %w", err)
 	}
 
 	columns := []string{"Name", "Owner"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, role := range roles {
-		rows = append(rows, []string{
-			role.Name,
-			role.Owner,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				role.Name,
+				role.Owner,
+			},
+			Record:     role,
+			Identifier: sdk.NewAccountObjectIdentifier(role.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "roles",
 		Columns: columns,
 		Rows:    rows,
@@ -69,16 +72,18 @@ func (v *RolesView) GetBindings(ctx context.Context, applicationState *Applicati
 			Description: "Use Role",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				cell := v.table.GetCell(r, 0)
-				query := fmt.Sprintf("USE ROLE %s", cell.Text)
-				_, err := v.connectionManager.GetClient().SDKClient.GetConn().ExecContext(ctx, query)
+				role, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
+				err := v.connectionManager.GetClient().Sessions.UseRole(ctx, role)
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.context.Update(ctx)
+				applicationState.updateContext(ctx)
 				return nil
 			},
 		},
@@ -87,14 +92,15 @@ func (v *RolesView) GetBindings(ctx context.Context, applicationState *Applicati
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				roleName := v.table.GetCell(r, 0).Text
-				role := sdk.NewAccountObjectIdentifier(roleName)
+				role, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeRole,
 							ObjectIdentifier: role,
@@ -106,7 +112,3 @@ func (v *RolesView) GetBindings(ctx context.Context, applicationState *Applicati
 		},
 	}
 }
-
-func (v *RolesView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type SchemasView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *SchemasOptions
 }
@@ -23,51 +24,62 @@ type SchemasOptions struct {
 func NewSchemasView(connectionManager *snowflake.ConnectionManager, opts *SchemasOptions) *SchemasView {
 	schemas := &SchemasView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	schemas.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return schemas
 }
 
-func (v *SchemasView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating schemas data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "schemas",
+		Aliases:     []string{"schema"},
+		Description: "schemas in every database or in a database",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			opts := &SchemasOptions{}
+			if scope.Database != nil {
+				database := scope.Database.Name()
+				opts.Database = &database
+			}
+			return NewSchemasView(cm, opts)
+		},
+	})
 }
 
-func (v *SchemasView) getData(ctx context.Context, opts *SchemasOptions) (*Table, error) {
-	sdkOpts := &sdk.ShowSchemaOptions{}
+func (v *SchemasView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := v.options
+
+	snowflakeOpts := &snowflake.ShowSchemaOptions{}
 	title := "schemas"
 	if opts.Database != nil {
-		sdkOpts.In = &sdk.SchemaIn{Database: sdk.Bool(true), Name: sdk.NewAccountObjectIdentifier(*opts.Database)}
-		title = fmt.Sprintf("schemas([pink]%s[blue])", *opts.Database)
+		database := sdk.NewAccountObjectIdentifier(*opts.Database)
+		snowflakeOpts.Database = &database
+		title = scopedTitle("schemas", *opts.Database)
 	}
 
-	schemas, err := v.connectionManager.GetClient().SDKClient.Schemas.Show(ctx, sdkOpts)
+	schemas, err := v.connectionManager.GetClient().Schemas.Show(ctx, snowflakeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show schemas %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Owner"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, schema := range schemas {
-		rows = append(rows, []string{
-			schema.DatabaseName,
-			schema.Name,
-			schema.Owner,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				schema.DatabaseName,
+				schema.Name,
+				schema.Owner,
+			},
+			Record:     schema,
+			Identifier: sdk.NewDatabaseObjectIdentifier(schema.DatabaseName, schema.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -80,17 +92,18 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Description: "Use Schema",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-
-				database := v.table.GetCell(r, 0).Text
-				schema := v.table.GetCell(r, 1).Text
-				_, err := v.connectionManager.GetClient().SDKClient.GetConn().ExecContext(ctx, fmt.Sprintf("USE SCHEMA %s.%s", database, schema))
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
+				err := v.connectionManager.GetClient().Sessions.UseSchema(ctx, schema)
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.context.Update(ctx)
+				applicationState.updateContext(ctx)
 				return nil
 			},
 		},
@@ -99,17 +112,18 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewStagesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&StagesOptions{
-							Database: sdk.String(databaseName),
-							Schema:   sdk.String(schemaName),
+							Database: sdk.String(schema.DatabaseName()),
+							Schema:   sdk.String(schema.Name()),
 						},
 					),
 				)
@@ -121,17 +135,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewServicesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ServicesOptions{
 							Schema: &schema,
 						},
@@ -145,17 +157,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewSnapshotsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&SnapshotsOptions{
 							Schema: &schema,
 						},
@@ -169,17 +179,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewImageRepositoriesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ImageRepositoriesOptions{
 							Schema: &schema,
 						},
@@ -193,17 +201,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewProceduresView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ProceduresOptions{
 							Schema: &schema,
 						},
@@ -217,17 +223,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewStreamlitsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&StreamlitsOptions{
 							Schema: &schema,
 						},
@@ -241,17 +245,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewSecretsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&SecretsOptions{
 							Schema: &schema,
 						},
@@ -265,17 +267,15 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeSchema,
 							ObjectIdentifier: schema,
@@ -289,25 +289,23 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				schema := sdk.NewDatabaseObjectIdentifier(
-					databaseName, schemaName,
-				)
-
-				message := fmt.Sprintf("Drop schema %s?", schema.FullyQualifiedName())
+				schema, ok := selectedIdentifier[sdk.DatabaseObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "schema", schema, func(action bool) {
 					if action {
-						err := v.connectionManager.GetClient().SDKClient.Schemas.Drop(
+						err := v.connectionManager.GetClient().Schemas.Drop(
 							ctx,
 							schema,
-							&sdk.DropSchemaOptions{},
+							&snowflake.DropSchemaOptions{},
 						)
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped schema %s", schema.FullyQualifiedName()))
 					} else {
@@ -320,7 +318,3 @@ func (v *SchemasView) GetBindings(ctx context.Context, applicationState *Applica
 		},
 	}
 }
-
-func (v *SchemasView) GetRender() tview.Primitive {
-	return v.table
-}
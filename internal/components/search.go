@@ -2,55 +2,45 @@ package components
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
 type Search struct {
-	words      []string
 	inputField *tview.InputField
+	completer  *completer
 }
 
-func NewSearch() *Search {
+func NewSearch(cm *snowflake.ConnectionManager, application *tview.Application) *Search {
 	search := &Search{
-		words: []string{
-			"users",
-			"roles",
-			"databases",
-			"schemas",
-			"application packages",
-			"applications",
-			"compute pools",
-			"listings",
-			"security integrations",
-			"stages",
-			"tables",
-			"views",
-			"warehouses",
-			"connections",
-			"snapshots",
-			"services",
-			"image repositories",
-			"procedures",
-			"network rules",
-			"network policies",
-			"secrets",
+		inputField: tview.NewInputField().SetPlaceholder("snowflake object and scope, services DB.SCHEMA").SetFieldWidth(0),
+	}
+
+	search.completer = &completer{
+		connectionManager: cm,
+		fetch: func(lookup func() ([]string, error), done func(names []string)) {
+			go func() {
+				names, err := lookup()
+				if err != nil {
+					names = nil
+				}
+				application.QueueUpdateDraw(func() {
+					done(names)
+					if search.inputField.HasFocus() {
+						search.inputField.Autocomplete()
+					}
+				})
+			}()
 		},
-		inputField: tview.NewInputField().SetPlaceholder("snowflake object").SetFieldWidth(0),
 	}
+	search.completer.reset()
 
 	search.inputField.SetAutocompleteFunc(func(currentText string) (entries []string) {
-		if len(currentText) == 0 {
-			return
-		}
-		for _, word := range search.words {
-			if strings.HasPrefix(strings.ToLower(word), strings.ToLower(currentText)) {
-				entries = append(entries, word)
-			}
-		}
+		entries = search.completer.complete(currentText)
 		if len(entries) < 1 {
 			entries = nil
 		}
@@ -70,138 +60,76 @@ func NewSearch() *Search {
 func (s *Search) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{
 		{
-			Description: "Search",
+			Description: "Open",
 			Event:       tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
 				applicationState.Pages.SwitchToPage("main")
 
-				switch applicationState.search.Value() {
-				case "users":
-					applicationState.Push(
-						ctx,
-						NewUsersView(applicationState.ConnectionManager, &UsersOptions{}),
-					)
-				case "roles":
-					applicationState.Push(
-						ctx,
-						NewRolesView(applicationState.ConnectionManager, &RolesOptions{}),
-					)
-				case "databases":
-					applicationState.Push(
-						ctx,
-						NewDatabasesView(applicationState.ConnectionManager, &DatabasesOptions{}),
-					)
-				case "schemas":
-					applicationState.Push(
-						ctx,
-						NewSchemasView(applicationState.ConnectionManager, &SchemasOptions{}),
-					)
-				case "application packages":
-					applicationState.Push(
-						ctx,
-						NewApplicationPackagesView(applicationState.ConnectionManager, &ApplicationPackagesOptions{}),
-					)
-				case "applications":
-					applicationState.Push(
-						ctx,
-						NewApplicationsView(applicationState.ConnectionManager, &ApplicationsOptions{}),
-					)
-				case "compute pools":
-					applicationState.Push(
-						ctx,
-						NewComputePoolsView(applicationState.ConnectionManager, &ComputePoolsOptions{}),
-					)
-				case "listings":
-					applicationState.Push(
-						ctx,
-						NewListingsView(applicationState.ConnectionManager, &ListingsOptions{}),
-					)
-				case "security integrations":
-					applicationState.Push(
-						ctx,
-						NewSecurityIntegrationsView(applicationState.ConnectionManager, &SecurityIntegrationsOptions{}),
-					)
-				case "stages":
-					applicationState.Push(
-						ctx,
-						NewStagesView(applicationState.ConnectionManager, &StagesOptions{}),
-					)
-				case "tables":
-					applicationState.Push(
-						ctx,
-						NewTablesView(applicationState.ConnectionManager, &TablesOptions{}),
-					)
-				case "views":
-					applicationState.Push(
-						ctx,
-						NewViewsView(applicationState.ConnectionManager, &ViewsOptions{}),
-					)
-				case "warehouses":
-					applicationState.Push(
-						ctx,
-						NewWarehousesView(applicationState.ConnectionManager, &WarehousesOptions{}),
-					)
-				case "connections":
-					applicationState.Push(
-						ctx,
-						NewConnectionsView(applicationState.ConnectionManager, &ConnectionsOptions{}),
-					)
-				case "snapshots":
-					applicationState.Push(
-						ctx,
-						NewSnapshotsView(applicationState.ConnectionManager, &SnapshotsOptions{}),
-					)
-				case "services":
-					applicationState.Push(
-						ctx,
-						NewServicesView(applicationState.ConnectionManager, &ServicesOptions{}),
-					)
-				case "image repositories":
-					applicationState.Push(
-						ctx,
-						NewImageRepositoriesView(applicationState.ConnectionManager, &ImageRepositoriesOptions{}),
-					)
-				case "procedures":
-					applicationState.Push(
-						ctx,
-						NewProceduresView(applicationState.ConnectionManager, &ProceduresOptions{}),
-					)
-				case "network rules":
-					applicationState.Push(
-						ctx,
-						NewNetworkRulesView(applicationState.ConnectionManager, &NetworkRulesOptions{}),
-					)
-				case "network policies":
-					applicationState.Push(
-						ctx,
-						NewNetworkPoliciesView(applicationState.ConnectionManager, &NetworkPoliciesOptions{}),
-					)
-				case "streamlits":
-					applicationState.Push(
-						ctx,
-						NewStreamlitsView(applicationState.ConnectionManager, &StreamlitsOptions{}),
-					)
-				case "secrets":
-					applicationState.Push(
-						ctx,
-						NewSecretsView(applicationState.ConnectionManager, &SecretsOptions{}),
-					)
-				default:
-					applicationState.status.SetError(fmt.Errorf("unknown snowflake object %s", applicationState.search.Value()))
+				resource, scope, err := registry.ParseCommand(applicationState.search.Value())
+				if err != nil {
+					applicationState.status.SetError(err)
 					applicationState.Pages.SwitchToPage("search")
 					return event
 				}
 
+				component, err := openResource(resource, applicationState.ConnectionManager(), scope)
+				if err != nil {
+					applicationState.status.SetError(err)
+					applicationState.Pages.SwitchToPage("search")
+					return event
+				}
+				applicationState.Push(ctx, component)
+
 				s.inputField.SetText("")
 				return event
 			},
 		},
+		{
+			Description: "Complete",
+			Event:       tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone),
+			Hidden:      false,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				text := s.inputField.GetText()
+				entries := s.completer.complete(text)
+				switch {
+				case len(entries) == 1 && entries[0] != text:
+					s.inputField.SetText(entries[0])
+				case len(entries) > 1:
+					s.inputField.SetText(commonPrefix(text, entries))
+				}
+				s.inputField.Autocomplete()
+				return nil
+			},
+		},
 	}
 }
 
+// commonPrefix extends the text with the prefix shared by every entry
+func commonPrefix(text string, entries []string) string {
+	prefix := entries[0]
+	for _, entry := range entries[1:] {
+		for !strings.HasPrefix(strings.ToLower(entry), strings.ToLower(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) < len(text) {
+		return text
+	}
+	return prefix
+}
+
+// Clear empties the search bar and forgets the names fetched for completion
 func (s *Search) Clear() {
 	s.inputField.SetText("")
+	s.completer.reset()
+}
+
+// SetConnectionManager completes the names of the tab of the connection
+// manager
+func (s *Search) SetConnectionManager(cm *snowflake.ConnectionManager) {
+	s.completer.connectionManager = cm
+	s.completer.reset()
 }
 
 func (s *Search) Value() string {
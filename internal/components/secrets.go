@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type SecretsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *SecretsOptions
 }
 
@@ -26,44 +27,49 @@ type SecretsOptions struct {
 func NewSecretsView(connectionManager *snowflake.ConnectionManager, opts *SecretsOptions) *SecretsView {
 	secrets := &SecretsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	secrets.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return secrets
 }
 
-func (v *SecretsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating network rules data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "secrets",
+		Aliases:     []string{"secret"},
+		Description: "secrets for services and procedures",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema, registry.ScopeApplicationPackage},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewSecretsView(cm, &SecretsOptions{
+				Database:           scope.Database,
+				Schema:             scope.Schema,
+				ApplicationPackage: scope.ApplicationPackage,
+			})
+		},
+	})
 }
 
-func (t *SecretsView) getData(ctx context.Context, opts *SecretsOptions) (*Table, error) {
+func (t *SecretsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	title := "secrets"
 	snowflakeOpts := snowflake.ShowSecretsOptions{}
 	if opts.Database != nil {
 		snowflakeOpts.Database = opts.Database
-		title = fmt.Sprintf("streamlits([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		title = scopedTitle("streamlits", opts.Database.FullyQualifiedName())
 	}
 	if opts.Schema != nil {
 		snowflakeOpts.Schema = opts.Schema
-		title = fmt.Sprintf("streamlits([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		title = scopedTitle("streamlits", opts.Schema.FullyQualifiedName())
 	}
 	if opts.Application != nil {
 		snowflakeOpts.Application = opts.Application
-		title = fmt.Sprintf("streamlits([pink]%s[blue])", opts.Application.FullyQualifiedName())
+		title = scopedTitle("streamlits", opts.Application.FullyQualifiedName())
 	}
 	if opts.ApplicationPackage != nil {
 		snowflakeOpts.ApplicationPackage = opts.ApplicationPackage
-		title = fmt.Sprintf("streamlits([pink]%s[blue])", opts.ApplicationPackage.FullyQualifiedName())
+		title = scopedTitle("streamlits", opts.ApplicationPackage.FullyQualifiedName())
 	}
 
 	secrets, err := t.connectionManager.GetClient().Secrets.Show(ctx, &snowflakeOpts)
@@ -72,18 +78,22 @@ func (t *SecretsView) getData(ctx context.Context, opts *SecretsOptions) (*Table
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Secret Type"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, secret := range secrets {
-		rows = append(rows, []string{
-			secret.DatabaseName,
-			secret.SchemaName,
-			secret.Name,
-			secret.SecretType,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				secret.DatabaseName,
+				secret.SchemaName,
+				secret.Name,
+				secret.SecretType,
+			},
+			Record:     secret,
+			Identifier: sdk.NewSchemaObjectIdentifier(secret.DatabaseName, secret.SchemaName, secret.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -97,18 +107,15 @@ func (v *SecretsView) GetBindings(ctx context.Context, applicationState *Applica
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				secretName := v.table.GetCell(r, 2).Text
-				secret := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, secretName,
-				)
+				secret, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeSecret,
 							ObjectIdentifier: secret,
@@ -122,22 +129,21 @@ func (v *SecretsView) GetBindings(ctx context.Context, applicationState *Applica
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				secretName := v.table.GetCell(r, 2).Text
-				secret := sdk.NewSchemaObjectIdentifier(databaseName, schemaName, secretName)
+				secret, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				message := fmt.Sprintf("Drop secret %s?", secret.FullyQualifiedName())
-
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "secret", secret, func(action bool) {
 					if action {
 						err := v.connectionManager.GetClient().Secrets.Drop(ctx, &snowflake.DropSecretsOptions{
 							Secret: &secret,
 						})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped secret %s", secret.FullyQualifiedName()))
 					} else {
@@ -150,7 +156,3 @@ func (v *SecretsView) GetBindings(ctx context.Context, applicationState *Applica
 		},
 	}
 }
-
-func (v *SecretsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type SecurityIntegrationsView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *SecurityIntegrationsOptions
 }
@@ -21,43 +22,45 @@ type SecurityIntegrationsOptions struct{}
 func NewSecurityIntegrationsView(connectionManager *snowflake.ConnectionManager, opts *SecurityIntegrationsOptions) *SecurityIntegrationsView {
 	securityIntegrations := &SecurityIntegrationsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	securityIntegrations.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return securityIntegrations
 }
 
-func (v *SecurityIntegrationsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating security integrations data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "security integrations",
+		Aliases:     []string{"security integration"},
+		Description: "security integrations of the account",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewSecurityIntegrationsView(cm, &SecurityIntegrationsOptions{})
+		},
+	})
 }
 
-func (v *SecurityIntegrationsView) getData(ctx context.Context) (*Table, error) {
-	securityIntegrations, err := v.connectionManager.GetClient().SDKClient.SecurityIntegrations.Show(ctx, sdk.NewShowSecurityIntegrationRequest())
+func (v *SecurityIntegrationsView) GetData(ctx context.Context) (*registry.Table, error) {
+	securityIntegrations, err := v.connectionManager.GetClient().SecurityIntegrations.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show integrations %w", err)
 	}
 
 	columns := []string{"Name", "Integration Type"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, securityIntegration := range securityIntegrations {
-		rows = append(rows, []string{
-			securityIntegration.Name,
-			securityIntegration.IntegrationType,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				securityIntegration.Name,
+				securityIntegration.IntegrationType,
+			},
+			Record:     securityIntegration,
+			Identifier: sdk.NewAccountObjectIdentifier(securityIntegration.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "security integrations",
 		Columns: columns,
 		Rows:    rows,
@@ -71,16 +74,15 @@ func (v *SecurityIntegrationsView) GetBindings(ctx context.Context, applicationS
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				securityIntegrationName := v.table.GetCell(r, 0).Text
-				securityIntegration := sdk.NewAccountObjectIdentifier(
-					securityIntegrationName,
-				)
+				securityIntegration, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeIntegration,
 							ObjectIdentifier: securityIntegration,
@@ -94,18 +96,19 @@ func (v *SecurityIntegrationsView) GetBindings(ctx context.Context, applicationS
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				securityIntegrationName := v.table.GetCell(r, 0).Text
-				securityIntegration := sdk.NewAccountObjectIdentifier(securityIntegrationName)
+				securityIntegration, ok := selectedIdentifier[sdk.AccountObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				message := fmt.Sprintf("Drop security integration %s?", securityIntegration.FullyQualifiedName())
-
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "security integration", securityIntegration, func(action bool) {
 					if action {
-						err := v.connectionManager.GetClient().SDKClient.SecurityIntegrations.Drop(ctx, sdk.NewDropSecurityIntegrationRequest(securityIntegration))
+						err := v.connectionManager.GetClient().SecurityIntegrations.Drop(ctx, securityIntegration, &snowflake.DropSecurityIntegrationOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped security integration %s", securityIntegration.FullyQualifiedName()))
 					} else {
@@ -118,7 +121,3 @@ func (v *SecurityIntegrationsView) GetBindings(ctx context.Context, applicationS
 		},
 	}
 }
-
-func (v *SecurityIntegrationsView) GetRender() tview.Primitive {
-	return v.table
-}
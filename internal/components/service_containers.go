@@ -4,16 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ServiceContainersView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ServiceContainersOptions
 }
 
@@ -24,48 +26,65 @@ type ServiceContainersOptions struct {
 func NewServiceContainersView(connectionManager *snowflake.ConnectionManager, opts *ServiceContainersOptions) *ServiceContainersView {
 	serviceContainers := &ServiceContainersView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	serviceContainers.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return serviceContainers
 }
 
-func (v *ServiceContainersView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating service containers data %w", err)
-	}
-
-	updateTable(v.table, table)
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "service containers",
+		Aliases:     []string{"service container", "containers"},
+		Description: "containers of a service",
+		Scopes:      []registry.ScopeArgument{registry.ScopeService},
+		Required:    []registry.ScopeArgument{registry.ScopeService},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewServiceContainersView(cm, &ServiceContainersOptions{Service: scope.Service})
+		},
+		States: []StateRule{
+			{Column: "Status", Values: []string{"FAILED", "SUSPENDED", "INTERNAL_ERROR"}, Style: "bad"},
+			{Column: "Restart Count", Above: sdk.Pointer(3.0), Style: "warning", Glyph: "↻"},
+			{Column: "Status", Values: []string{"READY", "RUNNING", "DONE"}, Style: "ok"},
+			{Column: "Status", Values: []string{"PENDING"}, Style: "warning"},
+		},
+	})
+}
 
-	return nil
+// RefreshInterval is shorter than the default to follow state changes
+func (t *ServiceContainersView) RefreshInterval() time.Duration {
+	return statusRefreshInterval
 }
 
-func (t *ServiceContainersView) getData(ctx context.Context, opts *ServiceContainersOptions) (*Table, error) {
+func (t *ServiceContainersView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	serviceContainers, err := t.connectionManager.GetClient().ServiceContainers.Show(ctx, opts.Service)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show service containers %w", err)
 	}
 
-	columns := []string{"Database", "Schema", "Name", "Instance Id", "Container", "Restart Count"}
-	rows := make([][]string, 0)
+	columns := []string{"Database", "Schema", "Name", "Instance Id", "Container", "Status", "Restart Count"}
+	rows := make([]registry.Row, 0)
 
 	for _, serviceContainer := range serviceContainers {
-		rows = append(rows, []string{
-			serviceContainer.DatabaseName,
-			serviceContainer.SchemaName,
-			serviceContainer.ServiceName,
-			strconv.Itoa(serviceContainer.InstanceId),
-			serviceContainer.ContainerName,
-			strconv.Itoa(serviceContainer.RestartCount),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				serviceContainer.DatabaseName,
+				serviceContainer.SchemaName,
+				serviceContainer.ServiceName,
+				strconv.Itoa(serviceContainer.InstanceId),
+				serviceContainer.ContainerName,
+				serviceContainer.Status,
+				strconv.Itoa(serviceContainer.RestartCount),
+			},
+			Record: serviceContainer,
 		})
 	}
 
-	return &Table{
-		Title:   fmt.Sprintf("service containers([pink]%s[blue])", opts.Service.FullyQualifiedName()),
+	return &registry.Table{
+		Title:   scopedTitle("service containers", opts.Service.FullyQualifiedName()),
 		Columns: columns,
 		Rows:    rows,
 	}, nil
@@ -78,28 +97,21 @@ func (v *ServiceContainersView) GetBindings(ctx context.Context, applicationStat
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				database := v.table.GetCell(r, 0).Text
-				schema := v.table.GetCell(r, 1).Text
-				name := v.table.GetCell(r, 2).Text
-
-				instanceId, err := strconv.Atoi(v.table.GetCell(r, 3).Text)
-				if err != nil {
-					applicationState.status.SetError(err)
-					return event
+				container, ok := selectedRecord[snowflake.ServiceContainer](v.TableView)
+				if !ok {
+					return nil
 				}
-				containerName := v.table.GetCell(r, 4).Text
 
-				service := sdk.NewSchemaObjectIdentifier(database, schema, name)
+				service := sdk.NewSchemaObjectIdentifier(container.DatabaseName, container.SchemaName, container.ServiceName)
 
 				applicationState.Push(
 					ctx,
 					NewServiceLogsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ServiceLogsOptions{
 							Service:       &service,
-							InstanceId:    instanceId,
-							ContainerName: containerName,
+							InstanceId:    container.InstanceId,
+							ContainerName: container.ContainerName,
 						},
 					),
 				)
@@ -108,7 +120,3 @@ func (v *ServiceContainersView) GetBindings(ctx context.Context, applicationStat
 		},
 	}
 }
-
-func (v *ServiceContainersView) GetRender() tview.Primitive {
-	return v.table
-}
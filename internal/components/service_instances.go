@@ -4,15 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type ServiceInstancesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ServiceInstancesOptions
 }
 
@@ -23,47 +25,62 @@ type ServiceInstancesOptions struct {
 func NewServiceInstancesView(connectionManager *snowflake.ConnectionManager, opts *ServiceInstancesOptions) *ServiceInstancesView {
 	serviceInstances := &ServiceInstancesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	serviceInstances.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return serviceInstances
 }
 
-func (v *ServiceInstancesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating service instances data %w", err)
-	}
-
-	updateTable(v.table, table)
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "service instances",
+		Aliases:     []string{"service instance", "instances"},
+		Description: "instances of a service",
+		Scopes:      []registry.ScopeArgument{registry.ScopeService},
+		Required:    []registry.ScopeArgument{registry.ScopeService},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewServiceInstancesView(cm, &ServiceInstancesOptions{Service: scope.Service})
+		},
+		States: []StateRule{
+			{Column: "Status", Values: []string{"READY", "RUNNING", "DONE"}, Style: "ok"},
+			{Column: "Status", Values: []string{"PENDING", "SUSPENDING", "DELETING"}, Style: "warning"},
+			{Column: "Status", Values: []string{"FAILED", "SUSPENDED", "DELETED", "INTERNAL_ERROR"}, Style: "bad"},
+		},
+	})
+}
 
-	return nil
+// RefreshInterval is shorter than the default to follow state changes
+func (t *ServiceInstancesView) RefreshInterval() time.Duration {
+	return statusRefreshInterval
 }
 
-func (t *ServiceInstancesView) getData(ctx context.Context, opts *ServiceInstancesOptions) (*Table, error) {
+func (t *ServiceInstancesView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	serviceInstances, err := t.connectionManager.GetClient().ServiceInstances.Show(ctx, opts.Service)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show service instances %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Instance Id", "Status"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, serviceInstance := range serviceInstances {
-		rows = append(rows, []string{
-			serviceInstance.DatabaseName,
-			serviceInstance.SchemaName,
-			serviceInstance.ServiceName,
-			strconv.Itoa(serviceInstance.InstanceId),
-			serviceInstance.Status,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				serviceInstance.DatabaseName,
+				serviceInstance.SchemaName,
+				serviceInstance.ServiceName,
+				strconv.Itoa(serviceInstance.InstanceId),
+				serviceInstance.Status,
+			},
+			Record: serviceInstance,
 		})
 	}
 
-	return &Table{
-		Title:   fmt.Sprintf("service instances([pink]%s[blue])", opts.Service.FullyQualifiedName()),
+	return &registry.Table{
+		Title:   scopedTitle("service instances", opts.Service.FullyQualifiedName()),
 		Columns: columns,
 		Rows:    rows,
 	}, nil
@@ -72,7 +89,3 @@ func (t *ServiceInstancesView) getData(ctx context.Context, opts *ServiceInstanc
 func (v *ServiceInstancesView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{}
 }
-
-func (v *ServiceInstancesView) GetRender() tview.Primitive {
-	return v.table
-}
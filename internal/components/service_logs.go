@@ -6,13 +6,14 @@ import (
 	"strings"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type ServiceLogsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ServiceLogsOptions
 }
 
@@ -25,43 +26,35 @@ type ServiceLogsOptions struct {
 func NewServiceLogsView(connectionManager *snowflake.ConnectionManager, opts *ServiceLogsOptions) *ServiceLogsView {
 	serviceLogs := &ServiceLogsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	serviceLogs.table.SetSelectable(true, false).SetBorder(true)
+	serviceLogs.table.SetFixed(0, 0)
 
 	return serviceLogs
 }
 
-func (v *ServiceLogsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating service logs data %w", err)
-	}
-
-	updateTable(v.table, table)
+func (t *ServiceLogsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
 
-	return nil
-}
-
-func (t *ServiceLogsView) getData(ctx context.Context, opts *ServiceLogsOptions) (*Table, error) {
-	var serviceLogs string
-	query := fmt.Sprintf("CALL SYSTEM$GET_SERVICE_LOGS('%s', %d, '%s')", opts.Service.FullyQualifiedName(), opts.InstanceId, opts.ContainerName)
-	err := t.connectionManager.GetClient().SDKClient.GetConn().QueryRow(query).Scan(&serviceLogs)
+	serviceLogs, err := t.connectionManager.GetClient().Services.Logs(ctx, *opts.Service, &snowflake.ServiceLogsOptions{
+		InstanceId:    opts.InstanceId,
+		ContainerName: opts.ContainerName,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show services logs %w", err)
 	}
 
 	columns := []string{"Logs"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, line := range strings.Split(serviceLogs, "\n") {
-		rows = append(rows, []string{line})
+		rows = append(rows, registry.Row{Cells: []string{line}})
 	}
 
-	return &Table{
-		Title:   fmt.Sprintf("logs([pink]%s[blue])", opts.Service.FullyQualifiedName()),
+	return &registry.Table{
+		Title:   scopedTitle("logs", opts.Service.FullyQualifiedName()),
 		Columns: columns,
 		Rows:    rows,
 	}, nil
@@ -70,7 +63,3 @@ func (t *ServiceLogsView) getData(ctx context.Context, opts *ServiceLogsOptions)
 func (v *ServiceLogsView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{}
 }
-
-func (v *ServiceLogsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ServicesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ServicesOptions
 }
 
@@ -25,27 +26,32 @@ type ServicesOptions struct {
 func NewServicesView(connectionManager *snowflake.ConnectionManager, opts *ServicesOptions) *ServicesView {
 	services := &ServicesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	services.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return services
 }
 
-func (v *ServicesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating services data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "services",
+		Aliases:     []string{"service", "svc"},
+		Description: "snowpark container services",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema, registry.ScopeComputePool},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewServicesView(cm, &ServicesOptions{
+				ComputePool: scope.ComputePool,
+				Database:    scope.Database,
+				Schema:      scope.Schema,
+			})
+		},
+	})
 }
 
-func (t *ServicesView) getData(ctx context.Context, opts *ServicesOptions) (*Table, error) {
+func (t *ServicesView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	services, err := t.connectionManager.GetClient().Services.Show(ctx, &snowflake.ShowServiceOptions{
 		ComputePool: opts.ComputePool,
 		Database:    opts.Database,
@@ -57,35 +63,48 @@ func (t *ServicesView) getData(ctx context.Context, opts *ServicesOptions) (*Tab
 
 	title := "services"
 	if opts.ComputePool != nil {
-		title = fmt.Sprintf("services([pink]%s[blue])", opts.ComputePool.FullyQualifiedName())
+		title = scopedTitle("services", opts.ComputePool.FullyQualifiedName())
 	}
 	if opts.Database != nil {
-		title = fmt.Sprintf("services([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		title = scopedTitle("services", opts.Database.FullyQualifiedName())
 	}
 	if opts.Schema != nil {
-		title = fmt.Sprintf("services([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		title = scopedTitle("services", opts.Schema.FullyQualifiedName())
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Compute Pool", "DNS Name"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, service := range services {
-		rows = append(rows, []string{
-			service.DatabaseName,
-			service.SchemaName,
-			service.Name,
-			service.ComputePool,
-			service.DNSName,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				service.DatabaseName,
+				service.SchemaName,
+				service.Name,
+				service.ComputePool,
+				service.DNSName,
+			},
+			Record:     service,
+			Identifier: sdk.NewSchemaObjectIdentifier(service.DatabaseName, service.SchemaName, service.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
 	}, nil
 }
 
+// Describe fetches the details of the service of a row
+func (v *ServicesView) Describe(ctx context.Context, record any) (any, error) {
+	service, ok := record.(snowflake.Service)
+	if !ok {
+		return nil, fmt.Errorf("unexpected service record %T", record)
+	}
+	return v.connectionManager.GetClient().Services.Describe(ctx, sdk.NewSchemaObjectIdentifier(service.DatabaseName, service.SchemaName, service.Name))
+}
+
 func (v *ServicesView) GetBindings(ctx context.Context, applicationState *ApplicationState) []*KeyBinding {
 	return []*KeyBinding{
 		{
@@ -93,17 +112,15 @@ func (v *ServicesView) GetBindings(ctx context.Context, applicationState *Applic
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				database := v.table.GetCell(r, 0).Text
-				schema := v.table.GetCell(r, 1).Text
-				name := v.table.GetCell(r, 2).Text
-
-				service := sdk.NewSchemaObjectIdentifier(database, schema, name)
+				service, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewEndpointsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&EndpointsOptions{
 							Service: &service,
 						},
@@ -117,17 +134,15 @@ func (v *ServicesView) GetBindings(ctx context.Context, applicationState *Applic
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				database := v.table.GetCell(r, 0).Text
-				schema := v.table.GetCell(r, 1).Text
-				name := v.table.GetCell(r, 2).Text
-
-				service := sdk.NewSchemaObjectIdentifier(database, schema, name)
+				service, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewServiceContainersView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ServiceContainersOptions{
 							Service: &service,
 						},
@@ -141,17 +156,15 @@ func (v *ServicesView) GetBindings(ctx context.Context, applicationState *Applic
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'i', tcell.ModNone),
 			Hidden:      false,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				database := v.table.GetCell(r, 0).Text
-				schema := v.table.GetCell(r, 1).Text
-				name := v.table.GetCell(r, 2).Text
-
-				service := sdk.NewSchemaObjectIdentifier(database, schema, name)
+				service, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewServiceInstancesView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&ServiceInstancesOptions{
 							Service: &service,
 						},
@@ -165,18 +178,15 @@ func (v *ServicesView) GetBindings(ctx context.Context, applicationState *Applic
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				serviceName := v.table.GetCell(r, 2).Text
-				service := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, serviceName,
-				)
+				service, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeService,
 							ObjectIdentifier: service,
@@ -190,20 +200,19 @@ func (v *ServicesView) GetBindings(ctx context.Context, applicationState *Applic
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				serviceName := v.table.GetCell(r, 2).Text
-				service := sdk.NewSchemaObjectIdentifier(databaseName, schemaName, serviceName)
-
-				message := fmt.Sprintf("Drop service %s?", service.FullyQualifiedName())
+				service, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "service", service, func(action bool) {
 					if action {
 						err := v.connectionManager.GetClient().Services.Drop(ctx, service, &snowflake.DropServiceOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped service %s", service.FullyQualifiedName()))
 					} else {
@@ -216,7 +225,3 @@ func (v *ServicesView) GetBindings(ctx context.Context, applicationState *Applic
 		},
 	}
 }
-
-func (v *ServicesView) GetRender() tview.Primitive {
-	return v.table
-}
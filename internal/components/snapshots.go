@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type SnapshotsView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *SnapshotsOptions
 }
@@ -24,35 +25,44 @@ type SnapshotsOptions struct {
 func NewSnapshotsView(connectionManager *snowflake.ConnectionManager, opts *SnapshotsOptions) *SnapshotsView {
 	snapshots := &SnapshotsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	snapshots.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return snapshots
 }
 
-func (v *SnapshotsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating stages data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "snapshots",
+		Aliases:     []string{"snapshot"},
+		Description: "snapshots of service volumes",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewSnapshotsView(cm, &SnapshotsOptions{
+				Database: scope.Database,
+				Schema:   scope.Schema,
+			})
+		},
+		States: []StateRule{
+			{Column: "State", Values: []string{"CREATED", "AVAILABLE"}, Style: "ok"},
+			{Column: "State", Values: []string{"CREATING", "PENDING"}, Style: "warning"},
+			{Column: "State", Values: []string{"FAILED"}, Style: "bad"},
+		},
+	})
 }
 
-func (t *SnapshotsView) getData(ctx context.Context, opts *SnapshotsOptions) (*Table, error) {
+func (t *SnapshotsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	title := "snapshots"
 	snowflakeOpts := snowflake.ShowSnapshotOptions{}
 	if opts.Database != nil {
-		title = fmt.Sprintf("snapshots([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		title = scopedTitle("snapshots", opts.Database.FullyQualifiedName())
 		snowflakeOpts.Database = opts.Database
 	}
 	if opts.Schema != nil {
-		title = fmt.Sprintf("stages([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		title = scopedTitle("stages", opts.Schema.FullyQualifiedName())
 		snowflakeOpts.Schema = opts.Schema
 	}
 
@@ -62,21 +72,25 @@ func (t *SnapshotsView) getData(ctx context.Context, opts *SnapshotsOptions) (*T
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Service", "Volume", "Size", "State"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, snapshot := range snapshots {
-		rows = append(rows, []string{
-			snapshot.DatabaseName,
-			snapshot.SchemaName,
-			snapshot.Name,
-			snapshot.ServiceName,
-			snapshot.VolumeName,
-			snapshot.Size,
-			snapshot.State,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				snapshot.DatabaseName,
+				snapshot.SchemaName,
+				snapshot.Name,
+				snapshot.ServiceName,
+				snapshot.VolumeName,
+				snapshot.Size,
+				snapshot.State,
+			},
+			Record:     snapshot,
+			Identifier: sdk.NewSchemaObjectIdentifier(snapshot.DatabaseName, snapshot.SchemaName, snapshot.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -89,22 +103,21 @@ func (t *SnapshotsView) GetBindings(ctx context.Context, applicationState *Appli
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				databaseName := t.table.GetCell(r, 0).Text
-				schemaName := t.table.GetCell(r, 1).Text
-				snapshotName := t.table.GetCell(r, 2).Text
-				snapshot := sdk.NewSchemaObjectIdentifier(databaseName, schemaName, snapshotName)
+				snapshot, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
-				message := fmt.Sprintf("Drop snapshot %s?", snapshot.FullyQualifiedName())
-
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "snapshot", snapshot, func(action bool) {
 					if action {
 						err := t.connectionManager.GetClient().Snapshots.Drop(ctx, &snowflake.DropSnapshotOptions{
 							Snapshot: &snapshot,
 						})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped snapshot %s", snapshot.FullyQualifiedName()))
 					} else {
@@ -117,7 +130,3 @@ func (t *SnapshotsView) GetBindings(ctx context.Context, applicationState *Appli
 		},
 	}
 }
-
-func (v *SnapshotsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -2,10 +2,11 @@ package components
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -35,47 +36,74 @@ func NewSnowflakeContext(cm *snowflake.ConnectionManager) *SnowflakeContext {
 }
 
 func (s *SnowflakeContext) updateTable() {
-	s.table.SetCell(0, 0, tview.NewTableCell(fmt.Sprintf("[orange]Database: [gray]%s", s.Database)).SetAlign(tview.AlignLeft))
-	s.table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("[orange]Schema: [gray]%s", s.Schema)).SetAlign(tview.AlignLeft))
-	s.table.SetCell(2, 0, tview.NewTableCell(fmt.Sprintf("[orange]Warehouse: [gray]%s", s.Warehouse)).SetAlign(tview.AlignLeft))
-	s.table.SetCell(3, 0, tview.NewTableCell(fmt.Sprintf("[orange]Role: [gray]%s", s.Role)).SetAlign(tview.AlignLeft))
-
-	s.table.SetCell(0, 1, tview.NewTableCell(fmt.Sprintf("[orange]Account: [gray]%s", s.Account)).SetAlign(tview.AlignLeft))
-	s.table.SetCell(1, 1, tview.NewTableCell(fmt.Sprintf("[orange]Account Name: [gray]%s", s.AccountName)).SetAlign(tview.AlignLeft))
-	s.table.SetCell(2, 1, tview.NewTableCell(fmt.Sprintf("[orange]Organization Name: [gray]%s", s.OrganizationName)).SetAlign(tview.AlignLeft))
-	s.table.SetCell(3, 1, tview.NewTableCell(fmt.Sprintf("[orange]Region: [gray]%s", s.Region)).SetAlign(tview.AlignLeft))
+	s.table.SetCell(0, 0, s.cell("Database", s.Database).SetAlign(tview.AlignLeft))
+	s.table.SetCell(1, 0, s.cell("Schema", s.Schema).SetAlign(tview.AlignLeft))
+	s.table.SetCell(2, 0, s.cell("Warehouse", s.Warehouse).SetAlign(tview.AlignLeft))
+	s.table.SetCell(3, 0, s.cell("Role", s.Role).SetAlign(tview.AlignLeft))
+
+	s.table.SetCell(0, 1, s.cell("Account", s.Account).SetAlign(tview.AlignLeft))
+	s.table.SetCell(1, 1, s.cell("Account Name", s.AccountName).SetAlign(tview.AlignLeft))
+	s.table.SetCell(2, 1, s.cell("Organization Name", s.OrganizationName).SetAlign(tview.AlignLeft))
+	s.table.SetCell(3, 1, s.cell("Region", s.Region).SetAlign(tview.AlignLeft))
+
+	// the environment of the connection is shown as a banner
+	environment := s.connectionManager.Environment()
+	banner := tview.NewTableCell("")
+	if environment != "" {
+		banner = tview.NewTableCell(" " + strings.ToUpper(tview.Escape(environment)) + " ").
+			SetTextColor(currentTheme.Background).
+			SetBackgroundColor(environmentColor(environment)).
+			SetAttributes(tcell.AttrBold)
+	}
+	s.table.SetCell(0, 2, banner)
+
+	readOnly := tview.NewTableCell("")
+	if s.connectionManager.ReadOnly() {
+		readOnly = tview.NewTableCell(" READ-ONLY ").
+			SetTextColor(currentTheme.Background).
+			SetBackgroundColor(currentTheme.Muted).
+			SetAttributes(tcell.AttrBold)
+	}
+	s.table.SetCell(1, 2, readOnly)
 }
 
-func (s *SnowflakeContext) Update(ctx context.Context) error {
-	type contextQuery struct {
-		database         sql.NullString
-		schema           sql.NullString
-		warehouse        sql.NullString
-		role             sql.NullString
-		account          sql.NullString
-		accountName      sql.NullString
-		organizationName sql.NullString
-		region           sql.NullString
+// environmentColor is the color of the banner of an environment
+func environmentColor(environment string) tcell.Color {
+	switch environment {
+	case "prod":
+		return currentTheme.StateBad
+	case "staging":
+		return currentTheme.Warning
+	case "dev":
+		return currentTheme.StateOK
 	}
+	return currentTheme.Muted
+}
+
+// cell renders a session detail, its label and value
+func (s *SnowflakeContext) cell(label string, value string) *tview.TableCell {
+	return tview.NewTableCell(fmt.Sprintf("%s%s: %s%s", colorTag(currentTheme.Label), label, colorTag(currentTheme.Muted), tview.Escape(value)))
+}
 
-	var context contextQuery
-	err := s.connectionManager.GetClient().SDKClient.GetConn().QueryRowContext(ctx, "SELECT CURRENT_DATABASE(), CURRENT_SCHEMA(), CURRENT_WAREHOUSE(), CURRENT_ROLE(), CURRENT_ACCOUNT(), CURRENT_ACCOUNT_NAME(), CURRENT_ORGANIZATION_NAME(), CURRENT_REGION();").Scan(&context.database, &context.schema, &context.warehouse, &context.role, &context.account, &context.accountName, &context.organizationName, &context.region)
+func (s *SnowflakeContext) GetData(ctx context.Context) (*snowflake.SessionDetails, error) {
+	session, err := s.connectionManager.GetClient().Sessions.Current(ctx)
 	if err != nil {
-		return fmt.Errorf("error fetching snowflake context %w", err)
+		return nil, fmt.Errorf("error fetching snowflake context %w", err)
 	}
+	return session, nil
+}
 
-	s.Database = context.database.String
-	s.Schema = context.schema.String
-	s.Warehouse = context.warehouse.String
-	s.Role = context.role.String
-	s.Account = context.account.String
-	s.AccountName = context.accountName.String
-	s.OrganizationName = context.organizationName.String
-	s.Region = context.region.String
+func (s *SnowflakeContext) Render(session *snowflake.SessionDetails) {
+	s.Database = session.Database
+	s.Schema = session.Schema
+	s.Warehouse = session.Warehouse
+	s.Role = session.Role
+	s.Account = session.Account
+	s.AccountName = session.AccountName
+	s.OrganizationName = session.OrganizationName
+	s.Region = session.Region
 
 	s.updateTable()
-
-	return nil
 }
 
 func (s *SnowflakeContext) GetRender() *tview.Table {
@@ -0,0 +1,163 @@
+package components
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+type columnKind int
+
+const (
+	textColumn columnKind = iota
+	numberColumn
+	timeColumn
+)
+
+// timeLayouts are the timestamp formats found in view cells
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// sizeUnits scale numbers with a unit suffix such as snapshot sizes
+var sizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+func parseNumber(text string) (float64, bool) {
+	number, unit, _ := strings.Cut(strings.TrimSpace(text), " ")
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+	if unit == "" {
+		return value, true
+	}
+	scale, ok := sizeUnits[strings.ToLower(unit)]
+	return value * scale, ok
+}
+
+func parseTime(text string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if value, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+			return value, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// kindOf picks how a column compares, a column is only numeric or a
+// timestamp when every non empty cell parses as such
+func kindOf(rows []registry.Row, column int) columnKind {
+	numbers, times := true, true
+	for _, row := range rows {
+		cell := row.Cells[column]
+		if cell == "" {
+			continue
+		}
+		if _, ok := parseNumber(cell); !ok {
+			numbers = false
+		}
+		if _, ok := parseTime(cell); !ok {
+			times = false
+		}
+	}
+
+	switch {
+	case numbers:
+		return numberColumn
+	case times:
+		return timeColumn
+	}
+	return textColumn
+}
+
+func compareCells(kind columnKind, a, b string) int {
+	switch kind {
+	case numberColumn:
+		x, _ := parseNumber(a)
+		y, _ := parseNumber(b)
+		return cmp.Compare(x, y)
+	case timeColumn:
+		x, _ := parseTime(a)
+		y, _ := parseTime(b)
+		return x.Compare(y)
+	}
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// sortRows orders the indices of the table rows by the column
+func sortRows(table *registry.Table, order []int, column int, descending bool) {
+	if column < 0 || column >= len(table.Columns) {
+		return
+	}
+
+	kind := kindOf(table.Rows, column)
+	slices.SortStableFunc(order, func(i, j int) int {
+		a, b := table.Rows[i].Cells[column], table.Rows[j].Cells[column]
+		// empty cells come last in both orders whatever the column kind
+		if a == "" || b == "" {
+			return cmp.Compare(len(b), len(a))
+		}
+		result := compareCells(kind, a, b)
+		if descending {
+			return -result
+		}
+		return result
+	})
+}
+
+// Sortable is implemented by views that sort their rows by a column
+type Sortable interface {
+	SortNext(step int)
+	ReverseSort()
+}
+
+func sortBindings(sortable Sortable) []*KeyBinding {
+	return []*KeyBinding{
+		{
+			Description: "sort next column",
+			Event:       tcell.NewEventKey(tcell.KeyRune, '>', tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				sortable.SortNext(1)
+				return nil
+			},
+		},
+		{
+			Description: "sort previous column",
+			Event:       tcell.NewEventKey(tcell.KeyRune, '<', tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				sortable.SortNext(-1)
+				return nil
+			},
+		},
+		{
+			Description: "reverse sort",
+			Event:       tcell.NewEventKey(tcell.KeyRune, '!', tcell.ModNone),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				sortable.ReverseSort()
+				return nil
+			},
+		},
+	}
+}
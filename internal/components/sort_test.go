@@ -0,0 +1,101 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/costrouc/snowctl/internal/registry"
+)
+
+// testTable returns a single column table of the cells
+func testTable(cells ...string) *registry.Table {
+	table := &registry.Table{Columns: []string{"Value"}}
+	for _, cell := range cells {
+		table.Rows = append(table.Rows, registry.Row{Cells: []string{cell}})
+	}
+	return table
+}
+
+// sortedCells sorts the rows of the table and returns their cells
+func sortedCells(table *registry.Table, descending bool) []string {
+	order := make([]int, len(table.Rows))
+	for i := range order {
+		order[i] = i
+	}
+	sortRows(table, order, 0, descending)
+
+	cells := make([]string, len(order))
+	for i, r := range order {
+		cells[i] = table.Rows[r].Cells[0]
+	}
+	return cells
+}
+
+func TestSortRows(t *testing.T) {
+	tests := []struct {
+		name       string
+		cells      []string
+		ascending  []string
+		descending []string
+	}{
+		{
+			name:       "numbers",
+			cells:      []string{"10", "9", "1,200", "-1", "2.5"},
+			ascending:  []string{"-1", "2.5", "9", "10", "1,200"},
+			descending: []string{"1,200", "10", "9", "2.5", "-1"},
+		},
+		{
+			name:       "byte sizes",
+			cells:      []string{"1 GB", "512 MB", "2 KiB", "900 b", "1 kb"},
+			ascending:  []string{"900 b", "1 kb", "2 KiB", "512 MB", "1 GB"},
+			descending: []string{"1 GB", "512 MB", "2 KiB", "1 kb", "900 b"},
+		},
+		{
+			name:       "timestamps",
+			cells:      []string{"2024-05-01 12:00:00", "2023-12-31", "2024-05-01T09:00:00Z", "2024-01-15 08:30:00.5 +0000 UTC"},
+			ascending:  []string{"2023-12-31", "2024-01-15 08:30:00.5 +0000 UTC", "2024-05-01T09:00:00Z", "2024-05-01 12:00:00"},
+			descending: []string{"2024-05-01 12:00:00", "2024-05-01T09:00:00Z", "2024-01-15 08:30:00.5 +0000 UTC", "2023-12-31"},
+		},
+		{
+			// 10 would come before 9 as text
+			name:       "text",
+			cells:      []string{"b", "A", "10", "9", "a"},
+			ascending:  []string{"10", "9", "A", "a", "b"},
+			descending: []string{"b", "A", "a", "9", "10"},
+		},
+		{
+			name:       "blanks last",
+			cells:      []string{"", "2", "", "10", "1"},
+			ascending:  []string{"1", "2", "10", "", ""},
+			descending: []string{"10", "2", "1", "", ""},
+		},
+		{
+			// a size column with a missing unit is compared as text
+			name:       "unknown unit",
+			cells:      []string{"2 GB", "10 parsecs"},
+			ascending:  []string{"10 parsecs", "2 GB"},
+			descending: []string{"2 GB", "10 parsecs"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sortedCells(testTable(test.cells...), false); !slices.Equal(got, test.ascending) {
+				t.Errorf("got ascending %q, want %q", got, test.ascending)
+			}
+			if got := sortedCells(testTable(test.cells...), true); !slices.Equal(got, test.descending) {
+				t.Errorf("got descending %q, want %q", got, test.descending)
+			}
+		})
+	}
+}
+
+func TestSortRowsOutOfRange(t *testing.T) {
+	table := testTable("b", "a")
+	order := []int{0, 1}
+	for _, column := range []int{-1, 1} {
+		sortRows(table, order, column, false)
+		if !slices.Equal(order, []int{0, 1}) {
+			t.Errorf("column %d reordered the rows %v", column, order)
+		}
+	}
+}
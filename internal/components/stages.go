@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type StagesView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *StagesOptions
 }
@@ -24,55 +25,65 @@ type StagesOptions struct {
 func NewStagesView(connectionManager *snowflake.ConnectionManager, opts *StagesOptions) *StagesView {
 	stages := &StagesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	stages.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return stages
 }
 
-func (v *StagesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating stages data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "stages",
+		Aliases:     []string{"stage"},
+		Description: "stages in every schema or in a schema",
+		Scopes:      []registry.ScopeArgument{registry.ScopeSchema},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			opts := &StagesOptions{}
+			if scope.Schema != nil {
+				database, schema := scope.Schema.DatabaseName(), scope.Schema.Name()
+				opts.Database = &database
+				opts.Schema = &schema
+			}
+			return NewStagesView(cm, opts)
+		},
+	})
 }
 
-func (t *StagesView) getData(ctx context.Context, opts *StagesOptions) (*Table, error) {
-	sdkOpts := sdk.NewShowStageRequest()
+func (t *StagesView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
+	snowflakeOpts := &snowflake.ShowStageOptions{}
 	title := "stages"
 	if opts.Database != nil && opts.Schema != nil {
-		sdkOpts.WithIn(&sdk.In{
-			Schema: sdk.NewDatabaseObjectIdentifier(*opts.Database, *opts.Schema),
-		})
-		title = fmt.Sprintf("stages([pink]%s.%s[blue])", *opts.Database, *opts.Schema)
+		schema := sdk.NewDatabaseObjectIdentifier(*opts.Database, *opts.Schema)
+		snowflakeOpts.Schema = &schema
+		title = scopedTitle("stages", *opts.Database+"."+*opts.Schema)
 	}
 
-	stages, err := t.connectionManager.GetClient().SDKClient.Stages.Show(ctx, sdkOpts)
+	stages, err := t.connectionManager.GetClient().Stages.Show(ctx, snowflakeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show stages %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Owner", "Type"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, stage := range stages {
-		rows = append(rows, []string{
-			stage.DatabaseName,
-			stage.SchemaName,
-			stage.Name,
-			stage.Owner,
-			stage.Type,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				stage.DatabaseName,
+				stage.SchemaName,
+				stage.Name,
+				stage.Owner,
+				stage.Type,
+			},
+			Record:     stage,
+			Identifier: sdk.NewSchemaObjectIdentifier(stage.DatabaseName, stage.SchemaName, stage.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -86,18 +97,15 @@ func (t *StagesView) GetBindings(ctx context.Context, applicationState *Applicat
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				databaseName := t.table.GetCell(r, 0).Text
-				schemaName := t.table.GetCell(r, 1).Text
-				name := t.table.GetCell(r, 2).Text
-				stage := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, name,
-				)
+				stage, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeStage,
 							ObjectIdentifier: stage,
@@ -111,20 +119,19 @@ func (t *StagesView) GetBindings(ctx context.Context, applicationState *Applicat
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				databaseName := t.table.GetCell(r, 0).Text
-				schemaName := t.table.GetCell(r, 1).Text
-				stageName := t.table.GetCell(r, 2).Text
-				stage := sdk.NewSchemaObjectIdentifier(databaseName, schemaName, stageName)
+				stage, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
-				message := fmt.Sprintf("Drop stage %s?", stage.FullyQualifiedName())
-
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "stage", stage, func(action bool) {
 					if action {
-						err := t.connectionManager.GetClient().SDKClient.Stages.Drop(ctx, sdk.NewDropStageRequest(stage))
+						err := t.connectionManager.GetClient().Stages.Drop(ctx, stage, &snowflake.DropStageOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped stage %s", stage.FullyQualifiedName()))
 					} else {
@@ -137,7 +144,3 @@ func (t *StagesView) GetBindings(ctx context.Context, applicationState *Applicat
 		},
 	}
 }
-
-func (v *StagesView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -0,0 +1,119 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/config"
+	"github.com/costrouc/snowctl/internal/formatter"
+	"github.com/gdamore/tcell/v2"
+)
+
+// StateRule is the rule of the configuration file, the default rules of the
+// views are written the same way
+type StateRule = config.StateRule
+
+// Stateful is implemented by views whose rows are styled by state rules
+type Stateful interface {
+	SetStates(rules []StateRule)
+}
+
+// stateGlyphs are the glyphs of the styles, other colors use the ok glyph
+var stateGlyphs = map[string]string{
+	"ok":      "●",
+	"warning": "◐",
+	"bad":     "✖",
+}
+
+// validateState reports rules that can never match or have an unknown style
+func validateState(r *StateRule) error {
+	if r.Column == "" {
+		return fmt.Errorf("state rule without a column")
+	}
+	if len(r.Values) == 0 && r.Above == nil {
+		return fmt.Errorf("state rule of %s needs values or above", r.Column)
+	}
+	if _, _, err := stateStyle(r); err != nil {
+		return fmt.Errorf("state rule of %s %w", r.Column, err)
+	}
+	return nil
+}
+
+// stateStyle returns the color and glyph of the rule in the current theme
+func stateStyle(r *StateRule) (tcell.Color, string, error) {
+	glyph, ok := stateGlyphs[strings.ToLower(r.Style)]
+	if !ok {
+		glyph = stateGlyphs["ok"]
+	}
+	if r.Glyph != "" {
+		glyph = r.Glyph
+	}
+
+	switch strings.ToLower(r.Style) {
+	case "ok":
+		return currentTheme.StateOK, glyph, nil
+	case "warning":
+		return currentTheme.Warning, glyph, nil
+	case "bad":
+		return currentTheme.StateBad, glyph, nil
+	}
+	color, err := parseColor(r.Style)
+	if err != nil {
+		return color, glyph, fmt.Errorf("expected a style of ok, warning, bad or a color, %w", err)
+	}
+	return color, glyph, nil
+}
+
+// stateMatches reports whether the value of the column matches the rule
+func stateMatches(r *StateRule, value string) bool {
+	if r.Above != nil {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil && number > *r.Above
+	}
+	for _, v := range r.Values {
+		if strings.EqualFold(v, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rowState is the style of a row matched by a state rule
+type rowState struct {
+	color tcell.Color
+	glyph string
+	// column is the rule column, it may be hidden
+	column string
+}
+
+// matchState returns the style of the first rule matching the row, the
+// cells are in the column order of GetData and the record may be nil
+func matchState(rules []StateRule, columns []string, cells []string, record any) *rowState {
+	var fields []formatter.Field
+	for i := range rules {
+		rule := &rules[i]
+
+		value, ok := "", false
+		if c := columnIndex(columns, rule.Column); c >= 0 && c < len(cells) {
+			value, ok = cells[c], true
+		} else if record != nil {
+			if fields == nil {
+				fields = formatter.Fields(record)
+			}
+			if f := fieldIndex(fields, rule.Column); f >= 0 && !fields[f].Null {
+				value, ok = fields[f].Text, true
+			}
+		}
+		if !ok || !stateMatches(rule, value) {
+			continue
+		}
+
+		color, glyph, err := stateStyle(rule)
+		if err != nil {
+			continue
+		}
+		return &rowState{color: color, glyph: glyph, column: rule.Column}
+	}
+	return nil
+}
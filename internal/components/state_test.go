@@ -0,0 +1,116 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+func above(value float64) *float64 {
+	return &value
+}
+
+func TestMatchState(t *testing.T) {
+	rules := []StateRule{
+		{Column: "Status", Values: []string{"FAILED", "Done"}, Style: "bad"},
+		{Column: "restart_count", Above: above(2), Style: "warning", Glyph: "!"},
+		{Column: "Status", Values: []string{"READY", "FAILED"}, Style: "ok"},
+		{Column: "Status", Values: []string{"PENDING"}, Style: "reddish"},
+	}
+	columns := []string{"Name", "Status"}
+
+	tests := []struct {
+		name   string
+		cells  []string
+		record any
+		color  string
+		glyph  string
+		column string
+	}{
+		{name: "value", cells: []string{"A", "READY"}, color: "ok", glyph: "●", column: "Status"},
+		{name: "first rule", cells: []string{"A", "FAILED"}, color: "bad", glyph: "✖", column: "Status"},
+		{name: "case and spaces", cells: []string{"A", " done "}, color: "bad", glyph: "✖", column: "Status"},
+		{name: "no match", cells: []string{"A", "STARTING"}},
+		// the style of an invalid rule never applies
+		{name: "invalid style", cells: []string{"A", "PENDING"}},
+		{
+			name:   "hidden field above",
+			cells:  []string{"A", "STARTING"},
+			record: snowflake.ServiceContainer{Status: "STARTING", RestartCount: 3},
+			color:  "warning",
+			glyph:  "!",
+			column: "restart_count",
+		},
+		{
+			name:   "hidden field not above",
+			cells:  []string{"A", "STARTING"},
+			record: &snowflake.ServiceContainer{Status: "STARTING", RestartCount: 2},
+		},
+		{name: "missing field", cells: []string{"A", "STARTING"}, record: struct{ Name string }{"A"}},
+		{name: "short row", cells: []string{"A"}},
+	}
+	colors := map[string]func() string{
+		"ok":      func() string { return currentTheme.StateOK.String() },
+		"warning": func() string { return currentTheme.Warning.String() },
+		"bad":     func() string { return currentTheme.StateBad.String() },
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := matchState(rules, columns, test.cells, test.record)
+			if test.color == "" {
+				if state != nil {
+					t.Errorf("got state %+v, want none", *state)
+				}
+				return
+			}
+			if state == nil {
+				t.Fatal("no rule matched")
+			}
+			if color := state.color.String(); color != colors[test.color]() || state.glyph != test.glyph || state.column != test.column {
+				t.Errorf("got %s %s of %s, want %s %s of %s", color, state.glyph, state.column, test.color, test.glyph, test.column)
+			}
+		})
+	}
+}
+
+func TestStateMatchesAbove(t *testing.T) {
+	rule := &StateRule{Column: "Load", Above: above(0.5), Style: "bad"}
+	for value, want := range map[string]bool{"0.75": true, " 1 ": true, "0.5": false, "-1": false, "high": false, "": false} {
+		if got := stateMatches(rule, value); got != want {
+			t.Errorf("%q matched %t, want %t", value, got, want)
+		}
+	}
+}
+
+func TestValidateState(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  StateRule
+		valid bool
+	}{
+		{"values", StateRule{Column: "Status", Values: []string{"READY"}, Style: "OK"}, true},
+		{"above", StateRule{Column: "restart_count", Above: above(0), Style: "warning"}, true},
+		{"color", StateRule{Column: "Status", Values: []string{"READY"}, Style: "#00afff", Glyph: "+"}, true},
+		{"without column", StateRule{Values: []string{"READY"}, Style: "ok"}, false},
+		{"without values", StateRule{Column: "Status", Style: "ok"}, false},
+		{"unknown style", StateRule{Column: "Status", Values: []string{"READY"}, Style: "reddish"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateState(&test.rule); (err == nil) != test.valid {
+				t.Errorf("got %v, want valid %t", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestDefaultStates(t *testing.T) {
+	for _, resource := range registry.Resources() {
+		for _, rule := range resource.States {
+			if err := validateState(&rule); err != nil {
+				t.Errorf("%s %v", resource.Name, err)
+			}
+		}
+	}
+}
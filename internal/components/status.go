@@ -17,16 +17,16 @@ func NewStatus() *Status {
 
 func (s *Status) GetRender() *tview.TextView {
 	if s.view == nil {
-		s.view = tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText("SNOWCTL").SetTextColor(tcell.ColorGrey).SetTextStyle(tcell.StyleDefault.Bold(true))
+		s.view = tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText("SNOWCTL").SetTextColor(currentTheme.Muted).SetTextStyle(tcell.StyleDefault.Bold(true))
 	}
 
 	return s.view
 }
 
 func (s *Status) SetError(err error) {
-	s.view.SetText(fmt.Sprintf("Error: %s", err.Error())).SetTextColor(tcell.ColorRed)
+	s.view.SetText(fmt.Sprintf("Error: %s", err.Error())).SetTextColor(currentTheme.Error)
 }
 
 func (s *Status) SetMessage(message string) {
-	s.view.SetText(message).SetTextColor(tcell.ColorGrey)
+	s.view.SetText(message).SetTextColor(currentTheme.Muted)
 }
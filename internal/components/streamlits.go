@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type StreamlitsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *StreamlitsOptions
 }
 
@@ -24,56 +25,64 @@ type StreamlitsOptions struct {
 func NewStreamlitsView(connectionManager *snowflake.ConnectionManager, opts *StreamlitsOptions) *StreamlitsView {
 	streamlits := &StreamlitsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	streamlits.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return streamlits
 }
 
-func (v *StreamlitsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating network rules data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "streamlits",
+		Aliases:     []string{"streamlit"},
+		Description: "streamlit applications",
+		Scopes:      []registry.ScopeArgument{registry.ScopeDatabase, registry.ScopeSchema},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewStreamlitsView(cm, &StreamlitsOptions{
+				Database: scope.Database,
+				Schema:   scope.Schema,
+			})
+		},
+	})
 }
 
-func (t *StreamlitsView) getData(ctx context.Context, opts *StreamlitsOptions) (*Table, error) {
+func (t *StreamlitsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	title := "streamlits"
-	snowflakeOpts := sdk.NewShowStreamlitRequest()
+	snowflakeOpts := &snowflake.ShowStreamlitOptions{}
 	if opts.Database != nil {
-		snowflakeOpts.WithIn(&sdk.In{Database: *opts.Database})
-		title = fmt.Sprintf("streamlits([pink]%s[blue])", opts.Database.FullyQualifiedName())
+		snowflakeOpts.Database = opts.Database
+		title = scopedTitle("streamlits", opts.Database.FullyQualifiedName())
 	}
 	if opts.Schema != nil {
-		snowflakeOpts.WithIn(&sdk.In{Schema: *opts.Schema})
-		title = fmt.Sprintf("streamlits([pink]%s[blue])", opts.Schema.FullyQualifiedName())
+		snowflakeOpts.Schema = opts.Schema
+		title = scopedTitle("streamlits", opts.Schema.FullyQualifiedName())
 	}
 
-	streamlits, err := t.connectionManager.GetClient().SDKClient.Streamlits.Show(ctx, snowflakeOpts)
+	streamlits, err := t.connectionManager.GetClient().Streamlits.Show(ctx, snowflakeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show streamlits %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Title"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, streamlit := range streamlits {
-		rows = append(rows, []string{
-			streamlit.DatabaseName,
-			streamlit.SchemaName,
-			streamlit.Name,
-			streamlit.Title,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				streamlit.DatabaseName,
+				streamlit.SchemaName,
+				streamlit.Name,
+				streamlit.Title,
+			},
+			Record:     streamlit,
+			Identifier: sdk.NewSchemaObjectIdentifier(streamlit.DatabaseName, streamlit.SchemaName, streamlit.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   title,
 		Columns: columns,
 		Rows:    rows,
@@ -87,18 +96,15 @@ func (v *StreamlitsView) GetBindings(ctx context.Context, applicationState *Appl
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				streamlitName := v.table.GetCell(r, 2).Text
-				streamlit := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, streamlitName,
-				)
+				streamlit, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeStreamlit,
 							ObjectIdentifier: streamlit,
@@ -110,7 +116,3 @@ func (v *StreamlitsView) GetBindings(ctx context.Context, applicationState *Appl
 		},
 	}
 }
-
-func (v *StreamlitsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -0,0 +1,347 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TableView renders a Table and keeps the data it was last rendered from
+// so that key bindings and exports do not need to query snowflake again
+type TableView struct {
+	table *tview.Table
+	data  *registry.Table
+
+	// source is the data in the order returned by snowflake, data is
+	// narrowed by the filter and sorted by sortColumn unless it is negative
+	source         *registry.Table
+	sortColumn     int
+	sortDescending bool
+	filter         string
+	filterMode     FilterMode
+
+	// changed flags the source rows that changed since the previous render
+	changed []bool
+
+	// shownColumns are the columns picked for display see SetColumns,
+	// sourceCells are the cells of the source rows with every column of
+	// GetData, in cellColumns, which the state rules match
+	shownColumns []string
+	sourceCells  [][]string
+	cellColumns  []string
+
+	// states are the rules styling the rows by the state of their objects
+	states []StateRule
+}
+
+func NewTableView() *TableView {
+	tableView := &TableView{
+		table:      tview.NewTable(),
+		sortColumn: -1,
+	}
+
+	tableView.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
+	tableView.table.SetSelectedStyle(tcell.StyleDefault.Foreground(currentTheme.Background).Background(currentTheme.Selected))
+	tableView.table.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action != tview.MouseLeftClick || tableView.data == nil {
+			return action, event
+		}
+
+		row, column := tableView.table.CellAt(event.Position())
+		if row != 0 || column < 0 {
+			return action, event
+		}
+		tableView.SortBy(column)
+		return tview.MouseConsumed, nil
+	})
+
+	return tableView
+}
+
+// Render replaces the rendered data, when the view is rendered again the
+// selected row is kept and the rows that changed are highlighted
+func (t *TableView) Render(table *registry.Table) {
+	cells := make([][]string, len(table.Rows))
+	for r, row := range table.Rows {
+		cells[r] = row.Cells
+	}
+	columns := table.Columns
+	table = projectColumns(table, t.shownColumns)
+	t.changed = changedRows(t.source, table)
+	t.source = table
+	t.sourceCells = cells
+	t.cellColumns = columns
+	t.update()
+}
+
+// SetColumns picks the columns shown by name and in order, see
+// projectColumns, it applies from the next render
+func (t *TableView) SetColumns(columns []string) {
+	t.shownColumns = columns
+}
+
+// SetStates sets the rules styling the rows, the first matching rule of a
+// row applies, it applies from the next render
+func (t *TableView) SetStates(rules []StateRule) {
+	t.states = rules
+}
+
+// SortBy sorts the rows by the column, sorting by the same column again
+// reverses the order
+func (t *TableView) SortBy(column int) {
+	if column == t.sortColumn {
+		t.sortDescending = !t.sortDescending
+	} else {
+		t.sortColumn = column
+		t.sortDescending = false
+	}
+
+	t.update()
+}
+
+// SortNext moves the sort to the column on the right, or on the left with
+// a negative step, wrapping around
+func (t *TableView) SortNext(step int) {
+	if t.source == nil || len(t.source.Columns) == 0 {
+		return
+	}
+
+	columns := len(t.source.Columns)
+	if t.sortColumn < 0 && step < 0 {
+		t.SortBy(columns - 1)
+		return
+	}
+	t.SortBy(((t.sortColumn+step)%columns + columns) % columns)
+}
+
+// ReverseSort flips the order of the current sort column
+func (t *TableView) ReverseSort() {
+	if t.sortColumn >= 0 {
+		t.SortBy(t.sortColumn)
+	}
+}
+
+// SetFilter narrows the rows to the ones matching the query, the filter is
+// kept when the query is invalid
+func (t *TableView) SetFilter(query string, mode FilterMode) error {
+	if _, err := parseFilter(query, mode, t.columns()); err != nil {
+		return err
+	}
+
+	t.filter = query
+	t.filterMode = mode
+	t.update()
+	return nil
+}
+
+func (t *TableView) GetFilter() (string, FilterMode) {
+	return t.filter, t.filterMode
+}
+
+func (t *TableView) columns() []string {
+	if t.source == nil {
+		return nil
+	}
+	return t.source.Columns
+}
+
+// update renders the source data filtered and sorted
+func (t *TableView) update() {
+	if t.source == nil {
+		return
+	}
+
+	terms, err := parseFilter(t.filter, t.filterMode, t.source.Columns)
+	if err != nil {
+		terms = nil
+	}
+	order := filterRows(t.source, terms)
+	sortRows(t.source, order, t.sortColumn, t.sortDescending)
+
+	table := *t.source
+	table.Rows = make([]registry.Row, len(order))
+	changed := make([]bool, len(order))
+	states := make([]*rowState, len(order))
+	for i, r := range order {
+		table.Rows[i] = t.source.Rows[r]
+		changed[i] = r < len(t.changed) && t.changed[r]
+		if len(t.states) > 0 {
+			states[i] = matchState(t.states, t.cellColumns, t.sourceCells[r], table.Rows[i].Record)
+		}
+	}
+	t.show(&table, changed, states)
+}
+
+func (t *TableView) title() string {
+	if t.source == nil {
+		return colorTag(currentTheme.Title) + "loading"
+	}
+
+	title := tableTitle(t.source)
+	if strings.TrimSpace(t.filter) != "" && t.data != nil {
+		title += fmt.Sprintf("[%s/%s %d%s]", colorTag(currentTheme.Warning), tview.Escape(t.filter), len(t.data.Rows), colorTag(currentTheme.Title))
+	}
+	return title
+}
+
+func (t *TableView) show(table *registry.Table, changed []bool, states []*rowState) {
+	previous := t.data
+	selectedRow, _ := t.table.GetSelection()
+	rowOffset, columnOffset := t.table.GetOffset()
+
+	t.data = table
+	updateTable(t.table, table, changed, states)
+	t.table.SetTitle(t.title())
+	if t.sortColumn >= 0 && t.sortColumn < len(table.Columns) {
+		arrow := " ▲"
+		if t.sortDescending {
+			arrow = " ▼"
+		}
+		t.table.GetCell(0, t.sortColumn).SetText(tview.Escape(table.Columns[t.sortColumn]) + arrow)
+	}
+
+	// the table was drawn empty while loading, start from the first row
+	if previous == nil {
+		t.table.Select(1, 0).ScrollToBeginning()
+		return
+	}
+
+	t.table.SetOffset(rowOffset, columnOffset)
+	if selectedRow >= 1 && selectedRow <= len(previous.Rows) {
+		selectedKey := rowKeys(previous)[selectedRow-1]
+		for r, key := range rowKeys(table) {
+			if key == selectedKey {
+				t.table.Select(r+1, 0)
+				return
+			}
+		}
+	}
+	t.table.Select(min(max(selectedRow, 1), len(table.Rows)), 0)
+}
+
+// SetLoading shows a spinner frame in the title while data is fetched, an
+// empty frame restores the title of the rendered data
+func (t *TableView) SetLoading(frame string) {
+	title := t.title()
+	if frame != "" {
+		title += " " + colorTag(currentTheme.Warning) + frame
+	}
+	t.table.SetTitle(title)
+}
+
+// Selected returns the selected row, nil when there is none
+func (t *TableView) Selected() *registry.Row {
+	r, _ := t.table.GetSelection()
+	if t.data == nil || r < 1 || r > len(t.data.Rows) {
+		return nil
+	}
+	return &t.data.Rows[r-1]
+}
+
+// selectedIdentifier returns the identifier of the selected row, false when
+// no row is selected or its identifier is not a T
+func selectedIdentifier[T sdk.ObjectIdentifier](t *TableView) (T, bool) {
+	var identifier T
+	row := t.Selected()
+	if row == nil {
+		return identifier, false
+	}
+	identifier, ok := row.Identifier.(T)
+	return identifier, ok
+}
+
+// selectedRecord returns the record of the selected row, false when no row
+// is selected or its record is not a T
+func selectedRecord[T any](t *TableView) (T, bool) {
+	var record T
+	row := t.Selected()
+	if row == nil {
+		return record, false
+	}
+	record, ok := row.Record.(T)
+	return record, ok
+}
+
+// GetTable returns the data currently rendered, nil before the first update
+func (t *TableView) GetTable() *registry.Table {
+	return t.data
+}
+
+// SelectedRecord returns the record of the selected row and its name, the
+// fully qualified name of its identifier or else the value of the Name
+// column or of the first column, nil when the row has no record
+func (t *TableView) SelectedRecord() (any, string) {
+	row := t.Selected()
+	if row == nil || row.Record == nil {
+		return nil, ""
+	}
+	if row.Identifier != nil {
+		return row.Record, snowflake.FullyQualifiedName(row.Identifier)
+	}
+
+	name := ""
+	if len(row.Cells) > 0 {
+		name = row.Cells[0]
+	}
+	for c, column := range t.data.Columns {
+		if column == "Name" && c < len(row.Cells) {
+			name = row.Cells[c]
+		}
+	}
+	return row.Record, name
+}
+
+func (t *TableView) GetRender() tview.Primitive {
+	return t.table
+}
+
+// rowKeys identify rows across refreshes by their identifier or else by the
+// columns up to the name column, or the first column, numbering rows that
+// share the same values
+func rowKeys(table *registry.Table) []string {
+	last := 0
+	for c, column := range table.Columns {
+		if column == "Name" {
+			last = c
+			break
+		}
+	}
+
+	keys := make([]string, len(table.Rows))
+	seen := make(map[string]int)
+	for r, row := range table.Rows {
+		key := strings.Join(row.Cells[:min(last+1, len(row.Cells))], "\x00")
+		if row.Identifier != nil {
+			key = snowflake.FullyQualifiedName(row.Identifier)
+		}
+		keys[r] = fmt.Sprintf("%s\x00%d", key, seen[key])
+		seen[key]++
+	}
+	return keys
+}
+
+// changedRows flags the rows that are new or have different values since
+// the previous render of the same table, nil when there is nothing to compare
+func changedRows(previous *registry.Table, table *registry.Table) []bool {
+	if previous == nil || previous.Title != table.Title {
+		return nil
+	}
+
+	values := make(map[string]string, len(previous.Rows))
+	for r, key := range rowKeys(previous) {
+		values[key] = strings.Join(previous.Rows[r].Cells, "\x00")
+	}
+
+	changed := make([]bool, len(table.Rows))
+	for r, key := range rowKeys(table) {
+		value, ok := values[key]
+		row := table.Rows[r]
+		changed[r] = !ok || value != strings.Join(row.Cells, "\x00")
+	}
+	return changed
+}
@@ -6,14 +6,15 @@ import (
 	"strconv"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type TablesView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *TablesOptions
 }
 
@@ -22,47 +23,49 @@ type TablesOptions struct{}
 func NewTablesView(connectionManager *snowflake.ConnectionManager, opts *TablesOptions) *TablesView {
 	tables := &TablesView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	tables.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return tables
 }
 
-func (v *TablesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating tables data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "tables",
+		Aliases:     []string{"table"},
+		Description: "tables in every database",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewTablesView(cm, &TablesOptions{})
+		},
+	})
 }
 
-func (t *TablesView) getData(ctx context.Context) (*Table, error) {
-	tables, err := t.connectionManager.GetClient().SDKClient.Tables.Show(ctx, sdk.NewShowTableRequest())
+func (t *TablesView) GetData(ctx context.Context) (*registry.Table, error) {
+	tables, err := t.connectionManager.GetClient().Tables.Show(ctx, &snowflake.ShowTableOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show tables %w", err)
 	}
 
 	columns := []string{"Database", "Schema", "Name", "Owner", "Kind", "Rows"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, table := range tables {
-		rows = append(rows, []string{
-			table.DatabaseName,
-			table.SchemaName,
-			table.Name,
-			table.Owner,
-			table.Kind,
-			strconv.Itoa(table.Rows),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				table.DatabaseName,
+				table.SchemaName,
+				table.Name,
+				table.Owner,
+				table.Kind,
+				strconv.Itoa(table.Rows),
+			},
+			Record:     table,
+			Identifier: sdk.NewSchemaObjectIdentifier(table.DatabaseName, table.SchemaName, table.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "services",
 		Columns: columns,
 		Rows:    rows,
@@ -76,18 +79,15 @@ func (v *TablesView) GetBindings(ctx context.Context, applicationState *Applicat
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				tableName := v.table.GetCell(r, 2).Text
-				table := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, tableName,
-				)
+				table, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeTable,
 							ObjectIdentifier: table,
@@ -101,20 +101,19 @@ func (v *TablesView) GetBindings(ctx context.Context, applicationState *Applicat
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				tableName := v.table.GetCell(r, 2).Text
-				table := sdk.NewSchemaObjectIdentifier(databaseName, schemaName, tableName)
+				table, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
-				message := fmt.Sprintf("Drop table %s?", table.FullyQualifiedName())
-
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "table", table, func(action bool) {
 					if action {
-						err := v.connectionManager.GetClient().SDKClient.Tables.Drop(ctx, sdk.NewDropTableRequest(table))
+						err := v.connectionManager.GetClient().Tables.Drop(ctx, table, &snowflake.DropTableOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped table %s", table.FullyQualifiedName()))
 					} else {
@@ -127,7 +126,3 @@ func (v *TablesView) GetBindings(ctx context.Context, applicationState *Applicat
 		},
 	}
 }
-
-func (v *TablesView) GetRender() tview.Primitive {
-	return v.table
-}
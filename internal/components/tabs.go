@@ -0,0 +1,231 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// tab is a session of its own, the connection manager holds its client
+// and the views of its history fetch through it
+type tab struct {
+	id                int
+	connectionManager *snowflake.ConnectionManager
+
+	// history is a stack that represents the state of the tab
+	history []Component
+	main    *tview.Pages
+	context *SnowflakeContext
+}
+
+func (t *tab) page() string {
+	return fmt.Sprintf("tab%d", t.id)
+}
+
+// TabBar lists the open tabs, the current one highlighted
+type TabBar struct {
+	view *tview.TextView
+}
+
+func NewTabBar() *TabBar {
+	return &TabBar{
+		view: tview.NewTextView().SetDynamicColors(true).SetWrap(false),
+	}
+}
+
+func (t *TabBar) Render(tabs []*tab, current *tab) {
+	var text strings.Builder
+	for i, tab := range tabs {
+		color := currentTheme.Muted
+		if tab == current {
+			color = currentTheme.Title
+		}
+		fmt.Fprintf(&text, "%s %d %s ", colorTag(color), i+1, tview.Escape(tab.connectionManager.Name()))
+		if environment := tab.connectionManager.Environment(); environment != "" {
+			fmt.Fprintf(&text, "%s%s ", colorTag(environmentColor(environment)), tview.Escape(environment))
+		}
+	}
+	t.view.SetText(text.String())
+}
+
+func (t *TabBar) GetRender() *tview.TextView {
+	return t.view
+}
+
+// ConnectionManager returns the connection manager of the current tab
+func (a *ApplicationState) ConnectionManager() *snowflake.ConnectionManager {
+	return a.tab.connectionManager
+}
+
+// SetStartView sets the view opened by new tabs, as typed in the command bar
+func (a *ApplicationState) SetStartView(command string) error {
+	resource, scope, err := registry.ParseCommand(command)
+	if err != nil {
+		return err
+	}
+	a.startResource = resource
+	a.startScope = scope
+	return nil
+}
+
+// Start opens the start view in the first tab
+func (a *ApplicationState) Start(ctx context.Context) error {
+	return a.openStartView(ctx)
+}
+
+// Close closes the clients of every tab
+func (a *ApplicationState) Close() {
+	a.sessions.CloseAll()
+}
+
+func (a *ApplicationState) openStartView(ctx context.Context) error {
+	if a.startResource == nil {
+		return fmt.Errorf("no start view")
+	}
+	view, err := openResource(a.startResource, a.ConnectionManager(), a.startScope)
+	if err != nil {
+		return err
+	}
+	a.Push(ctx, view)
+	return nil
+}
+
+// addTab adds a tab for the connection manager without switching to it
+func (a *ApplicationState) addTab(cm *snowflake.ConnectionManager) *tab {
+	a.tabCount++
+	t := &tab{
+		id:                a.tabCount,
+		connectionManager: cm,
+		history:           make([]Component, 0),
+		main:              tview.NewPages(),
+		context:           NewSnowflakeContext(cm),
+	}
+	a.tabs = append(a.tabs, t)
+	a.Main.AddPage(t.page(), t.main, true, false)
+	a.header.AddPage(t.page(), t.context.GetRender(), true, false)
+	return t
+}
+
+// openTab connects a new tab to the named connection and opens the start
+// view in it
+func (a *ApplicationState) openTab(ctx context.Context, name string) error {
+	cm, err := a.sessions.Open(name)
+	if err != nil {
+		return err
+	}
+	a.switchTab(ctx, a.addTab(cm))
+	if err := a.openStartView(ctx); err != nil {
+		return fmt.Errorf("opening start view %w", err)
+	}
+	return nil
+}
+
+// switchTab shows the tab, the load of the previous tab is cancelled and
+// the top view of the tab fetched again
+func (a *ApplicationState) switchTab(ctx context.Context, t *tab) {
+	a.cancelLoad()
+	a.stopRefresh()
+
+	a.tab = t
+	a.Main.SwitchToPage(t.page())
+	a.header.SwitchToPage(t.page())
+	a.search.SetConnectionManager(t.connectionManager)
+	a.tabBar.Render(a.tabs, a.tab)
+
+	if len(t.history) > 0 {
+		a.UpdateView(ctx, false)
+	}
+}
+
+// closeTab closes the client of the current tab and switches to the next
+// one, the last tab is kept open
+func (a *ApplicationState) closeTab(ctx context.Context) error {
+	if len(a.tabs) == 1 {
+		return fmt.Errorf("cannot close the last tab")
+	}
+
+	a.cancelLoad()
+	a.stopRefresh()
+
+	closed := a.tab
+	index := slices.Index(a.tabs, closed)
+	a.tabs = slices.Delete(a.tabs, index, index+1)
+	a.Main.RemovePage(closed.page())
+	a.header.RemovePage(closed.page())
+	a.sessions.Close(closed.connectionManager)
+
+	a.switchTab(ctx, a.tabs[min(index, len(a.tabs)-1)])
+	return nil
+}
+
+// useConnection connects the current tab to the named connection, the views
+// of the previous connection are dropped and the start view opened
+func (a *ApplicationState) useConnection(ctx context.Context, name string) error {
+	a.cancelLoad()
+	a.stopRefresh()
+
+	if err := a.ConnectionManager().SetClient(name); err != nil {
+		return err
+	}
+
+	for i := range a.tab.history {
+		a.tab.main.RemovePage(fmt.Sprintf("page%d", i+1))
+	}
+	a.tab.history = a.tab.history[:0]
+	a.search.SetConnectionManager(a.ConnectionManager())
+	a.tabBar.Render(a.tabs, a.tab)
+
+	if err := a.openStartView(ctx); err != nil {
+		return fmt.Errorf("opening start view %w", err)
+	}
+	return nil
+}
+
+// tabBindings switch between and close the tabs of the main page
+func (a *ApplicationState) tabBindings(ctx context.Context) []*KeyBinding {
+	cycle := func(offset int) func(event *tcell.EventKey) *tcell.EventKey {
+		return func(event *tcell.EventKey) *tcell.EventKey {
+			if name, _ := a.Pages.GetFrontPage(); name != "main" {
+				return event
+			}
+			index := slices.Index(a.tabs, a.tab) + offset + len(a.tabs)
+			a.switchTab(ctx, a.tabs[index%len(a.tabs)])
+			return nil
+		}
+	}
+
+	return []*KeyBinding{
+		{
+			Description: "next tab",
+			Event:       tcell.NewEventKey(tcell.KeyRune, ']', tcell.ModNone),
+			Hidden:      true,
+			Callback:    cycle(1),
+		},
+		{
+			Description: "previous tab",
+			Event:       tcell.NewEventKey(tcell.KeyRune, '[', tcell.ModNone),
+			Hidden:      true,
+			Callback:    cycle(-1),
+		},
+		{
+			Description: "close tab",
+			Event:       tcell.NewEventKey(tcell.KeyCtrlW, 0, tcell.ModCtrl),
+			Hidden:      true,
+			Callback: func(event *tcell.EventKey) *tcell.EventKey {
+				if name, _ := a.Pages.GetFrontPage(); name != "main" {
+					return event
+				}
+				if err := a.closeTab(ctx); err != nil {
+					a.status.SetError(err)
+				}
+				return nil
+			},
+		},
+	}
+}
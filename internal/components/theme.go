@@ -0,0 +1,288 @@
+package components
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+// Theme holds the colors of the interface by their meaning
+type Theme struct {
+	Background tcell.Color
+	Border     tcell.Color
+	// Text is the color of values and of the input fields and Muted of the
+	// secondary text such as counts, descriptions and messages
+	Text  tcell.Color
+	Muted tcell.Color
+	// Input is the background of the input fields and dialogs
+	Input tcell.Color
+
+	// Header, Row, Changed and Selected are the colors of the tables, the
+	// rows that changed since the last refresh and the selected row
+	Header   tcell.Color
+	Row      tcell.Color
+	Changed  tcell.Color
+	Selected tcell.Color
+
+	// Title and Scope are the colors of the view titles, services(DB.SCHEMA)
+	Title tcell.Color
+	Scope tcell.Color
+	// Label is the color of the session labels and Key the one of the keys
+	// in the key bindings bar
+	Label tcell.Color
+	Key   tcell.Color
+
+	Error   tcell.Color
+	Warning tcell.Color
+	// StateOK and StateBad are the colors of healthy and failing objects
+	StateOK  tcell.Color
+	StateBad tcell.Color
+}
+
+// themes are the built-in skins
+var themes = map[string]*Theme{
+	"default": {
+		Background: tcell.ColorBlack,
+		Border:     tcell.ColorWhite,
+		Text:       tcell.ColorWhite,
+		Muted:      tcell.ColorGrey,
+		Input:      tcell.ColorBlue,
+		Header:     tcell.ColorGrey,
+		Row:        tcell.ColorAqua,
+		Changed:    tcell.ColorYellow,
+		Selected:   tcell.ColorAqua,
+		Title:      tcell.ColorBlue,
+		Scope:      tcell.ColorPink,
+		Label:      tcell.ColorOrange,
+		Key:        tcell.ColorBlue,
+		Error:      tcell.ColorRed,
+		Warning:    tcell.ColorYellow,
+		StateOK:    tcell.ColorGreen,
+		StateBad:   tcell.ColorRed,
+	},
+	"light": {
+		Background: tcell.ColorWhite,
+		Border:     tcell.ColorDarkGrey,
+		Text:       tcell.ColorBlack,
+		Muted:      tcell.ColorDimGrey,
+		Input:      tcell.ColorLightGrey,
+		Header:     tcell.ColorBlack,
+		Row:        tcell.ColorNavy,
+		Changed:    tcell.ColorDarkOrange,
+		Selected:   tcell.ColorRoyalBlue,
+		Title:      tcell.ColorDarkBlue,
+		Scope:      tcell.ColorPurple,
+		Label:      tcell.ColorSaddleBrown,
+		Key:        tcell.ColorDarkBlue,
+		Error:      tcell.ColorRed,
+		Warning:    tcell.ColorDarkOrange,
+		StateOK:    tcell.ColorGreen,
+		StateBad:   tcell.ColorRed,
+	},
+	// terminal keeps the background and text of the terminal and only uses
+	// the 16 colors of its palette, for dark and light terminals alike
+	"terminal": {
+		Background: tcell.ColorDefault,
+		Border:     tcell.ColorDefault,
+		Text:       tcell.ColorDefault,
+		Muted:      tcell.ColorGray,
+		Input:      tcell.ColorGray,
+		Header:     tcell.ColorDefault,
+		Row:        tcell.ColorTeal,
+		Changed:    tcell.ColorOlive,
+		Selected:   tcell.ColorTeal,
+		Title:      tcell.ColorNavy,
+		Scope:      tcell.ColorPurple,
+		Label:      tcell.ColorMaroon,
+		Key:        tcell.ColorNavy,
+		Error:      tcell.ColorRed,
+		Warning:    tcell.ColorOlive,
+		StateOK:    tcell.ColorGreen,
+		StateBad:   tcell.ColorRed,
+	},
+}
+
+// currentTheme is the theme the components are created with, see SetTheme
+var currentTheme = themes["default"]
+
+// ThemeNames returns the names of the built-in skins
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SetTheme sets the colors of the components, it must be called before the
+// application is created as tview reads its styles when creating primitives
+func SetTheme(theme *Theme) {
+	currentTheme = theme
+	tview.Styles = tview.Theme{
+		PrimitiveBackgroundColor:    theme.Background,
+		ContrastBackgroundColor:     theme.Input,
+		MoreContrastBackgroundColor: theme.Selected,
+		BorderColor:                 theme.Border,
+		TitleColor:                  theme.Title,
+		GraphicsColor:               theme.Border,
+		PrimaryTextColor:            theme.Text,
+		SecondaryTextColor:          theme.Label,
+		TertiaryTextColor:           theme.Muted,
+		InverseTextColor:            theme.Background,
+		ContrastSecondaryTextColor:  theme.Muted,
+	}
+}
+
+// skinFile is a skin as written in a yaml or toml file, the colors are
+// names such as aqua or hex values such as #00afff and the ones left out
+// are taken from the base skin, default unless given
+type skinFile struct {
+	Base       string `yaml:"base" toml:"base"`
+	Background string `yaml:"background" toml:"background"`
+	Border     string `yaml:"border" toml:"border"`
+	Text       string `yaml:"text" toml:"text"`
+	Muted      string `yaml:"muted" toml:"muted"`
+	Input      string `yaml:"input" toml:"input"`
+	Header     string `yaml:"header" toml:"header"`
+	Row        string `yaml:"row" toml:"row"`
+	Changed    string `yaml:"changed" toml:"changed"`
+	Selected   string `yaml:"selected" toml:"selected"`
+	Title      string `yaml:"title" toml:"title"`
+	Scope      string `yaml:"scope" toml:"scope"`
+	Label      string `yaml:"label" toml:"label"`
+	Key        string `yaml:"key" toml:"key"`
+	Error      string `yaml:"error" toml:"error"`
+	Warning    string `yaml:"warning" toml:"warning"`
+	StateOK    string `yaml:"state-ok" toml:"state-ok"`
+	StateBad   string `yaml:"state-bad" toml:"state-bad"`
+}
+
+// LoadTheme returns the built-in skin of the name or else reads the skin
+// file NAME.yaml, NAME.yml or NAME.toml in the directory. A name with an
+// extension is the path of a skin file.
+func LoadTheme(name string, directory string) (*Theme, error) {
+	name = cmp.Or(name, "default")
+	if theme, ok := themes[name]; ok {
+		return theme, nil
+	}
+	if filepath.Ext(name) != "" {
+		return ReadTheme(name)
+	}
+
+	for _, extension := range []string{".yaml", ".yml", ".toml"} {
+		theme, err := ReadTheme(filepath.Join(directory, name+extension))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return theme, err
+	}
+	return nil, fmt.Errorf("unknown skin %s, expected one of %s or a skin file in %s", name, strings.Join(ThemeNames(), ", "), directory)
+}
+
+// ReadTheme reads a yaml or toml skin file, see skinFile
+func ReadTheme(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading skin %w", err)
+	}
+
+	var skin skinFile
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&skin); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("decoding %s from yaml %w", path, err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(data), &skin)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s from toml %w", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown key %s in %s", undecoded[0], path)
+		}
+	default:
+		return nil, fmt.Errorf("cannot read skin %s, expected a .yaml, .yml or .toml file", path)
+	}
+
+	base, ok := themes[cmp.Or(skin.Base, "default")]
+	if !ok {
+		return nil, fmt.Errorf("unknown base skin %s in %s, expected one of %s", skin.Base, path, strings.Join(ThemeNames(), ", "))
+	}
+	theme := *base
+
+	for _, color := range []struct {
+		key   string
+		value string
+		color *tcell.Color
+	}{
+		{"background", skin.Background, &theme.Background},
+		{"border", skin.Border, &theme.Border},
+		{"text", skin.Text, &theme.Text},
+		{"muted", skin.Muted, &theme.Muted},
+		{"input", skin.Input, &theme.Input},
+		{"header", skin.Header, &theme.Header},
+		{"row", skin.Row, &theme.Row},
+		{"changed", skin.Changed, &theme.Changed},
+		{"selected", skin.Selected, &theme.Selected},
+		{"title", skin.Title, &theme.Title},
+		{"scope", skin.Scope, &theme.Scope},
+		{"label", skin.Label, &theme.Label},
+		{"key", skin.Key, &theme.Key},
+		{"error", skin.Error, &theme.Error},
+		{"warning", skin.Warning, &theme.Warning},
+		{"state-ok", skin.StateOK, &theme.StateOK},
+		{"state-bad", skin.StateBad, &theme.StateBad},
+	} {
+		if color.value == "" {
+			continue
+		}
+		parsed, err := parseColor(color.value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s of %s %w", color.key, path, err)
+		}
+		*color.color = parsed
+	}
+	return &theme, nil
+}
+
+// parseColor parses a color name, a hex value or default for the color of
+// the terminal
+func parseColor(name string) (tcell.Color, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "default" {
+		return tcell.ColorDefault, nil
+	}
+	color := tcell.GetColor(name)
+	if color == tcell.ColorDefault {
+		return color, fmt.Errorf("unknown color %s, expected a color name or a hex value such as #00afff", name)
+	}
+	return color, nil
+}
+
+// colorTag is the tview markup switching the text to the color
+func colorTag(color tcell.Color) string {
+	if color == tcell.ColorDefault {
+		return "[-]"
+	}
+	return "[" + color.String() + "]"
+}
+
+// scopedTitle is the title of a view showing the objects in a scope
+func scopedTitle(name string, scope string) string {
+	return fmt.Sprintf("%s(%s%s%s)", name, colorTag(currentTheme.Scope), tview.Escape(scope), colorTag(currentTheme.Title))
+}
@@ -0,0 +1,101 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// writeSkin writes a skin file to the directory
+func writeSkin(t *testing.T, directory string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(directory, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadTheme(t *testing.T) {
+	directory := t.TempDir()
+	writeSkin(t, directory, "ocean.yaml", "row: '#00afff'\nstate-ok: teal\nerror: default\n")
+	writeSkin(t, directory, "paper.toml", "base = \"light\"\ntitle = \"purple\"\n")
+	other := writeSkin(t, t.TempDir(), "other.yml", "")
+
+	tests := []struct {
+		name string
+		base string
+		want map[string]tcell.Color
+	}{
+		{
+			// the keys left out are taken from the default skin
+			name: "ocean",
+			base: "default",
+			want: map[string]tcell.Color{"row": tcell.GetColor("#00afff"), "state-ok": tcell.ColorTeal, "error": tcell.ColorDefault},
+		},
+		{
+			name: "paper",
+			base: "light",
+			want: map[string]tcell.Color{"title": tcell.ColorPurple},
+		},
+		{
+			name: other,
+			base: "default",
+		},
+		{
+			name: "light",
+			base: "light",
+		},
+		{
+			name: "",
+			base: "default",
+		},
+	}
+	for _, test := range tests {
+		t.Run(filepath.Base(test.name), func(t *testing.T) {
+			theme, err := LoadTheme(test.name, directory)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := *themes[test.base]
+			colors := map[string]*tcell.Color{"row": &want.Row, "state-ok": &want.StateOK, "error": &want.Error, "title": &want.Title}
+			for key, color := range test.want {
+				*colors[key] = color
+			}
+			if *theme != want {
+				t.Errorf("got theme %+v, want %+v", *theme, want)
+			}
+		})
+	}
+
+	// loading a skin leaves the built-in ones as they are
+	if themes["default"].Row != tcell.ColorAqua {
+		t.Errorf("the default skin row color changed to %s", themes["default"].Row)
+	}
+}
+
+func TestLoadThemeInvalid(t *testing.T) {
+	directory := t.TempDir()
+	writeSkin(t, directory, "typo.yaml", "rows: red\n")
+	writeSkin(t, directory, "typo.toml", "rows = \"red\"\n")
+	writeSkin(t, directory, "color.yaml", "row: reddish\n")
+	writeSkin(t, directory, "base.yaml", "base: solarized\n")
+	writeSkin(t, directory, "skin.json", "{}")
+
+	for _, name := range []string{
+		"missing",
+		"typo",
+		filepath.Join(directory, "typo.toml"),
+		"color",
+		"base",
+		filepath.Join(directory, "skin.json"),
+	} {
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			if _, err := LoadTheme(name, directory); err == nil {
+				t.Errorf("loaded the invalid skin %s", name)
+			}
+		})
+	}
+}
@@ -5,27 +5,47 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+
+	"github.com/costrouc/snowctl/internal/registry"
 )
 
-func updateTable(tableView *tview.Table, table *Table) {
-	tableView.SetTitle(fmt.Sprintf("[blue]%s[[grey]%d[blue]]", table.Title, len(table.Rows)))
+func tableTitle(table *registry.Table) string {
+	title := colorTag(currentTheme.Title)
+	return fmt.Sprintf("%s%s[%s%d%s]", title, table.Title, colorTag(currentTheme.Muted), len(table.Rows), title)
+}
+
+// updateTable renders the table, rows matching a state rule take its color
+// and glyph and the other rows flagged in changed are highlighted
+func updateTable(tableView *tview.Table, table *registry.Table, changed []bool, states []*rowState) {
+	tableView.SetTitle(tableTitle(table))
 
 	tableView.Clear()
 	cols, rows := len(table.Columns), len(table.Rows)
 	for c := 0; c < cols; c++ {
-		color := tcell.ColorWhite
 		tableView.SetCell(0, c,
-			tview.NewTableCell(table.Columns[c]).
-				SetTextColor(color).
-				SetStyle(tcell.StyleDefault.Foreground(tcell.ColorGrey).Bold(true)).
+			tview.NewTableCell(tview.Escape(table.Columns[c])).
+				SetStyle(tcell.StyleDefault.Foreground(currentTheme.Header).Bold(true)).
 				SetAlign(tview.AlignLeft).SetExpansion(1).SetSelectable(false))
 	}
 
 	for r := 0; r < rows; r++ {
+		var state *rowState
+		if r < len(states) {
+			state = states[r]
+		}
 		for c := 0; c < cols; c++ {
-			color := tcell.ColorAqua
+			color := currentTheme.Row
+			text := tview.Escape(table.Rows[r].Cells[c])
+			if state != nil {
+				color = state.color
+				if columnIndex(table.Columns, state.column) == c {
+					text = state.glyph + " " + text
+				}
+			} else if r < len(changed) && changed[r] {
+				color = currentTheme.Changed
+			}
 			tableView.SetCell(r+1, c,
-				tview.NewTableCell(table.Rows[r][c]).
+				tview.NewTableCell(text).
 					SetTextColor(color).
 					SetAlign(tview.AlignLeft))
 		}
@@ -6,13 +6,14 @@ import (
 	"strconv"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
-	"github.com/rivo/tview"
 )
 
 type VersionsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *VersionsOptions
 }
 
@@ -23,46 +24,51 @@ type VersionsOptions struct {
 func NewVersionsView(connectionManager *snowflake.ConnectionManager, opts *VersionsOptions) *VersionsView {
 	versions := &VersionsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	versions.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return versions
 }
 
-func (v *VersionsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx, v.options)
-	if err != nil {
-		return fmt.Errorf("updating application packages versions data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "versions",
+		Aliases:     []string{"version"},
+		Description: "versions of an application package",
+		Scopes:      []registry.ScopeArgument{registry.ScopeApplicationPackage},
+		Required:    []registry.ScopeArgument{registry.ScopeApplicationPackage},
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewVersionsView(cm, &VersionsOptions{ApplicationPackage: *scope.ApplicationPackage})
+		},
+	})
 }
 
-func (t *VersionsView) getData(ctx context.Context, opts *VersionsOptions) (*Table, error) {
+func (t *VersionsView) GetData(ctx context.Context) (*registry.Table, error) {
+	opts := t.options
+
 	versions, err := t.connectionManager.GetClient().ApplicationPackageVersions.Show(ctx, opts.ApplicationPackage)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show versions %w", err)
 	}
 
 	columns := []string{"Version", "Patch", "Label", "CreatedOn"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, version := range versions {
-		rows = append(rows, []string{
-			version.Version,
-			strconv.Itoa(version.Patch),
-			version.Label,
-			version.CreatedOn.Time.String(),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				version.Version,
+				strconv.Itoa(version.Patch),
+				version.Label,
+				version.CreatedOn.Time.String(),
+			},
+			Record: version,
 		})
 	}
 
-	return &Table{
-		Title:   fmt.Sprintf("versions([pink]%s[blue])", opts.ApplicationPackage.Name()),
+	return &registry.Table{
+		Title:   scopedTitle("versions", opts.ApplicationPackage.Name()),
 		Columns: columns,
 		Rows:    rows,
 	}, nil
@@ -73,7 +79,3 @@ func (v *VersionsView) GetBindings(ctx context.Context, applicationState *Applic
 
 	return bindings
 }
-
-func (v *VersionsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type ViewsView struct {
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
-	table             *tview.Table
 	options           *ViewsOptions
 }
 
@@ -21,46 +22,48 @@ type ViewsOptions struct{}
 func NewViewsView(connectionManager *snowflake.ConnectionManager, opts *ViewsOptions) *ViewsView {
 	views := &ViewsView{
 		connectionManager: connectionManager,
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		options:           opts,
 	}
 
-	views.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return views
 }
 
-func (v *ViewsView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating views data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "views",
+		Aliases:     []string{"view"},
+		Description: "views in every database",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewViewsView(cm, &ViewsOptions{})
+		},
+	})
 }
 
-func (t *ViewsView) getData(ctx context.Context) (*Table, error) {
-	views, err := t.connectionManager.GetClient().SDKClient.Views.Show(ctx, sdk.NewShowViewRequest())
+func (t *ViewsView) GetData(ctx context.Context) (*registry.Table, error) {
+	views, err := t.connectionManager.GetClient().Views.Show(ctx, &snowflake.ShowViewOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show views %w", err)
 	}
 
 	columns := []string{"Name", "Schema Name", "Database Name", "Owner", "Kind"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, view := range views {
-		rows = append(rows, []string{
-			view.Name,
-			view.SchemaName,
-			view.DatabaseName,
-			view.Owner,
-			view.Kind,
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				view.Name,
+				view.SchemaName,
+				view.DatabaseName,
+				view.Owner,
+				view.Kind,
+			},
+			Record:     view,
+			Identifier: sdk.NewSchemaObjectIdentifier(view.DatabaseName, view.SchemaName, view.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "views",
 		Columns: columns,
 		Rows:    rows,
@@ -74,18 +77,15 @@ func (v *ViewsView) GetBindings(ctx context.Context, applicationState *Applicati
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := v.table.GetSelection()
-				databaseName := v.table.GetCell(r, 0).Text
-				schemaName := v.table.GetCell(r, 1).Text
-				viewName := v.table.GetCell(r, 2).Text
-				view := sdk.NewSchemaObjectIdentifier(
-					databaseName, schemaName, viewName,
-				)
+				view, ok := selectedIdentifier[sdk.SchemaObjectIdentifier](v.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeView,
 							ObjectIdentifier: view,
@@ -97,7 +97,3 @@ func (v *ViewsView) GetBindings(ctx context.Context, applicationState *Applicati
 		},
 	}
 }
-
-func (v *ViewsView) GetRender() tview.Primitive {
-	return v.table
-}
@@ -0,0 +1,130 @@
+package components
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
+	"github.com/costrouc/snowctl/internal/snowflake"
+	"github.com/costrouc/snowctl/internal/snowflake/fake"
+)
+
+// testFixtures is a small account with a service in a schema whose name
+// needs quoting
+var testFixtures = fake.Fixtures{
+	Roles:      []sdk.Role{{Name: "ACCOUNTADMIN"}, {Name: "SYSADMIN"}},
+	Users:      []sdk.User{{Name: "ALICE"}},
+	Warehouses: []sdk.Warehouse{{Name: "COMPUTE_WH"}, {Name: "Reporting WH"}},
+	Databases:  []sdk.Database{{Name: "DB"}, {Name: "OTHER"}},
+	Schemas: []sdk.Schema{
+		{Name: "my.schema", DatabaseName: "DB"},
+		{Name: "PUBLIC", DatabaseName: "OTHER"},
+	},
+	ComputePools: []snowflake.ComputePool{
+		{Name: "POOL", State: "SUSPENDED", InstanceFamily: "CPU_X64_XS"},
+		{Name: "GPU_POOL", State: "IDLE", InstanceFamily: "GPU_NV_S"},
+	},
+	Services: []snowflake.Service{
+		{Name: "SVC", DatabaseName: "DB", SchemaName: "my.schema", ComputePool: "POOL", Status: "RUNNING"},
+		{Name: "ELSEWHERE", DatabaseName: "OTHER", SchemaName: "PUBLIC", ComputePool: "GPU_POOL", Status: "RUNNING"},
+	},
+	ServiceInstances: []snowflake.ServiceInstance{
+		{DatabaseName: "DB", SchemaName: "my.schema", ServiceName: "SVC", InstanceId: 0, Status: "READY"},
+	},
+	ServiceContainers: []snowflake.ServiceContainer{
+		{DatabaseName: "DB", SchemaName: "my.schema", ServiceName: "SVC", InstanceId: 0, ContainerName: "main", Status: "READY"},
+	},
+	ServiceLogs: map[string]string{
+		fake.ServiceLogsKey(sdk.NewSchemaObjectIdentifier("DB", "my.schema", "SVC"), 0, "main"): "starting\nlistening",
+	},
+}
+
+// columnValues returns the cells of a column of every row
+func columnValues(t *testing.T, table *registry.Table, column string) []string {
+	t.Helper()
+	c := slices.Index(table.Columns, column)
+	if c < 0 {
+		t.Fatalf("table %s has no %s column in %q", table.Title, column, table.Columns)
+	}
+	values := make([]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		values = append(values, row.Cells[c])
+	}
+	return values
+}
+
+func TestGetData(t *testing.T) {
+	tests := []struct {
+		command string
+		column  string
+		want    []string
+	}{
+		{"roles", "Name", []string{"ACCOUNTADMIN", "SYSADMIN"}},
+		{"users", "Name", []string{"ALICE"}},
+		{"warehouses", "Name", []string{"COMPUTE_WH", "Reporting WH"}},
+		{"databases", "Name", []string{"DB", "OTHER"}},
+		{"schemas DB", "Schema", []string{"my.schema"}},
+		{"compute pools", "Name", []string{"POOL", "GPU_POOL"}},
+		{"services", "Name", []string{"SVC", "ELSEWHERE"}},
+		{`services DB."my.schema"`, "Name", []string{"SVC"}},
+		{"services compute-pool=GPU_POOL", "Name", []string{"ELSEWHERE"}},
+		{`service instances DB."my.schema".SVC`, "Status", []string{"READY"}},
+		{`service containers DB."my.schema".SVC`, "Container", []string{"main"}},
+	}
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			backend := fake.NewBackend(&testFixtures)
+			cm := snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client())
+
+			resource, scope, err := registry.ParseCommand(test.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			view, err := resource.Open(cm, scope)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table, err := view.GetData(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := columnValues(t, table, test.column); !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetDataMissingScope(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	cm := snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client())
+
+	resource, scope, err := registry.ParseCommand("service instances DB.PUBLIC.MISSING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, err := resource.Open(cm, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := view.GetData(context.Background()); err == nil {
+		t.Error("showing the instances of a missing service succeeded")
+	}
+}
+
+func TestServiceLogsGetData(t *testing.T) {
+	backend := fake.NewBackend(&testFixtures)
+	cm := snowflake.NewStaticConnectionManager(fake.ConnectionName, backend.Client())
+
+	service := sdk.NewSchemaObjectIdentifier("DB", "my.schema", "SVC")
+	view := NewServiceLogsView(cm, &ServiceLogsOptions{Service: &service, InstanceId: 0, ContainerName: "main"})
+	table, err := view.GetData(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := columnValues(t, table, "Logs"), []string{"starting", "listening"}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/registry"
 	"github.com/costrouc/snowctl/internal/snowflake"
 	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
 )
 
 type WarehousesView struct {
-	table             *tview.Table
+	*TableView
+
 	connectionManager *snowflake.ConnectionManager
 	options           *WarehousesOptions
 }
@@ -20,48 +21,55 @@ type WarehousesOptions struct{}
 
 func NewWarehousesView(connectionManager *snowflake.ConnectionManager, opts *WarehousesOptions) *WarehousesView {
 	warehouses := &WarehousesView{
-		table:             tview.NewTable(),
+		TableView:         NewTableView(),
 		connectionManager: connectionManager,
 		options:           opts,
 	}
 
-	warehouses.table.SetFixed(1, 0).SetSelectable(true, false).SetBorder(true)
-
 	return warehouses
 }
 
-func (v *WarehousesView) Update(ctx context.Context) error {
-	table, err := v.getData(ctx)
-	if err != nil {
-		return fmt.Errorf("updating warehouses data %w", err)
-	}
-
-	updateTable(v.table, table)
-
-	return nil
+func init() {
+	registry.RegisterResource(&registry.Resource{
+		Name:        "warehouses",
+		Aliases:     []string{"warehouse", "wh"},
+		Description: "virtual warehouses",
+		New: func(cm *snowflake.ConnectionManager, scope *registry.Scope) registry.Source {
+			return NewWarehousesView(cm, &WarehousesOptions{})
+		},
+		States: []StateRule{
+			{Column: "State", Values: []string{"STARTED"}, Style: "ok"},
+			{Column: "State", Values: []string{"RESUMING", "SUSPENDING", "RESIZING"}, Style: "warning"},
+			{Column: "State", Values: []string{"SUSPENDED", "FAILED"}, Style: "bad"},
+		},
+	})
 }
 
-func (t *WarehousesView) getData(ctx context.Context) (*Table, error) {
-	warehouses, err := t.connectionManager.GetClient().SDKClient.Warehouses.Show(ctx, &sdk.ShowWarehouseOptions{})
+func (t *WarehousesView) GetData(ctx context.Context) (*registry.Table, error) {
+	warehouses, err := t.connectionManager.GetClient().Warehouses.Show(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("calling snowflake show warehouses %w", err)
 	}
 
 	columns := []string{"Name", "Owner", "Scaling Policy", "Size", "State", "Type"}
-	rows := make([][]string, 0)
+	rows := make([]registry.Row, 0)
 
 	for _, warehouse := range warehouses {
-		rows = append(rows, []string{
-			warehouse.Name,
-			warehouse.Owner,
-			string(warehouse.ScalingPolicy),
-			string(warehouse.Size),
-			string(warehouse.State),
-			string(warehouse.Type),
+		rows = append(rows, registry.Row{
+			Cells: []string{
+				warehouse.Name,
+				warehouse.Owner,
+				string(warehouse.ScalingPolicy),
+				string(warehouse.Size),
+				string(warehouse.State),
+				string(warehouse.Type),
+			},
+			Record:     warehouse,
+			Identifier: sdk.NewAccountObjectIdentifier(warehouse.Name),
 		})
 	}
 
-	return &Table{
+	return &registry.Table{
 		Title:   "warehouses",
 		Columns: columns,
 		Rows:    rows,
@@ -74,15 +82,18 @@ func (t *WarehousesView) GetBindings(ctx context.Context, applicationState *Appl
 			Description: "Use Warehouse",
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				cell := t.table.GetCell(r, 0)
-				_, err := t.connectionManager.GetClient().SDKClient.GetConn().ExecContext(ctx, fmt.Sprintf("USE WAREHOUSE %s", cell.Text))
+				warehouse, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
+				err := t.connectionManager.GetClient().Sessions.UseWarehouse(ctx, warehouse)
 				if err != nil {
 					applicationState.status.SetError(err)
 					return nil
 				}
-				applicationState.context.Update(ctx)
+				applicationState.updateContext(ctx)
 				return nil
 			},
 		},
@@ -91,16 +102,15 @@ func (t *WarehousesView) GetBindings(ctx context.Context, applicationState *Appl
 			Event:       tcell.NewEventKey(tcell.KeyRune, 'g', tcell.ModNone),
 			Hidden:      true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				warehouseName := t.table.GetCell(r, 0).Text
-				warehouse := sdk.NewAccountObjectIdentifier(
-					warehouseName,
-				)
+				warehouse, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
 				applicationState.Push(
 					ctx,
 					NewGrantsView(
-						applicationState.ConnectionManager,
+						applicationState.ConnectionManager(),
 						&GrantsOptions{
 							ObjectType:       sdk.ObjectTypeWarehouse,
 							ObjectIdentifier: warehouse,
@@ -114,18 +124,19 @@ func (t *WarehousesView) GetBindings(ctx context.Context, applicationState *Appl
 			Description: "Drop",
 			Event:       tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl),
 			Hidden:      false,
+			Mutating:    true,
 			Callback: func(event *tcell.EventKey) *tcell.EventKey {
-				r, _ := t.table.GetSelection()
-				warehouseName := t.table.GetCell(r, 0).Text
-				warehouse := sdk.NewAccountObjectIdentifier(warehouseName)
-
-				message := fmt.Sprintf("Drop warehouse %s?", warehouse.FullyQualifiedName())
+				warehouse, ok := selectedIdentifier[sdk.AccountObjectIdentifier](t.TableView)
+				if !ok {
+					return nil
+				}
 
-				applicationState.modal.Prompt(ctx, applicationState, message, func(action bool) {
+				applicationState.confirmDrop(ctx, "warehouse", warehouse, func(action bool) {
 					if action {
-						err := t.connectionManager.GetClient().SDKClient.Warehouses.Drop(ctx, warehouse, &sdk.DropWarehouseOptions{})
+						err := t.connectionManager.GetClient().Warehouses.Drop(ctx, warehouse, &snowflake.DropWarehouseOptions{})
 						if err != nil {
 							applicationState.status.SetError(err)
+							return
 						}
 						applicationState.status.SetMessage(fmt.Sprintf("Dropped warehouse %s", warehouse.FullyQualifiedName()))
 					} else {
@@ -138,7 +149,3 @@ func (t *WarehousesView) GetBindings(ctx context.Context, applicationState *Appl
 		},
 	}
 }
-
-func (v *WarehousesView) GetRender() tview.Primitive {
-	return v.table
-}
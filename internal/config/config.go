@@ -0,0 +1,143 @@
+// Package config reads the configuration of snowctl itself from
+// ~/.config/snowctl/config.toml. The snowflake connections are not part of
+// it, they are read from the snowflake and snowsql configuration files.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+type Config struct {
+	// Connection is the connection to start with as listed in the
+	// connections view, snowflake.prod, or its name alone, prod
+	Connection string `toml:"connection"`
+	// Role and Warehouse override the ones of every connection
+	Role      string `toml:"role"`
+	Warehouse string `toml:"warehouse"`
+
+	// View is the startup view as typed in the command bar, with its
+	// scope, such as `services DB.SCHEMA`
+	View string `toml:"view"`
+
+	// Refresh is the default auto refresh interval, zero disables it, and
+	// RefreshIntervals the intervals of the views by resource name
+	Refresh          *time.Duration           `toml:"refresh"`
+	RefreshIntervals map[string]time.Duration `toml:"refresh_intervals"`
+
+	// Aliases are command bar shortcuts expanding to a command such as
+	// `prodsvc = "services PROD.APP"`
+	Aliases map[string]string `toml:"aliases"`
+
+	// Columns are the columns shown by resource name, in order, and may
+	// name fields of the objects that are not shown by default
+	Columns map[string][]string `toml:"columns"`
+
+	// States are rules styling the rows by state by resource name, they
+	// apply before the default rules of snowctl
+	States map[string][]StateRule `toml:"states"`
+
+	// KeyPreset is default, emacs or vim and Keys remap the bindings by
+	// action ID, `drop = "ctrl-x"` or `"compute pools.services" = "S"`
+	KeyPreset string            `toml:"key_preset"`
+	Keys      map[string]string `toml:"keys"`
+
+	// Environments tag the connections as prod, staging or dev by
+	// connection name, `"snowflake.prod" = "prod"`, ProtectedEnvironments
+	// require typing the name of an object to drop it, prod and staging
+	// when unset, and DenyDrop are patterns of the objects never dropped
+	Environments          map[string]string `toml:"environments"`
+	ProtectedEnvironments []string          `toml:"protected_environments"`
+	DenyDrop              []string          `toml:"deny_drop"`
+
+	// ReadOnly disables the bindings changing objects of the account on
+	// every connection and ReadOnlyConnections on the ones named
+	ReadOnly            bool     `toml:"read_only"`
+	ReadOnlyConnections []string `toml:"read_only_connections"`
+
+	// Skin is a built-in skin, default, light or terminal, or the name of a
+	// skin file in the skins directory, see SkinsDir
+	Skin string `toml:"skin"`
+}
+
+// StateRule styles the rows whose column, or field of the object such as
+// restart_count, has one of the values or, when Above is set, a number
+// above it. Style is ok, bad, warning or a color and Glyph replaces the one
+// of the style, shown before the value of the column.
+type StateRule struct {
+	Column string   `toml:"column"`
+	Values []string `toml:"values"`
+	Above  *float64 `toml:"above"`
+	Style  string   `toml:"style"`
+	Glyph  string   `toml:"glyph"`
+}
+
+// Dir returns the directory of the configuration of snowctl, under
+// XDG_CONFIG_HOME when set
+func Dir() (string, error) {
+	directory := os.Getenv("XDG_CONFIG_HOME")
+	if directory == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("finding home directory %w", err)
+		}
+		directory = filepath.Join(home, ".config")
+	}
+	return filepath.Join(directory, "snowctl"), nil
+}
+
+// Path returns the location of the configuration file
+func Path() (string, error) {
+	directory, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(directory, "config.toml"), nil
+}
+
+// SkinsDir returns the directory of the skin files, NAME.yaml or NAME.toml
+func SkinsDir() (string, error) {
+	directory, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(directory, "skins"), nil
+}
+
+// Read reads the configuration file, a missing file is an empty
+// configuration
+func Read() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return ReadFile(path)
+}
+
+func ReadFile(path string) (*Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s file %w", path, err)
+	}
+
+	metadata, err := toml.Decode(string(data), &config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s from toml %w", path, err)
+	}
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown key %s in %s", undecoded[0], path)
+	}
+
+	return &config, nil
+}
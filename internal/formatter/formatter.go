@@ -0,0 +1,204 @@
+// Package formatter serializes the data behind a view. The typed snowflake
+// records are flattened using their `db` tags, falling back to the snake
+// cased field name, and written as a text table, a wide text table with
+// every field, JSON, YAML or CSV.
+package formatter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	// Table writes the columns shown in the view
+	Table Format = "table"
+	// Wide writes every field of the records
+	Wide Format = "wide"
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+)
+
+var Formats = []Format{Table, Wide, JSON, YAML, CSV}
+
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %s, expected one of %s", name, formatNames())
+}
+
+// FormatForPath picks the format from the file extension, text files use
+// the wide format
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".csv":
+		return CSV, nil
+	case ".txt", "":
+		return Wide, nil
+	}
+	return "", fmt.Errorf("cannot infer output format from %s, expected a .json, .yaml, .csv or .txt file", path)
+}
+
+func formatNames() string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Data is the content of a view, Records are optional and when missing
+// the columns and rows are used for every format
+type Data struct {
+	Columns []string
+	Rows    [][]string
+	Records []any
+}
+
+func (d *Data) records() []record {
+	if d.Records == nil {
+		result := make([]record, len(d.Rows))
+		for i, row := range d.Rows {
+			for c, column := range d.Columns {
+				result[i] = append(result[i], field{Name: snakeCase(column), Value: row[c]})
+			}
+		}
+		return result
+	}
+
+	result := make([]record, len(d.Records))
+	for i, r := range d.Records {
+		result[i] = newRecord(r)
+	}
+	return result
+}
+
+// Write serializes the data in the given format
+func Write(w io.Writer, format Format, data *Data) error {
+	switch format {
+	case Table:
+		return writeTable(w, data.Columns, data.Rows)
+	case Wide:
+		columns, rows := recordsToRows(data.records(), data.Columns)
+		return writeTable(w, columns, rows)
+	case CSV:
+		columns, rows := recordsToRows(data.records(), data.Columns)
+		return writeCSV(w, columns, rows)
+	case JSON:
+		records := data.records()
+		if records == nil {
+			records = []record{}
+		}
+		return writeJSON(w, records)
+	case YAML:
+		return writeYAML(w, data.records())
+	}
+	return fmt.Errorf("unknown output format %s", format)
+}
+
+// WriteRecord serializes a single record such as the result of a describe,
+// the text formats write one field per line
+func WriteRecord(w io.Writer, format Format, value any) error {
+	r := newRecord(value)
+
+	switch format {
+	case Table, Wide:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		for _, f := range r {
+			fmt.Fprintf(tw, "%s:\t%s\n", f.Name, formatText(f.Value))
+		}
+		return tw.Flush()
+	case CSV:
+		columns, rows := recordsToRows([]record{r}, nil)
+		return writeCSV(w, columns, rows)
+	case JSON:
+		return writeJSON(w, r)
+	case YAML:
+		return writeYAML(w, r)
+	}
+	return fmt.Errorf("unknown output format %s", format)
+}
+
+// recordsToRows flattens records into text cells using the fields of the
+// first record as columns
+func recordsToRows(records []record, fallback []string) ([]string, [][]string) {
+	if len(records) == 0 {
+		columns := make([]string, len(fallback))
+		for i, column := range fallback {
+			columns[i] = snakeCase(column)
+		}
+		return columns, nil
+	}
+
+	columns := make([]string, len(records[0]))
+	for i, f := range records[0] {
+		columns[i] = f.Name
+	}
+
+	rows := make([][]string, len(records))
+	for i, r := range records {
+		rows[i] = make([]string, len(r))
+		for j, f := range r {
+			rows[i][j] = formatText(f.Value)
+		}
+	}
+	return columns, rows
+}
+
+func writeTable(w io.Writer, columns []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = strings.ToUpper(snakeCase(column))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, columns []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeYAML(w io.Writer, value any) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,206 @@
+package formatter
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+// testRecord has the kinds of fields of the snowflake records, the order
+// of its fields is the order of the columns
+type testRecord struct {
+	Name      string         `db:"name"`
+	Comment   sql.NullString `db:"comment"`
+	Owner     *string
+	CreatedOn time.Time `db:"created_on"`
+	DNSName   string
+	Database  sdk.AccountObjectIdentifier
+	Sizes     []int
+}
+
+func testData() *Data {
+	owner := "SYSADMIN"
+	created := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	return &Data{
+		Columns: []string{"Name", "Comment"},
+		Rows: [][]string{
+			{"A", ""},
+			{`quoted, "name"`, "say hi; bye"},
+		},
+		Records: []any{
+			testRecord{Name: "A", CreatedOn: created, Database: sdk.NewAccountObjectIdentifier("DB")},
+			&testRecord{
+				Name:      `quoted, "name"`,
+				Comment:   sql.NullString{String: "say hi; bye", Valid: true},
+				Owner:     &owner,
+				CreatedOn: created,
+				DNSName:   "a.example.com",
+				Database:  sdk.NewAccountObjectIdentifier("Other"),
+				Sizes:     []int{1, 2},
+			},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: Table,
+			want: `NAME             COMMENT
+A                
+quoted, "name"   say hi; bye
+`,
+		},
+		{
+			// NULL cells are empty and every field is a column in field order
+			format: Wide,
+			want: `NAME             COMMENT       OWNER      CREATED_ON             DNS_NAME        DATABASE   SIZES
+A                                         2024-05-01T12:30:00Z                   "DB"       
+quoted, "name"   say hi; bye   SYSADMIN   2024-05-01T12:30:00Z   a.example.com   "Other"    [1,2]
+`,
+		},
+		{
+			format: CSV,
+			want: `name,comment,owner,created_on,dns_name,database,sizes
+A,,,2024-05-01T12:30:00Z,,"""DB""",
+"quoted, ""name""",say hi; bye,SYSADMIN,2024-05-01T12:30:00Z,a.example.com,"""Other""","[1,2]"
+`,
+		},
+		{
+			format: JSON,
+			want: `[
+  {
+    "name": "A",
+    "comment": null,
+    "owner": null,
+    "created_on": "2024-05-01T12:30:00Z",
+    "dns_name": "",
+    "database": "\"DB\"",
+    "sizes": null
+  },
+  {
+    "name": "quoted, \"name\"",
+    "comment": "say hi; bye",
+    "owner": "SYSADMIN",
+    "created_on": "2024-05-01T12:30:00Z",
+    "dns_name": "a.example.com",
+    "database": "\"Other\"",
+    "sizes": [
+      1,
+      2
+    ]
+  }
+]
+`,
+		},
+		{
+			format: YAML,
+			want: `- name: A
+  comment: null
+  owner: null
+  created_on: 2024-05-01T12:30:00Z
+  dns_name: ""
+  database: '"DB"'
+  sizes: null
+- name: quoted, "name"
+  comment: say hi; bye
+  owner: SYSADMIN
+  created_on: 2024-05-01T12:30:00Z
+  dns_name: a.example.com
+  database: '"Other"'
+  sizes:
+    - 1
+    - 2
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := Write(&buffer, test.format, testData()); err != nil {
+				t.Fatal(err)
+			}
+			if got := buffer.String(); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWriteRows(t *testing.T) {
+	// without records the columns of the view are the fields
+	data := testData()
+	data.Records = nil
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{Table, "NAME             COMMENT\nA                \nquoted, \"name\"   say hi; bye\n"},
+		{CSV, "name,comment\nA,\n\"quoted, \"\"name\"\"\",say hi; bye\n"},
+		// the empty cells of rows are empty strings rather than NULL
+		{JSON, "[\n  {\n    \"name\": \"A\",\n    \"comment\": \"\"\n  },\n  {\n    \"name\": \"quoted, \\\"name\\\"\",\n    \"comment\": \"say hi; bye\"\n  }\n]\n"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := Write(&buffer, test.format, data); err != nil {
+				t.Fatal(err)
+			}
+			if got := buffer.String(); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	data := &Data{Columns: []string{"Name", "DNS Name"}, Records: []any{}}
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{Wide, "NAME   DNS_NAME\n"},
+		{CSV, "name,dns_name\n"},
+		{JSON, "[]\n"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := Write(&buffer, test.format, data); err != nil {
+				t.Fatal(err)
+			}
+			if got := buffer.String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields := Fields(testData().Records[0])
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	want := []string{"name", "comment", "owner", "created_on", "dns_name", "database", "sizes"}
+	if len(names) != len(want) {
+		t.Fatalf("got fields %q, want %q", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("got field %d %s, want %s", i, names[i], want[i])
+		}
+	}
+	for _, f := range fields {
+		if null := f.Name == "comment" || f.Name == "owner" || f.Name == "sizes"; f.Null != null {
+			t.Errorf("got %s null %t, want %t", f.Name, f.Null, null)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package formatter
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+type field struct {
+	Name  string
+	Value any
+}
+
+// record is an ordered set of fields that keeps the struct field order
+// when serialized
+type record []field
+
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling field %s %w", f.Name, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (r record) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, f := range r {
+		var value yaml.Node
+		if err := value.Encode(f.Value); err != nil {
+			return nil, fmt.Errorf("marshaling field %s %w", f.Name, err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.Name}, &value)
+	}
+	return node, nil
+}
+
+// identifier matches every sdk object identifier
+type identifier interface {
+	FullyQualifiedName() string
+}
+
+var (
+	identifierType = reflect.TypeOf((*identifier)(nil)).Elem()
+	valuerType     = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// newRecord flattens the exported fields of a struct, any other value
+// becomes a record with a single value field
+func newRecord(value any) record {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return record{}
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct || v.Type() == timeType || v.Type().Implements(valuerType) {
+		return record{{Name: "value", Value: normalize(v)}}
+	}
+
+	result := make(record, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := structField.Tag.Get("db")
+		if name == "" {
+			name = snakeCase(structField.Name)
+		}
+		result = append(result, field{Name: name, Value: normalize(v.Field(i))})
+	}
+	return result
+}
+
+// Field is a field of a record rendered as text, Null is set when the
+// value is NULL rather than an empty string
+type Field struct {
+	Name string
+	Text string
+	Null bool
+}
+
+// Fields flattens a record into its fields rendered as text, used to show a
+// single object as name and value pairs
+func Fields(value any) []Field {
+	r := newRecord(value)
+	fields := make([]Field, len(r))
+	for i, f := range r {
+		fields[i] = Field{Name: f.Name, Text: formatText(f.Value), Null: f.Value == nil}
+	}
+	return fields
+}
+
+// normalize converts a field to a value with a natural serialization,
+// sql.Null* types become their value or nil and identifiers become their
+// fully qualified name
+func normalize(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type().Implements(identifierType) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil
+		}
+		return v.Interface().(identifier).FullyQualifiedName()
+	}
+
+	if v.Type().Implements(valuerType) && v.Kind() == reflect.Struct {
+		value, err := v.Interface().(driver.Valuer).Value()
+		if err != nil || value == nil {
+			return nil
+		}
+		return normalize(reflect.ValueOf(value))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return normalize(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		return newRecord(v.Interface())
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+		fallthrough
+	case reflect.Array:
+		values := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values[i] = normalize(v.Index(i))
+		}
+		return values
+	}
+
+	return v.Interface()
+}
+
+// formatText renders a normalized value in a single cell
+func formatText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case record, []any, map[string]any:
+		text, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(text)
+	}
+	return fmt.Sprint(value)
+}
+
+// snakeCase converts both Go field names and view column titles,
+// "DNSName" and "DNS Name" both become "dns_name"
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(strings.TrimSpace(name))
+	for i, r := range runes {
+		switch {
+		case r == ' ' || r == '-':
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteRune('_')
+			}
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if (unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower)) && !strings.HasSuffix(b.String(), "_") {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
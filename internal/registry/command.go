@@ -0,0 +1,196 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+// GrantObjectTypes are the object types of the grants scope, completed as
+// its first argument
+var GrantObjectTypes = []string{
+	"role",
+	"user",
+	"database",
+	"schema",
+	"warehouse",
+	"compute-pool",
+	"application",
+	"application-package",
+	"integration",
+	"service",
+	"table",
+	"view",
+	"stage",
+}
+
+// SplitCommand splits a command bar entry on spaces outside of double quotes
+func SplitCommand(text string) []string {
+	words := make([]string, 0)
+
+	var current strings.Builder
+	quoted := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				words = append(words, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+	return words
+}
+
+// FindCommandResource matches the longest leading words naming a resource,
+// resource names may have several words such as `compute pools`
+func FindCommandResource(words []string) (*Resource, []string) {
+	for i := len(words); i > 0; i-- {
+		if resource := FindResource(strings.Join(words[:i], " ")); resource != nil {
+			return resource, words[i:]
+		}
+	}
+	return nil, nil
+}
+
+// ParseCommand parses a command bar entry such as `services DB.SCHEMA` or
+// `grants role ACCOUNTADMIN` into the resource and its scope, a leading
+// alias is expanded first
+func ParseCommand(text string) (*Resource, *Scope, error) {
+	words := ExpandAlias(SplitCommand(text))
+	resource, args := FindCommandResource(words)
+	if resource == nil {
+		return nil, nil, fmt.Errorf("unknown snowflake object %s", strings.TrimSpace(text))
+	}
+
+	scope, err := resource.ParseScope(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource, scope, nil
+}
+
+// ParseScope fills a scope from command arguments. An argument is either
+// `scope=value` or an identifier assigned by its number of parts, `DB` to
+// the first account level scope of the resource, `DB.SCHEMA` to the schema
+// and `DB.SCHEMA.NAME` to the service. Resources scoped to an object take
+// the object type followed by its name.
+func (r *Resource) ParseScope(args []string) (*Scope, error) {
+	scope := &Scope{}
+
+	if r.Accepts(ScopeObject) {
+		if len(args) == 0 {
+			return scope, nil
+		}
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s expect an object type and name such as role ACCOUNTADMIN", r.Name)
+		}
+		if err := scope.setObject(args[0], args[1]); err != nil {
+			return nil, err
+		}
+		return scope, nil
+	}
+
+	for _, arg := range args {
+		argument, value, explicit := strings.Cut(arg, "=")
+		if !explicit {
+			value = arg
+			parts, err := snowflake.SplitIdentifier(value, 0)
+			if err != nil {
+				return nil, err
+			}
+			argument = string(r.ScopeForParts(len(parts)))
+			if argument == "" {
+				return nil, fmt.Errorf("%s do not accept %s", r.Name, value)
+			}
+		}
+
+		if !r.Accepts(ScopeArgument(argument)) {
+			return nil, fmt.Errorf("%s do not accept %s", r.Name, argument)
+		}
+		if err := scope.set(ScopeArgument(argument), value); err != nil {
+			return nil, fmt.Errorf("parsing %s %w", argument, err)
+		}
+	}
+	return scope, nil
+}
+
+// Accepts reports whether the resource takes the scope argument
+func (r *Resource) Accepts(argument ScopeArgument) bool {
+	return slices.Contains(r.Scopes, argument)
+}
+
+// ScopeForParts picks the scope an identifier with the number of parts
+// belongs to, empty when the resource has none
+func (r *Resource) ScopeForParts(parts int) ScopeArgument {
+	for _, argument := range r.Scopes {
+		switch {
+		case parts == 1 && (argument == ScopeDatabase || argument == ScopeComputePool || argument == ScopeApplicationPackage):
+			return argument
+		case parts == 2 && argument == ScopeSchema:
+			return argument
+		case parts == 3 && argument == ScopeService:
+			return argument
+		}
+	}
+	return ""
+}
+
+func (s *Scope) set(argument ScopeArgument, value string) error {
+	switch argument {
+	case ScopeDatabase, ScopeComputePool, ScopeApplicationPackage:
+		id, err := snowflake.ParseAccountObjectIdentifier(value)
+		if err != nil {
+			return err
+		}
+		switch argument {
+		case ScopeDatabase:
+			s.Database = &id
+		case ScopeComputePool:
+			s.ComputePool = &id
+		default:
+			s.ApplicationPackage = &id
+		}
+	case ScopeSchema:
+		id, err := snowflake.ParseDatabaseObjectIdentifier(value)
+		if err != nil {
+			return err
+		}
+		s.Schema = &id
+	case ScopeService:
+		id, err := snowflake.ParseSchemaObjectIdentifier(value)
+		if err != nil {
+			return err
+		}
+		s.Service = &id
+	default:
+		return fmt.Errorf("unknown scope %s", argument)
+	}
+	return nil
+}
+
+// setObject parses an object type such as `role` or `compute-pool` and
+// the identifier of the object
+func (s *Scope) setObject(objectType string, name string) error {
+	if !slices.Contains(GrantObjectTypes, strings.ToLower(strings.ReplaceAll(objectType, "_", "-"))) {
+		return fmt.Errorf("unknown object type %s", objectType)
+	}
+	s.ObjectType = sdk.ObjectType(strings.ToUpper(strings.NewReplacer("-", " ", "_", " ").Replace(objectType)))
+
+	object, err := snowflake.ParseObjectIdentifier(name)
+	if err != nil {
+		return err
+	}
+	s.Object = object
+	return nil
+}
@@ -0,0 +1,208 @@
+// Package registry holds the resources snowctl opens by name, the scopes
+// narrowing them down and the tables of rows they list. The views register
+// themselves from the components package, the command line lists the same
+// resources without drawing them.
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/config"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+// ScopeArgument names an argument narrowing down the objects of a resource
+type ScopeArgument string
+
+const (
+	ScopeDatabase           ScopeArgument = "database"
+	ScopeSchema             ScopeArgument = "schema"
+	ScopeComputePool        ScopeArgument = "compute-pool"
+	ScopeService            ScopeArgument = "service"
+	ScopeApplicationPackage ScopeArgument = "application-package"
+	// ScopeObject is an object type followed by its name, `role ACCOUNTADMIN`
+	ScopeObject ScopeArgument = "object"
+)
+
+var ScopeArguments = []ScopeArgument{ScopeDatabase, ScopeSchema, ScopeComputePool, ScopeService, ScopeApplicationPackage, ScopeObject}
+
+// Scope holds the values of the scope arguments, nil when not given
+type Scope struct {
+	Database           *sdk.AccountObjectIdentifier
+	Schema             *sdk.DatabaseObjectIdentifier
+	ComputePool        *sdk.AccountObjectIdentifier
+	Service            *sdk.SchemaObjectIdentifier
+	ApplicationPackage *sdk.AccountObjectIdentifier
+	ObjectType         sdk.ObjectType
+	Object             sdk.ObjectIdentifier
+}
+
+// Has reports whether the argument was given
+func (s *Scope) Has(argument ScopeArgument) bool {
+	switch argument {
+	case ScopeDatabase:
+		return s.Database != nil
+	case ScopeSchema:
+		return s.Schema != nil
+	case ScopeComputePool:
+		return s.ComputePool != nil
+	case ScopeService:
+		return s.Service != nil
+	case ScopeApplicationPackage:
+		return s.ApplicationPackage != nil
+	case ScopeObject:
+		return s.Object != nil
+	}
+	return false
+}
+
+// Resource is a view that can be opened by name from the search bar and
+// listed by snowctl get
+type Resource struct {
+	// Name is plural and lower case with spaces, "compute pools"
+	Name    string
+	Aliases []string
+	// Description is shown in the help view
+	Description string
+	// Interactive resources are only opened from the interface, snowctl
+	// get neither lists nor accepts them
+	Interactive bool
+	// Scopes are the arguments the view accepts, Required the ones it needs
+	Scopes   []ScopeArgument
+	Required []ScopeArgument
+	New      func(cm *snowflake.ConnectionManager, scope *Scope) Source
+	// States are the default rules styling the rows by state, after the
+	// configured ones
+	States []config.StateRule
+
+	// viewType is the type of the views created by New
+	viewType reflect.Type
+}
+
+var resources = make([]*Resource, 0)
+
+// RegisterResource adds a resource, every view registers itself from init
+func RegisterResource(resource *Resource) {
+	for _, name := range append([]string{resource.Name}, resource.Aliases...) {
+		if FindResource(name) != nil {
+			panic(fmt.Sprintf("resource name %s registered twice", name))
+		}
+	}
+	resource.viewType = reflect.TypeOf(resource.New(nil, CompleteScope()))
+	resources = append(resources, resource)
+}
+
+// CompleteScope has every argument set so that New can create a view of
+// any resource to learn its type
+func CompleteScope() *Scope {
+	return &Scope{
+		Database:           &sdk.AccountObjectIdentifier{},
+		Schema:             &sdk.DatabaseObjectIdentifier{},
+		ComputePool:        &sdk.AccountObjectIdentifier{},
+		Service:            &sdk.SchemaObjectIdentifier{},
+		ApplicationPackage: &sdk.AccountObjectIdentifier{},
+		Object:             sdk.AccountObjectIdentifier{},
+	}
+}
+
+// Resources returns every registered resource sorted by name
+func Resources() []*Resource {
+	result := slices.Clone(resources)
+	slices.SortFunc(result, func(a, b *Resource) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// FindResource looks a resource up by name or alias, case insensitive and
+// with `-` or `_` in place of spaces
+func FindResource(name string) *Resource {
+	name = NormalizeName(name)
+	for _, resource := range resources {
+		if NormalizeName(resource.Name) == name {
+			return resource
+		}
+		for _, alias := range resource.Aliases {
+			if NormalizeName(alias) == name {
+				return resource
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceOf finds the resource of a view by its type, views pushed from
+// key bindings are not created through Open
+func ResourceOf(view Source) *Resource {
+	for _, resource := range resources {
+		if resource.viewType == reflect.TypeOf(view) {
+			return resource
+		}
+	}
+	return nil
+}
+
+// commandAliases map a word of the command bar to the command it expands to
+var commandAliases = make(map[string]string)
+
+// RegisterAlias adds a command bar alias expanding to a command such as
+// `services DB.SCHEMA`, an alias cannot shadow a resource name
+func RegisterAlias(alias string, command string) error {
+	if len(strings.Fields(alias)) != 1 {
+		return fmt.Errorf("alias %q must be a single word", alias)
+	}
+	if FindResource(alias) != nil {
+		return fmt.Errorf("alias %s is already the name of a resource", alias)
+	}
+	if _, _, err := ParseCommand(command); err != nil {
+		return fmt.Errorf("parsing alias %s %w", alias, err)
+	}
+
+	commandAliases[NormalizeName(alias)] = command
+	return nil
+}
+
+// Aliases returns the registered aliases sorted
+func Aliases() []string {
+	aliases := make([]string, 0, len(commandAliases))
+	for alias := range commandAliases {
+		aliases = append(aliases, alias)
+	}
+	slices.Sort(aliases)
+	return aliases
+}
+
+// ExpandAlias replaces a leading alias by the words of its command
+func ExpandAlias(words []string) []string {
+	if len(words) == 0 {
+		return words
+	}
+	if command, ok := commandAliases[NormalizeName(words[0])]; ok {
+		return append(SplitCommand(command), words[1:]...)
+	}
+	return words
+}
+
+// NormalizeName lower cases a resource name or alias and replaces `-` and
+// `_` by spaces
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimSpace(name)))
+}
+
+// Open creates the view of the resource, the scope must hold every
+// required argument
+func (r *Resource) Open(cm *snowflake.ConnectionManager, scope *Scope) (Source, error) {
+	if scope == nil {
+		scope = &Scope{}
+	}
+	for _, argument := range r.Required {
+		if !scope.Has(argument) {
+			return nil, fmt.Errorf("%s require the %s scope", r.Name, argument)
+		}
+	}
+	return r.New(cm, scope), nil
+}
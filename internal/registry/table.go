@@ -0,0 +1,53 @@
+package registry
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/formatter"
+)
+
+// Source is a view as the registry knows it, the rows it lists
+type Source interface {
+	GetData(ctx context.Context) (*Table, error)
+}
+
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    []Row
+}
+
+// Row is a row of a Table, the cells in the order of the columns and the
+// object the row shows. Key bindings act on the Identifier and Record of
+// the selected row, never on the cells which may be hidden or reordered.
+type Row struct {
+	Cells []string
+
+	// Record is the typed snowflake object the row was built from, used to
+	// export the full object rather than the displayed columns
+	Record any
+
+	// Identifier is the fully qualified identifier of the object, nil for
+	// rows that are not snowflake objects such as grants or containers
+	Identifier sdk.ObjectIdentifier
+}
+
+// Data returns the rows and records of the table for the formatter, the
+// records are only used when every row has one
+func (t *Table) Data() *formatter.Data {
+	data := &formatter.Data{
+		Columns: t.Columns,
+		Rows:    make([][]string, len(t.Rows)),
+		Records: make([]any, len(t.Rows)),
+	}
+	for r, row := range t.Rows {
+		data.Rows[r] = row.Cells
+		data.Records[r] = row.Record
+	}
+	if slices.ContainsFunc(t.Rows, func(row Row) bool { return row.Record == nil }) {
+		data.Records = nil
+	}
+	return data
+}
@@ -3,7 +3,6 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -33,7 +32,10 @@ type ApplicationPackageManifest struct {
 }
 
 func (c *applicationpackageversions) Show(ctx context.Context, id sdk.AccountObjectIdentifier) ([]ApplicationPackageVersion, error) {
-	stmt := fmt.Sprintf("SHOW VERSIONS IN APPLICATION PACKAGE %s", id.Name())
+	stmt, err := newStatement("SHOW", "VERSIONS").in("APPLICATION PACKAGE", id).build()
+	if err != nil {
+		return nil, err
+	}
 	rows, err := c.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
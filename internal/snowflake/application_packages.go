@@ -0,0 +1,19 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type ApplicationPackages interface {
+	Show(ctx context.Context) ([]sdk.ApplicationPackage, error)
+}
+
+type applicationpackages struct {
+	client *Client
+}
+
+func (c *applicationpackages) Show(ctx context.Context) ([]sdk.ApplicationPackage, error) {
+	return c.client.SDKClient.ApplicationPackages.Show(ctx, sdk.NewShowApplicationPackageRequest())
+}
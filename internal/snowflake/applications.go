@@ -0,0 +1,19 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Applications interface {
+	Show(ctx context.Context) ([]sdk.Application, error)
+}
+
+type applications struct {
+	client *Client
+}
+
+func (c *applications) Show(ctx context.Context) ([]sdk.Application, error) {
+	return c.client.SDKClient.Applications.Show(ctx, sdk.NewShowApplicationRequest())
+}
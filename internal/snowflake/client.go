@@ -1,9 +1,13 @@
 package snowflake
 
 import (
+	"cmp"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 
 	sf "github.com/snowflakedb/gosnowflake"
 
@@ -15,6 +19,15 @@ import (
 type Client struct {
 	SDKClient *sdk.Client
 
+	// safeguards are checked before dropping objects
+	safeguards *Safeguards
+
+	// holds counts the calls using the client, it is closed once they are
+	// done when replaced is set, see ConnectionManager.Hold
+	holdMutex sync.Mutex
+	holds     int
+	replaced  bool
+
 	// Modeled after the SDKClient we create the missing interfaces
 	Listings                   Listings
 	ImageRepositories          ImageRepositories
@@ -28,114 +41,342 @@ type Client struct {
 	Files                      Files
 	ReleaseDirectives          ReleaseDirectives
 	ApplicationPackageVersions ApplicationPackageVersions
+
+	// Thin wrappers around the SDKClient so that every call made by
+	// snowctl goes through an interface that can be replaced
+	Roles                Roles
+	Users                Users
+	Warehouses           Warehouses
+	Databases            Databases
+	Schemas              Schemas
+	Tables               Tables
+	Views                Views
+	Stages               Stages
+	Grants               Grants
+	Applications         Applications
+	ApplicationPackages  ApplicationPackages
+	NetworkRules         NetworkRules
+	NetworkPolicies      NetworkPolicies
+	Procedures           Procedures
+	Streamlits           Streamlits
+	SecurityIntegrations SecurityIntegrations
+	Sessions             Sessions
 }
 
 type Connection interface {
-	SnowflakeConfig() *sf.Config
+	// Settings is the configuration without the credentials that
+	// SnowflakeConfig reads from key and token files
+	Settings() *sf.Config
+	SnowflakeConfig() (*sf.Config, error)
+	// AuthenticatorName is the authentication method of the connection
+	AuthenticatorName() string
+	// File is where the connection was read from
+	File() string
+}
+
+// ConnectionInfo describes a configured connection without connecting to it
+type ConnectionInfo struct {
+	// Name is snowflake.NAME or snowsql.NAME
+	Name          string
+	Settings      *sf.Config
+	Authenticator string
+	File          string
 }
 
 type ConnectionManager struct {
+	// connectionConfig is shared by the connection managers of a
+	// SessionRegistry, each with its own client
+	*connectionConfig
+
+	// clientMutex guards the current client and its name, SetClient
+	// replaces them on the UI goroutine while views load on others
+	clientMutex   sync.RWMutex
+	currentClient *Client
+	// name is the connection of the current client
+	name string
+}
+
+// connectionConfig is the configuration read from the files and the
+// session defaults
+type connectionConfig struct {
+	// the connection maps are replaced by reload on the UI goroutine while
+	// views read them on the load goroutines, see connections
+	connectionsMutex     sync.RWMutex
 	snowflakeConnections map[string]*configuration.Connection
 	snowsqlConnections   map[string]*snowsql.Connection
-	currentClient        *Client
+
+	// staticName is set when the connection manager serves a single
+	// pre-built client see NewStaticConnectionManager
+	staticName   string
+	staticClient *Client
+
+	// role and warehouse override the ones of the connections when set
+	role      string
+	warehouse string
+
+	// safeguards are given to the clients, see SetSafeguards
+	safeguards *Safeguards
+
+	// health caches the last check of each connection by name
+	healthMutex sync.Mutex
+	health      map[string]*ConnectionHealth
 }
 
 func NewConnectionManager() (*ConnectionManager, error) {
-	var connectionManager ConnectionManager
-
-	snowflakeConnections, err := configuration.ReadConfig()
-	if err != nil {
-		return nil, fmt.Errorf("reading snowflake standard configuration %w", err)
-	}
-	if snowflakeConnections != nil {
-		connectionManager.snowflakeConnections = snowflakeConnections
+	connectionManager := ConnectionManager{connectionConfig: &connectionConfig{}}
+	if err := connectionManager.reload(); err != nil {
+		return nil, err
 	}
+	return &connectionManager, nil
+}
 
-	snowsqlConnections, err := snowsql.ReadConfig()
-	if err != nil {
-		return nil, fmt.Errorf("reading snowflake snowsql configuration %w", err)
+// NewStaticConnectionManager returns a connection manager that always
+// serves the given client under the given name. It does not read any
+// configuration files which makes it suitable for use with the in-memory
+// backend in the fake package.
+func NewStaticConnectionManager(name string, client *Client) *ConnectionManager {
+	return &ConnectionManager{
+		connectionConfig: &connectionConfig{
+			snowflakeConnections: map[string]*configuration.Connection{},
+			snowsqlConnections:   map[string]*snowsql.Connection{},
+			staticName:           name,
+			staticClient:         client,
+		},
+		currentClient: client,
+		name:          name,
 	}
-	connectionManager.snowsqlConnections = snowsqlConnections
+}
 
-	return &connectionManager, nil
+// connections returns the connection maps, they are never modified once
+// read so they are safe to use after reload replaces them
+func (c *connectionConfig) connections() (map[string]*configuration.Connection, map[string]*snowsql.Connection) {
+	c.connectionsMutex.RLock()
+	defer c.connectionsMutex.RUnlock()
+
+	return c.snowflakeConnections, c.snowsqlConnections
 }
 
-func (cm *ConnectionManager) AvailableClients() map[string]*sf.Config {
-	var connections = make(map[string]*sf.Config, 0)
+// Connections returns the configured connections sorted by name
+func (cm *ConnectionManager) Connections() []*ConnectionInfo {
+	snowflakeConnections, snowsqlConnections := cm.connections()
+	connections := make([]*ConnectionInfo, 0, len(snowsqlConnections)+len(snowflakeConnections)+1)
 
-	for name, connection := range cm.snowsqlConnections {
-		connections[fmt.Sprintf("snowsql.%s", name)] = connection.SnowflakeConfig()
+	add := func(name string, connection Connection) {
+		connections = append(connections, &ConnectionInfo{
+			Name:          name,
+			Settings:      connection.Settings(),
+			Authenticator: connection.AuthenticatorName(),
+			File:          connection.File(),
+		})
+	}
+	for name, connection := range snowsqlConnections {
+		add(fmt.Sprintf("snowsql.%s", name), connection)
+	}
+	for name, connection := range snowflakeConnections {
+		add(fmt.Sprintf("snowflake.%s", name), connection)
 	}
-	for name, connection := range cm.snowflakeConnections {
-		connections[fmt.Sprintf("snowflake.%s", name)] = connection.SnowflakeConfig()
+	if cm.staticName != "" {
+		connections = append(connections, &ConnectionInfo{Name: cm.staticName, Settings: &sf.Config{}})
 	}
 
+	slices.SortFunc(connections, func(a, b *ConnectionInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 	return connections
 }
 
+// HasConnection reports whether the named connection, snowflake.NAME or
+// snowsql.NAME, is configured
+func (cm *ConnectionManager) HasConnection(name string) bool {
+	return slices.ContainsFunc(cm.Connections(), func(connection *ConnectionInfo) bool {
+		return connection.Name == name
+	})
+}
+
 func (cm *ConnectionManager) SetDefault() error {
-	availableConnections := cm.AvailableClients()
-	if len(availableConnections) == 0 {
+	if cm.staticName != "" {
+		return nil
+	}
+
+	connections := cm.Connections()
+	if len(connections) == 0 {
 		return fmt.Errorf("no connections listed in connections.toml or snowsql configuration files")
 	}
 
-	if availableConnections["snowflake.default"] != nil {
+	if cm.HasConnection("snowflake.default") {
 		return cm.SetClient("snowflake.default")
 	}
-	if availableConnections["snowsql.default"] != nil {
+	if cm.HasConnection("snowsql.default") {
 		return cm.SetClient("snowsql.default")
 	}
 
 	// be consistent and if no default set choose
 	// the alphabetically first connection name
-	connectionNames := make([]string, 0)
-	for connectionName, _ := range availableConnections {
-		connectionNames = append(connectionNames, connectionName)
-	}
-	slices.Sort(connectionNames)
-	return cm.SetClient(connectionNames[0])
+	return cm.SetClient(connections[0].Name)
+}
+
+// SetSessionDefaults overrides the role and warehouse of every connection,
+// empty values keep the ones of the connection
+func (cm *ConnectionManager) SetSessionDefaults(role string, warehouse string) {
+	cm.role = role
+	cm.warehouse = warehouse
 }
 
+// SetClient connects to the named connection, either snowflake.NAME or
+// snowsql.NAME or NAME alone preferring connections.toml over snowsql. The
+// previous client is closed once connected and the loads holding it are done.
 func (cm *ConnectionManager) SetClient(name string) error {
-	var connection Connection
-	tokens := strings.Split(name, ".")
-	if len(tokens) != 2 {
-		return fmt.Errorf("expected client name with '.' separator e.g. snowsql.connectionname got %s", name)
+	if cm.staticName != "" && name == cm.staticName {
+		cm.replaceClient(cm.staticClient, name)
+		return nil
 	}
-	switch tokens[0] {
-	case "snowsql":
-		connection = cm.snowsqlConnections[tokens[1]]
-		if connection == nil {
-			return fmt.Errorf("snowsql connection with name %s does not exist", tokens[1])
-		}
-	case "snowflake":
-		connection = cm.snowflakeConnections[tokens[1]]
-		if connection == nil {
-			return fmt.Errorf("snowflake connection with name %s does not exist", tokens[1])
-		}
-	default:
-		return fmt.Errorf("unhandled connection base type %s", tokens[0])
+
+	config, err := cm.ConnectionConfig(name)
+	if err != nil {
+		return err
 	}
 
-	sdkClient, err := sdk.NewClient(connection.SnowflakeConfig())
+	sdkClient, err := newSDKClient(config, cm.readOnly(name))
 	if err != nil {
 		return err
 	}
 
 	client := &Client{
-		SDKClient: sdkClient,
+		SDKClient:  sdkClient,
+		safeguards: cm.safeguards,
 	}
 	client.initialize()
 
+	cm.replaceClient(client, name)
+	return nil
+}
+
+// replaceClient makes the client current, the previous one is closed once
+// the calls holding it are done, see Hold
+func (cm *ConnectionManager) replaceClient(client *Client, name string) {
+	cm.clientMutex.Lock()
+	previous := cm.currentClient
 	cm.currentClient = client
+	cm.name = name
+	cm.clientMutex.Unlock()
 
-	return nil
+	if previous != nil && previous != cm.staticClient && previous != client {
+		previous.closeWhenReleased()
+	}
+}
+
+// newSDKClient connects with the configuration, the sdk goes through a DSN
+// which drops the transport so the ones with a transport such as a proxy
+// open the database with a connector instead, as do the read-only ones to
+// check their statements
+func newSDKClient(config *sf.Config, readOnly bool) (*sdk.Client, error) {
+	if config.Transporter == nil && !readOnly {
+		return sdk.NewClient(config)
+	}
+
+	var connector driver.Connector = sf.NewConnector(sf.SnowflakeDriver{}, *config)
+	if readOnly {
+		connector = readOnlyConnector{Connector: connector}
+	}
+	db := sql.OpenDB(connector)
+	sdkClient := sdk.NewClientFromDB(db)
+	if err := sdkClient.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to snowflake %w", err)
+	}
+	return sdkClient, nil
+}
+
+// ConnectionConfig returns the configuration of the named connection, see
+// SetClient, with the role and warehouse overrides
+func (cm *ConnectionManager) ConnectionConfig(name string) (*sf.Config, error) {
+	kind, connectionName, err := cm.splitConnectionName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	snowflakeConnections, snowsqlConnections := cm.connections()
+	var connection Connection
+	switch kind {
+	case "snowsql":
+		if snowsqlConnections[connectionName] != nil {
+			connection = snowsqlConnections[connectionName]
+		}
+	case "snowflake":
+		if snowflakeConnections[connectionName] != nil {
+			// the SNOWFLAKE_<KEY> variables set the keys of the selected
+			// connection
+			active, err := snowflakeConnections[connectionName].Active()
+			if err != nil {
+				return nil, fmt.Errorf("configuring connection %s %w", name, err)
+			}
+			connection = active
+		}
+	}
+	if connection == nil {
+		return nil, fmt.Errorf("%s connection with name %s does not exist", kind, connectionName)
+	}
+
+	config, err := connection.SnowflakeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuring connection %s %w", name, err)
+	}
+	config.Role = cmp.Or(cm.role, config.Role)
+	config.Warehouse = cmp.Or(cm.warehouse, config.Warehouse)
+	return config, nil
+}
+
+// splitConnectionName splits snowflake.NAME or snowsql.NAME, NAME alone
+// prefers connections.toml over snowsql
+func (cm *ConnectionManager) splitConnectionName(name string) (string, string, error) {
+	kind, connectionName, ok := strings.Cut(name, ".")
+	if !ok {
+		if snowflakeConnections, _ := cm.connections(); snowflakeConnections[name] != nil {
+			return "snowflake", name, nil
+		}
+		return "snowsql", name, nil
+	}
+	if kind != "snowflake" && kind != "snowsql" {
+		return "", "", fmt.Errorf("unhandled connection base type %s", kind)
+	}
+	return kind, connectionName, nil
 }
 
 func (cm *ConnectionManager) GetClient() *Client {
+	cm.clientMutex.RLock()
+	defer cm.clientMutex.RUnlock()
+
 	return cm.currentClient
 }
 
+// Hold returns the current client and keeps it open until release is
+// called even when SetClient replaces it meanwhile, calls made off the UI
+// goroutine hold the client they start with
+func (cm *ConnectionManager) Hold() (client *Client, release func()) {
+	cm.clientMutex.RLock()
+	defer cm.clientMutex.RUnlock()
+
+	client = cm.currentClient
+	if client == nil {
+		return nil, func() {}
+	}
+	client.hold()
+	return client, sync.OnceFunc(client.release)
+}
+
+// Name returns the connection of the current client
+func (cm *ConnectionManager) Name() string {
+	cm.clientMutex.RLock()
+	defer cm.clientMutex.RUnlock()
+
+	return cm.name
+}
+
+// Close closes the current client, the static client is left to its owner
+func (cm *ConnectionManager) Close() {
+	cm.replaceClient(nil, cm.Name())
+}
+
 func (c *Client) initialize() {
 	c.Listings = &listings{client: c}
 	c.ImageRepositories = &imagerepositories{client: c}
@@ -149,8 +390,62 @@ func (c *Client) initialize() {
 	c.Files = &files{client: c}
 	c.ReleaseDirectives = &releasedirectives{client: c}
 	c.ApplicationPackageVersions = &applicationpackageversions{client: c}
+
+	c.Roles = &roles{client: c}
+	c.Users = &users{client: c}
+	c.Warehouses = &warehouses{client: c}
+	c.Databases = &databases{client: c}
+	c.Schemas = &schemas{client: c}
+	c.Tables = &tables{client: c}
+	c.Views = &views{client: c}
+	c.Stages = &stages{client: c}
+	c.Grants = &grants{client: c}
+	c.Applications = &applications{client: c}
+	c.ApplicationPackages = &applicationpackages{client: c}
+	c.NetworkRules = &networkrules{client: c}
+	c.NetworkPolicies = &networkpolicies{client: c}
+	c.Procedures = &procedures{client: c}
+	c.Streamlits = &streamlits{client: c}
+	c.SecurityIntegrations = &securityintegrations{client: c}
+	c.Sessions = &sessions{client: c}
+}
+
+// hold marks the client in use, see ConnectionManager.Hold
+func (c *Client) hold() {
+	c.holdMutex.Lock()
+	defer c.holdMutex.Unlock()
+
+	c.holds++
+}
+
+// release ends a hold, the last one closes the client when it was replaced
+func (c *Client) release() {
+	c.holdMutex.Lock()
+	defer c.holdMutex.Unlock()
+
+	c.holds--
+	if c.holds == 0 && c.replaced {
+		c.Close()
+	}
+}
+
+// closeWhenReleased closes the client now or, when it is held, once the
+// last hold is released
+func (c *Client) closeWhenReleased() {
+	c.holdMutex.Lock()
+	defer c.holdMutex.Unlock()
+
+	if c.holds > 0 {
+		c.replaced = true
+		return
+	}
+	c.Close()
 }
 
 func (c *Client) Close() {
-	c.SDKClient.Close()
+	// clients built without an SDKClient e.g. the in-memory fake have
+	// nothing to close
+	if c.SDKClient != nil {
+		c.SDKClient.Close()
+	}
 }
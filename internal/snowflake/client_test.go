@@ -0,0 +1,110 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+// testClient returns a client of a database that is never connected to
+func testClient(t *testing.T) *Client {
+	t.Helper()
+	db, err := sql.Open("snowflake", "user:password@account/database")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &Client{SDKClient: sdk.NewClientFromDB(db)}
+	client.initialize()
+	return client
+}
+
+// isClosed tells a closed database apart from an open one without
+// connecting, the cancelled context fails the connection after the check
+// for a closed database
+func isClosed(client *Client) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.SDKClient.GetConn().Conn(ctx)
+	return !errors.Is(err, context.Canceled)
+}
+
+func TestReplaceHeldClient(t *testing.T) {
+	static := &Client{}
+	cm := NewStaticConnectionManager("static", static)
+	client := testClient(t)
+	cm.replaceClient(client, "other")
+
+	held, release := cm.Hold()
+	if held != client {
+		t.Fatal("held a client other than the current one")
+	}
+	if err := cm.SetClient("static"); err != nil {
+		t.Fatal(err)
+	}
+	if cm.GetClient() != static || cm.Name() != "static" {
+		t.Errorf("got client of %s after switching to static", cm.Name())
+	}
+	if isClosed(client) {
+		t.Error("the replaced client was closed while held")
+	}
+
+	release()
+	// releasing twice does not release another hold
+	release()
+	if !isClosed(client) {
+		t.Error("the replaced client is open after its last hold was released")
+	}
+
+	// the static client is never closed
+	_, release = cm.Hold()
+	cm.Close()
+	release()
+	if cm.GetClient() != nil {
+		t.Error("closing kept the current client")
+	}
+}
+
+func TestReplaceClient(t *testing.T) {
+	cm := NewStaticConnectionManager("static", &Client{})
+	client := testClient(t)
+	cm.replaceClient(client, "other")
+	if err := cm.SetClient("static"); err != nil {
+		t.Fatal(err)
+	}
+	if !isClosed(client) {
+		t.Error("the replaced client is open without holds")
+	}
+}
+
+// TestSetClientWhileLoading switches connections while other goroutines
+// use the current client as loads do, run it with -race
+func TestSetClientWhileLoading(t *testing.T) {
+	cm := NewStaticConnectionManager("static", &Client{})
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				client, release := cm.Hold()
+				if client != nil && client.SDKClient != nil && isClosed(client) {
+					t.Error("held a closed client")
+				}
+				_ = cm.Name()
+				release()
+			}
+		}()
+	}
+	for range 100 {
+		cm.replaceClient(testClient(t), "other")
+		if err := cm.SetClient("static"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+}
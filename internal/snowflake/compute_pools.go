@@ -45,21 +45,32 @@ type CreateComputePoolOptions struct {
 	Comment            *string
 }
 
+// https://docs.snowflake.com/en/sql-reference/sql/create-compute-pool
 func (c *computepools) Create(ctx context.Context, id sdk.AccountObjectIdentifier, opts *CreateComputePoolOptions) error {
-	createTemplate := fmt.Sprintf(`
-	CREATE COMPUTE POOL {{ if .IfNotExists }}IF NOT EXISTS{{ end }} %s
-	    {{ if .Application }}FOR APPLICATION {{ .Application.FullQualifiedName }}{{ end }}
-	    MIN_NODES = {{ .MinNodes }}
-		MAX_NODES = {{ .MaxNodes }}
-		INSTANCE_FAMILY = {{ .InstanceFamily }}
-		{{ if .AutoResume }}AUTO_RESUME = {{ if .AutoResume }}TRUE{{ else }}FALSE{{ end }}{{ end }}
-		{{ if .InitiallySuspended }}INITIALLY_SUSPENDED = {{ if .InitiallySuspended }}TRUE{{ else }}FALSE{{ end }}{{ end }}
-		{{ if .AutoSuspendSecs }}AUTO_SUSPEND_SECS = {{ .AutoSuspendSecs }}{{ end }}
-		{{ if .Comment }}COMMENT = '{{ .Comment }}'{{ end }};
-	`, id.FullyQualifiedName())
-	stmt := templateToQuery(createTemplate, opts)
-
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	if opts == nil {
+		opts = &CreateComputePoolOptions{}
+	}
+
+	stmt := newStatement("CREATE", "COMPUTE POOL").
+		keywordIf(opts.IfNotExists, "IF NOT EXISTS").
+		identifier(id)
+	if opts.Application != nil {
+		stmt.keyword("FOR", "APPLICATION").identifier(opts.Application)
+	}
+	query, err := stmt.
+		intProperty("MIN_NODES", &opts.MinNodes).
+		intProperty("MAX_NODES", &opts.MaxNodes).
+		keywordProperty("INSTANCE_FAMILY", string(opts.InstanceFamily)).
+		boolProperty("AUTO_RESUME", opts.AutoResume).
+		boolProperty("INITIALLY_SUSPENDED", opts.InitiallySuspended).
+		intProperty("AUTO_SUSPEND_SECS", opts.AutoSuspendSecs).
+		stringProperty("COMMENT", opts.Comment).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
 
@@ -85,7 +96,7 @@ type ComputePool struct {
 }
 
 func (c *computepools) Show(ctx context.Context) ([]ComputePool, error) {
-	rows, err := c.client.SDKClient.GetConn().Query("SHOW COMPUTE POOLS")
+	rows, err := c.client.SDKClient.GetConn().QueryContext(ctx, "SHOW COMPUTE POOLS")
 	if err != nil {
 		return nil, err
 	}
@@ -109,10 +120,22 @@ type DropComputePoolOptions struct {
 }
 
 func (c *computepools) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropComputePoolOptions) error {
-	dropTemplate := fmt.Sprintf("DROP COMPUTE POOL {{ if .IfExists }}IF EXISTS{{ end }} %s", id.FullyQualifiedName())
-	stmt := templateToQuery(dropTemplate, opts)
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropComputePoolOptions{}
+	}
+
+	query, err := newStatement("DROP", "COMPUTE POOL").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
 
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
 
@@ -138,10 +161,13 @@ type ComputePoolDetails struct {
 }
 
 func (c *computepools) Describe(ctx context.Context, id sdk.AccountObjectIdentifier) (*ComputePoolDetails, error) {
-	stmt := fmt.Sprintf("DESCRIBE COMPUTE POOL %s", id.FullyQualifiedName())
+	stmt, err := newStatement("DESCRIBE", "COMPUTE POOL").identifier(id).build()
+	if err != nil {
+		return nil, err
+	}
 	var describeComputePoolResult ComputePoolDetails
 
-	err := c.client.SDKClient.GetConn().QueryRow(stmt).Scan(&describeComputePoolResult.Name, &describeComputePoolResult.State, &describeComputePoolResult.MinNodes, &describeComputePoolResult.MaxNodes, &describeComputePoolResult.InstanceFamily, &describeComputePoolResult.NumServices, &describeComputePoolResult.NumJobs, &describeComputePoolResult.AutoSuspendSecs, &describeComputePoolResult.AutoResume, &describeComputePoolResult.ActiveNodes, &describeComputePoolResult.IdleNodes, &describeComputePoolResult.CreatedOn, &describeComputePoolResult.ResumedOn, &describeComputePoolResult.UpdatedOn, &describeComputePoolResult.Owner, &describeComputePoolResult.Comment, &describeComputePoolResult.IsExclusive, &describeComputePoolResult.Application)
+	err = c.client.SDKClient.GetConn().QueryRowContext(ctx, stmt).Scan(&describeComputePoolResult.Name, &describeComputePoolResult.State, &describeComputePoolResult.MinNodes, &describeComputePoolResult.MaxNodes, &describeComputePoolResult.InstanceFamily, &describeComputePoolResult.NumServices, &describeComputePoolResult.NumJobs, &describeComputePoolResult.AutoSuspendSecs, &describeComputePoolResult.AutoResume, &describeComputePoolResult.ActiveNodes, &describeComputePoolResult.IdleNodes, &describeComputePoolResult.CreatedOn, &describeComputePoolResult.ResumedOn, &describeComputePoolResult.UpdatedOn, &describeComputePoolResult.Owner, &describeComputePoolResult.Comment, &describeComputePoolResult.IsExclusive, &describeComputePoolResult.Application)
 	if err != nil {
 		return nil, err
 	}
@@ -151,27 +177,34 @@ func (c *computepools) Describe(ctx context.Context, id sdk.AccountObjectIdentif
 
 type AlterComputePoolOptions struct {
 	IfExists        bool
-	MinNodes        int
-	MaxNodes        int
-	AutoResume      bool
-	AutoSuspendSecs int
-	Comment         string
+	MinNodes        *int
+	MaxNodes        *int
+	AutoResume      *bool
+	AutoSuspendSecs *int
+	Comment         *string
 }
 
 // https://docs.snowflake.com/en/sql-reference/sql/alter-compute-pool
 func (c *computepools) Alter(ctx context.Context, id sdk.AccountObjectIdentifier, opts *AlterComputePoolOptions) error {
-	alterTemplate := fmt.Sprintf(`
-	ALTER COMPUTE POOL {{ if .IfExists }}IF EXISTS{{ end }} %s
-		SET
-		{{ if .MinNodes }}MIN NODES = {{ .MinNodes }}{{ end }}
-		{{ if .MaxNodes }}MAX NODES = {{ .MaxNodes }}{{ end }}
-		{{ if .AutoResume }}AUTO RESUME = {{ .AutoResume }}{{ end }}
-		{{ if .AutoSuspendSecs }}AUTO SUSPEND = {{ .AutoSuspendSecs }}{{ end }}
-		{{ if .Comment }}COMMENT = '{{ .Comment }}'{{ end }};
-	`, id.FullyQualifiedName())
-	stmt := templateToQuery(alterTemplate, opts)
-
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	if opts == nil {
+		opts = &AlterComputePoolOptions{}
+	}
+
+	query, err := newStatement("ALTER", "COMPUTE POOL").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		setProperties().
+		intProperty("MIN_NODES", opts.MinNodes).
+		intProperty("MAX_NODES", opts.MaxNodes).
+		boolProperty("AUTO_RESUME", opts.AutoResume).
+		intProperty("AUTO_SUSPEND_SECS", opts.AutoSuspendSecs).
+		stringProperty("COMMENT", opts.Comment).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
 
@@ -190,9 +223,22 @@ type AlterComputePoolStateOptions struct {
 
 // https://docs.snowflake.com/en/sql-reference/sql/alter-compute-pool
 func (c *computepools) AlterState(ctx context.Context, id sdk.AccountObjectIdentifier, opts *AlterComputePoolStateOptions) error {
-	alterTemplate := fmt.Sprintf("ALTER COMPUTE POOL {{ if .IfExists }}IF EXISTS{{ end }} %s {{ .StateAction }};", id.FullyQualifiedName())
-	stmt := templateToQuery(alterTemplate, opts)
+	if opts == nil {
+		opts = &AlterComputePoolStateOptions{}
+	}
+	if !keywordValue.MatchString(string(opts.StateAction)) {
+		return fmt.Errorf("invalid compute pool state action %q", opts.StateAction)
+	}
+
+	query, err := newStatement("ALTER", "COMPUTE POOL").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		keyword(string(opts.StateAction)).
+		build()
+	if err != nil {
+		return err
+	}
 
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
@@ -3,207 +3,217 @@ package configuration
 import (
 	"cmp"
 	"fmt"
+	"net/url"
 	"os"
-	"os/user"
-	"path/filepath"
-	"runtime"
 	"strings"
 
-	"github.com/BurntSushi/toml"
 	sf "github.com/snowflakedb/gosnowflake"
 )
 
-type ConnectionsToml struct {
-	Connections map[string]Connection `toml:"connections"`
-}
-
 type Connection struct {
 	Account       string `toml:"account"`
 	User          string `toml:"user"`
 	Password      string `toml:"password"`
 	Region        string `toml:"region"`
 	Host          string `toml:"host"`
+	Port          int    `toml:"port"`
+	Protocol      string `toml:"protocol"`
 	Database      string `toml:"database"`
 	Schema        string `toml:"schema"`
 	Role          string `toml:"role"`
 	Warehouse     string `toml:"warehouse"`
 	Authenticator string `toml:"authenticator"`
+
+	// PrivateKeyFile, or PrivateKeyPath as the snowflake cli names it, is
+	// the PEM key of SNOWFLAKE_JWT authentication and PrivateKeyRaw the key
+	// itself, PrivateKeyFilePwd decrypts an encrypted key
+	PrivateKeyFile    string `toml:"private_key_file"`
+	PrivateKeyPath    string `toml:"private_key_path"`
+	PrivateKeyRaw     string `toml:"private_key_raw"`
+	PrivateKeyFilePwd string `toml:"private_key_file_pwd"`
+
+	// Token is the OAuth or programmatic access token, read from
+	// TokenFilePath when empty
+	Token         string `toml:"token"`
+	TokenFilePath string `toml:"token_file_path"`
+
+	// Passcode is the MFA code of username_password_mfa, or part of the
+	// password with PasscodeInPassword
+	Passcode           string `toml:"passcode"`
+	PasscodeInPassword bool   `toml:"passcode_in_password"`
+
+	// Name is the name of the connection in its file and Path the file it
+	// was read from, empty for the SNOWFLAKE_CONNECTIONS variable
+	Name string `toml:"-"`
+	Path string `toml:"-"`
+
+	// Sources maps the keys set on the connection to the file or the
+	// environment variable they were read from
+	Sources map[string]string `toml:"-"`
 }
 
-type ConfigToml struct {
-	DefaultConnectionName string `toml:"default_connection_name"`
+// EditableKeys are the keys of a connection edited from the connections
+// view, in the order they are written to new connections
+var EditableKeys = []string{
+	"account",
+	"user",
+	"password",
+	"authenticator",
+	"role",
+	"warehouse",
+	"database",
+	"schema",
+	"region",
+	"host",
+	"private_key_file",
+	"private_key_file_pwd",
+	"token_file_path",
 }
 
-func (c *Connection) SnowflakeConfig() *sf.Config {
-	var authType sf.AuthType
-	switch c.Authenticator {
-	case "externalbrowser":
-		authType = sf.AuthTypeExternalBrowser
-	default:
-		authType = sf.AuthTypeSnowflake
-	}
+type stringField struct {
+	key   string
+	value *string
+}
 
-	return &sf.Config{
-		Account:       c.Account,
-		User:          c.User,
-		Password:      c.Password,
-		Region:        c.Region,
-		Host:          c.Host,
-		Database:      c.Database,
-		Schema:        c.Schema,
-		Role:          c.Role,
-		Warehouse:     c.Warehouse,
-		Authenticator: authType,
+// stringFields returns the string keys of the connection with their values
+func (c *Connection) stringFields() []stringField {
+	return []stringField{
+		{"account", &c.Account},
+		{"user", &c.User},
+		{"password", &c.Password},
+		{"region", &c.Region},
+		{"host", &c.Host},
+		{"protocol", &c.Protocol},
+		{"database", &c.Database},
+		{"schema", &c.Schema},
+		{"role", &c.Role},
+		{"warehouse", &c.Warehouse},
+		{"authenticator", &c.Authenticator},
+		{"private_key_file", &c.PrivateKeyFile},
+		{"private_key_path", &c.PrivateKeyPath},
+		{"private_key_raw", &c.PrivateKeyRaw},
+		{"private_key_file_pwd", &c.PrivateKeyFilePwd},
+		{"token", &c.Token},
+		{"token_file_path", &c.TokenFilePath},
+		{"passcode", &c.Passcode},
 	}
 }
 
-func getConfigurationDirectories() []string {
-	paths := make([]string, 0)
-
-	if os.Getenv("SNOWFLAKE_HOME") == "" {
-		paths = append(paths, os.Getenv("SNOWFLAKE_HOME"))
+// Value returns the value of a string key of the connection
+func (c *Connection) Value(key string) string {
+	for _, field := range c.stringFields() {
+		if field.key == key {
+			return *field.value
+		}
 	}
+	return ""
+}
 
-	usr, _ := user.Current()
-	homedir := usr.HomeDir
-
-	switch os := runtime.GOOS; os {
-	case "darwin":
-		paths = append(
-			paths,
-			filepath.Join(homedir, "Library/Application Support/snowflake"),
-		)
-	case "windows":
-		paths = append(
-			paths,
-			"%USERPROFILE%\\AppData\\Local\\snowflake",
-		)
-	default: // assume linux like
-		paths = append(
-			paths,
-			filepath.Join(homedir, ".config/snowflake"),
-			filepath.Join(homedir, ".snowflake"),
-		)
+// Settings returns the configuration of the connection without reading its
+// key or token files nor checking its authenticator, as listed in the
+// connections view
+func (c *Connection) Settings() *sf.Config {
+	return &sf.Config{
+		Account:            c.Account,
+		User:               c.User,
+		Password:           c.Password,
+		Region:             c.Region,
+		Host:               c.Host,
+		Port:               c.Port,
+		Protocol:           c.Protocol,
+		Database:           c.Database,
+		Schema:             c.Schema,
+		Role:               c.Role,
+		Warehouse:          c.Warehouse,
+		Passcode:           c.Passcode,
+		PasscodeInPassword: c.PasscodeInPassword,
 	}
-
-	return paths
 }
 
-func readConnectionsToml(directory string) (*ConnectionsToml, error) {
-	path := filepath.Join(directory, "connections.toml")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("reading %s file %w", path, err)
+// SnowflakeConfig returns the configuration of the connection with the
+// credentials of its authenticator, reading its private key or token file
+func (c *Connection) SnowflakeConfig() (*sf.Config, error) {
+	config := c.Settings()
+
+	authenticator := c.AuthenticatorName()
+	privateKeyFile := cmp.Or(c.PrivateKeyFile, c.PrivateKeyPath)
+
+	switch {
+	case authenticator == "snowflake":
+		config.Authenticator = sf.AuthTypeSnowflake
+	case authenticator == "externalbrowser":
+		config.Authenticator = sf.AuthTypeExternalBrowser
+	case authenticator == "username_password_mfa":
+		config.Authenticator = sf.AuthTypeUsernamePasswordMFA
+	case authenticator == "snowflake_jwt":
+		config.Authenticator = sf.AuthTypeJwt
+		var err error
+		switch {
+		case c.PrivateKeyRaw != "":
+			config.PrivateKey, err = ParsePrivateKey([]byte(c.PrivateKeyRaw), c.PrivateKeyFilePwd)
+		case privateKeyFile != "":
+			config.PrivateKey, err = LoadPrivateKey(privateKeyFile, c.PrivateKeyFilePwd)
+		default:
+			err = fmt.Errorf("snowflake_jwt authenticator needs private_key_file or private_key_raw")
+		}
+		if err != nil {
+			return nil, err
+		}
+	case authenticator == "oauth" || authenticator == "programmatic_access_token":
+		token, err := c.token()
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			return nil, fmt.Errorf("%s authenticator needs token or token_file_path", authenticator)
+		}
+		if authenticator == "oauth" {
+			config.Authenticator = sf.AuthTypeOAuth
+			config.Token = token
+		} else {
+			// programmatic access tokens are sent in place of the password
+			config.Authenticator = sf.AuthTypeSnowflake
+			config.Password = token
+		}
+	case strings.HasPrefix(authenticator, "https://"):
+		oktaURL, err := url.Parse(c.Authenticator)
+		if err != nil {
+			return nil, fmt.Errorf("parsing okta authenticator %w", err)
+		}
+		config.Authenticator = sf.AuthTypeOkta
+		config.OktaURL = oktaURL
+	default:
+		return nil, fmt.Errorf("unknown authenticator %s, expected snowflake, externalbrowser, snowflake_jwt, oauth, programmatic_access_token, username_password_mfa or an okta URL", c.Authenticator)
 	}
 
-	var connectionsToml ConnectionsToml
-	_, err = toml.Decode(string(data), &connectionsToml)
-	if err != nil {
-		return nil, fmt.Errorf("decoding %s from toml %w", path, err)
-	}
+	return config, nil
+}
 
-	for name, connection := range connectionsToml.Connections {
-		connection.Account = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_ACCOUNT", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_ACCOUNT"),
-			connection.Account,
-		)
-		connection.User = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_USER", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_USER"),
-			connection.User,
-		)
-		connection.Password = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_PASSWORD", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_PASSWORD"),
-			connection.Password,
-		)
-		connection.Region = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_REGION", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_REGION"),
-			connection.Region,
-		)
-		connection.Host = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_HOST", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_HOST"),
-			connection.Host,
-		)
-		connection.Database = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_DATABASE", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_DATABASE"),
-			connection.Database,
-		)
-		connection.Schema = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_SCHEMA", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_SCHEMA"),
-			connection.Schema,
-		)
-		connection.Role = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_ROLE", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_ROLE"),
-			connection.Role,
-		)
-		connection.Warehouse = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_WAREHOUSE", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_WAREHOUSE"),
-			connection.Warehouse,
-		)
-		connection.Authenticator = cmp.Or(
-			os.Getenv(fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_AUTHENTICATOR", strings.ToUpper(name))),
-			os.Getenv("SNOWFLAKE_AUTHENTICATOR"),
-			connection.Authenticator,
-		)
+// AuthenticatorName returns the authenticator in lower case, snowflake_jwt
+// for a connection with a private key and snowflake when unset
+func (c *Connection) AuthenticatorName() string {
+	authenticator := strings.ToLower(strings.TrimSpace(c.Authenticator))
+	if authenticator == "" && (c.PrivateKeyFile != "" || c.PrivateKeyPath != "" || c.PrivateKeyRaw != "") {
+		return "snowflake_jwt"
 	}
+	return cmp.Or(authenticator, "snowflake")
+}
 
-	return &connectionsToml, nil
+// File returns the file the connection was read from or the
+// SNOWFLAKE_CONNECTIONS variable
+func (c *Connection) File() string {
+	return cmp.Or(c.Path, "SNOWFLAKE_CONNECTIONS")
 }
 
-func readConfigToml(directory string) (*ConfigToml, error) {
-	path := filepath.Join(directory, "config.toml")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("reading %s file %w", path, err)
+// token returns the token of the connection, read from its token file
+// unless given
+func (c *Connection) token() (string, error) {
+	if c.Token != "" || c.TokenFilePath == "" {
+		return c.Token, nil
 	}
-
-	var configToml ConfigToml
-	_, err = toml.Decode(string(data), &configToml)
+	data, err := os.ReadFile(ExpandHome(c.TokenFilePath))
 	if err != nil {
-		return nil, fmt.Errorf("decoding %s from toml %w", path, err)
-	}
-
-	configToml.DefaultConnectionName = cmp.Or(
-		configToml.DefaultConnectionName,
-		os.Getenv("SNOWFLAKE_DEFAULT_CONNECTION_NAME"),
-	)
-
-	return &configToml, nil
-}
-
-func ReadConfig() (map[string]*Connection, error) {
-	paths := getConfigurationDirectories()
-
-	var connections = make(map[string]*Connection, 0)
-
-	for _, path := range paths {
-		if stat, err := os.Stat(path); err == nil && stat.IsDir() {
-			configToml, err := readConfigToml(path)
-			if err != nil {
-				return nil, err
-			}
-
-			connectionsToml, err := readConnectionsToml(path)
-			if err != nil {
-				return nil, err
-			}
-
-			for name, connection := range connectionsToml.Connections {
-				if name == configToml.DefaultConnectionName {
-					connections["default"] = &connection
-				}
-				connections[name] = &connection
-			}
-			break
-		}
+		return "", fmt.Errorf("reading token file %w", err)
 	}
-	return connections, nil
+	return strings.TrimSpace(string(data)), nil
 }
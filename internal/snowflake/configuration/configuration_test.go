@@ -0,0 +1,106 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	sf "github.com/snowflakedb/gosnowflake"
+)
+
+func TestSnowflakeConfig(t *testing.T) {
+	key := testKey(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "rsa_key.p8"), encodeKey(t, key, "PRIVATE KEY", ""), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	encrypted := writeKey(t, encodeKey(t, key, "ENCRYPTED PRIVATE KEY", "secret"))
+	tokenFile := filepath.Join(home, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		connection    Connection
+		authenticator sf.AuthType
+		password      string
+		token         string
+		privateKey    bool
+		oktaURL       string
+		invalid       bool
+	}{
+		{name: "default", connection: Connection{Password: "pw"}, authenticator: sf.AuthTypeSnowflake, password: "pw"},
+		{name: "snowflake", connection: Connection{Authenticator: "SNOWFLAKE", Password: "pw"}, authenticator: sf.AuthTypeSnowflake, password: "pw"},
+		{name: "externalbrowser", connection: Connection{Authenticator: "externalbrowser"}, authenticator: sf.AuthTypeExternalBrowser},
+		{name: "username_password_mfa", connection: Connection{Authenticator: "USERNAME_PASSWORD_MFA", Password: "pw"}, authenticator: sf.AuthTypeUsernamePasswordMFA, password: "pw"},
+		{name: "jwt raw key", connection: Connection{Authenticator: "snowflake_jwt", PrivateKeyRaw: string(encodeKey(t, key, "RSA PRIVATE KEY", ""))}, authenticator: sf.AuthTypeJwt, privateKey: true},
+		{name: "jwt key file", connection: Connection{Authenticator: "SNOWFLAKE_JWT", PrivateKeyFile: "~/rsa_key.p8"}, authenticator: sf.AuthTypeJwt, privateKey: true},
+		{name: "jwt encrypted key file", connection: Connection{PrivateKeyFile: encrypted, PrivateKeyFilePwd: "secret"}, authenticator: sf.AuthTypeJwt, privateKey: true},
+		{name: "jwt key path", connection: Connection{PrivateKeyPath: filepath.Join(home, "rsa_key.p8")}, authenticator: sf.AuthTypeJwt, privateKey: true},
+		{name: "jwt without key", connection: Connection{Authenticator: "snowflake_jwt"}, invalid: true},
+		{name: "jwt missing key file", connection: Connection{PrivateKeyFile: filepath.Join(home, "missing.p8")}, invalid: true},
+		{name: "jwt without passphrase", connection: Connection{PrivateKeyFile: encrypted}, invalid: true},
+		{name: "oauth", connection: Connection{Authenticator: "oauth", Token: "token"}, authenticator: sf.AuthTypeOAuth, token: "token"},
+		{name: "oauth token file", connection: Connection{Authenticator: "oauth", TokenFilePath: tokenFile}, authenticator: sf.AuthTypeOAuth, token: "file-token"},
+		{name: "oauth token over file", connection: Connection{Authenticator: "oauth", Token: "token", TokenFilePath: filepath.Join(home, "missing")}, authenticator: sf.AuthTypeOAuth, token: "token"},
+		{name: "oauth missing token file", connection: Connection{Authenticator: "oauth", TokenFilePath: filepath.Join(home, "missing")}, invalid: true},
+		{name: "oauth without token", connection: Connection{Authenticator: "oauth"}, invalid: true},
+		{name: "programmatic_access_token", connection: Connection{Authenticator: "programmatic_access_token", Password: "pw", TokenFilePath: "~/token"}, authenticator: sf.AuthTypeSnowflake, password: "file-token"},
+		{name: "programmatic_access_token without token", connection: Connection{Authenticator: "programmatic_access_token"}, invalid: true},
+		{name: "okta", connection: Connection{Authenticator: "https://example.okta.com"}, authenticator: sf.AuthTypeOkta, oktaURL: "https://example.okta.com"},
+		{name: "okta invalid url", connection: Connection{Authenticator: "https://example.okta.com/%zz"}, invalid: true},
+		{name: "unknown", connection: Connection{Authenticator: "kerberos"}, invalid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := test.connection.SnowflakeConfig()
+			if test.invalid {
+				if err == nil {
+					t.Error("configured an invalid connection")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Authenticator != test.authenticator {
+				t.Errorf("got authenticator %s, want %s", config.Authenticator, test.authenticator)
+			}
+			if config.Password != test.password || config.Token != test.token {
+				t.Errorf("got password %q token %q", config.Password, config.Token)
+			}
+			if test.privateKey != (config.PrivateKey != nil) {
+				t.Errorf("got private key %t, want %t", config.PrivateKey != nil, test.privateKey)
+			} else if test.privateKey && !config.PrivateKey.Equal(key) {
+				t.Error("got another private key")
+			}
+			var oktaURL string
+			if config.OktaURL != nil {
+				oktaURL = config.OktaURL.String()
+			}
+			if oktaURL != test.oktaURL {
+				t.Errorf("got okta url %q, want %q", oktaURL, test.oktaURL)
+			}
+		})
+	}
+}
+
+func TestSettings(t *testing.T) {
+	connection := Connection{
+		Account:            "xy12345",
+		User:               "alice",
+		Port:               8443,
+		Role:               "R",
+		Warehouse:          "WH",
+		Authenticator:      "snowflake_jwt",
+		PrivateKeyFile:     "missing.p8",
+		PasscodeInPassword: true,
+	}
+	// the settings are listed without reading the key
+	settings := connection.Settings()
+	if settings.Account != "xy12345" || settings.User != "alice" || settings.Port != 8443 || settings.Role != "R" || settings.Warehouse != "WH" || !settings.PasscodeInPassword {
+		t.Errorf("got settings %+v", settings)
+	}
+}
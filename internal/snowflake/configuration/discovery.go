@@ -0,0 +1,296 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config is the configuration merged from every configuration directory and
+// the environment
+type Config struct {
+	Connections map[string]*Connection
+
+	DefaultConnectionName string
+	// DefaultConnectionSource is the file or the environment variable the
+	// default connection name was read from
+	DefaultConnectionSource string
+
+	// Files are the files searched, lowest precedence first
+	Files []File
+}
+
+// File is a configuration file searched, missing ones are skipped
+type File struct {
+	Path  string
+	Found bool
+}
+
+// Discover reads the config.toml and connections.toml files of every
+// configuration directory, later directories and connections.toml taking
+// precedence, then the SNOWFLAKE_CONNECTIONS toml document and the
+// environment overrides of each connection, the SNOWFLAKE_<KEY> ones of the
+// default connection only
+func Discover() (*Config, error) {
+	config := &Config{Connections: map[string]*Connection{}}
+
+	for _, directory := range getConfigurationDirectories() {
+		path := filepath.Join(directory, "config.toml")
+		data, found, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		config.Files = append(config.Files, File{Path: path, Found: found})
+		if found {
+			if err := config.readConfigToml(data, path); err != nil {
+				return nil, err
+			}
+		}
+
+		path = filepath.Join(directory, "connections.toml")
+		data, found, err = readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		config.Files = append(config.Files, File{Path: path, Found: found})
+		if found {
+			if err := config.readConnectionsToml(data, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if data := os.Getenv("SNOWFLAKE_CONNECTIONS"); data != "" {
+		if err := config.readConnectionsToml([]byte(data), "SNOWFLAKE_CONNECTIONS"); err != nil {
+			return nil, err
+		}
+	}
+
+	if name := os.Getenv("SNOWFLAKE_DEFAULT_CONNECTION_NAME"); name != "" {
+		config.DefaultConnectionName = name
+		config.DefaultConnectionSource = "SNOWFLAKE_DEFAULT_CONNECTION_NAME"
+	}
+
+	// the SNOWFLAKE_<KEY> variables set the keys of the default connection,
+	// Active applies them to another one when it is selected
+	for name, connection := range config.Connections {
+		if err := connection.applyEnvironment(name == config.DefaultConnectionName); err != nil {
+			return nil, fmt.Errorf("configuring connection %s %w", name, err)
+		}
+	}
+
+	return config, nil
+}
+
+// ReadConfig returns the connections by name, the default connection also
+// listed as default
+func ReadConfig() (map[string]*Connection, error) {
+	config, err := Discover()
+	if err != nil {
+		return nil, err
+	}
+
+	connections := maps.Clone(config.Connections)
+	if connection := config.Connections[config.DefaultConnectionName]; connection != nil {
+		connections["default"] = connection
+	}
+	return connections, nil
+}
+
+// getConfigurationDirectories returns the directories searched, lowest
+// precedence first, the platform directory, ~/.snowflake and SNOWFLAKE_HOME
+func getConfigurationDirectories() []string {
+	paths := make([]string, 0)
+
+	switch runtime.GOOS {
+	case "windows":
+		if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+			paths = append(paths, filepath.Join(localAppData, "snowflake"))
+		}
+	default:
+		// ~/Library/Application Support on darwin and XDG_CONFIG_HOME or
+		// ~/.config otherwise
+		if configDir, err := os.UserConfigDir(); err == nil {
+			paths = append(paths, filepath.Join(configDir, "snowflake"))
+		}
+	}
+
+	if homedir, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(homedir, ".snowflake"))
+	}
+
+	if snowflakeHome := os.Getenv("SNOWFLAKE_HOME"); snowflakeHome != "" {
+		paths = append(paths, ExpandHome(snowflakeHome))
+	}
+
+	// SNOWFLAKE_HOME may name one of the default directories, keep its
+	// last position
+	unique := make([]string, 0, len(paths))
+	for i, path := range paths {
+		if !slices.Contains(paths[i+1:], path) {
+			unique = append(unique, path)
+		}
+	}
+	return unique
+}
+
+// readFile reads the file, found is false when it does not exist
+func readFile(path string) ([]byte, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading %s file %w", path, err)
+	}
+	return data, true, nil
+}
+
+// readConfigToml reads the default connection name and the connections of
+// the [connections] table of a config.toml file
+func (c *Config) readConfigToml(data []byte, path string) error {
+	var configToml struct {
+		DefaultConnectionName string                `toml:"default_connection_name"`
+		Connections           map[string]Connection `toml:"connections"`
+	}
+	metadata, err := toml.Decode(string(data), &configToml)
+	if err != nil {
+		return fmt.Errorf("decoding %s from toml %w", path, err)
+	}
+
+	if metadata.IsDefined("default_connection_name") {
+		c.DefaultConnectionName = configToml.DefaultConnectionName
+		c.DefaultConnectionSource = path
+	}
+	c.merge(configToml.Connections, metadata, []string{"connections"}, path)
+	return nil
+}
+
+// readConnectionsToml reads a connections.toml document, with a table per
+// connection as the snowflake cli writes them or under [connections.NAME]
+// as in config.toml
+func (c *Config) readConnectionsToml(data []byte, source string) error {
+	var tables map[string]toml.Primitive
+	metadata, err := toml.Decode(string(data), &tables)
+	if err != nil {
+		return fmt.Errorf("decoding %s from toml %w", source, err)
+	}
+
+	for name, table := range tables {
+		switch name {
+		case "default_connection_name":
+			if err := metadata.PrimitiveDecode(table, &c.DefaultConnectionName); err != nil {
+				return fmt.Errorf("decoding %s default_connection_name %w", source, err)
+			}
+			c.DefaultConnectionSource = source
+		case "connections":
+			var connections map[string]Connection
+			if err := metadata.PrimitiveDecode(table, &connections); err != nil {
+				return fmt.Errorf("decoding %s connections %w", source, err)
+			}
+			c.merge(connections, metadata, []string{"connections"}, source)
+		default:
+			var connection Connection
+			if err := metadata.PrimitiveDecode(table, &connection); err != nil {
+				return fmt.Errorf("decoding %s connection %s %w", source, name, err)
+			}
+			c.merge(map[string]Connection{name: connection}, metadata, nil, source)
+		}
+	}
+	return nil
+}
+
+// merge replaces the connections of the same name by the ones read from the
+// source, recording the source of the keys they set under the prefix
+func (c *Config) merge(connections map[string]Connection, metadata toml.MetaData, prefix []string, source string) {
+	path := source
+	if source == "SNOWFLAKE_CONNECTIONS" {
+		path = ""
+	}
+
+	for name, connection := range connections {
+		connection.Name = name
+		connection.Path = path
+		connection.Sources = map[string]string{}
+		for _, key := range metadata.Keys() {
+			if len(key) == len(prefix)+2 && slices.Equal(key[:len(prefix)], prefix) && key[len(prefix)] == name {
+				connection.Sources[key[len(key)-1]] = source
+			}
+		}
+		c.Connections[name] = &connection
+	}
+}
+
+// Active returns a copy of the connection with the SNOWFLAKE_<KEY> variables
+// applied, they set the keys of the connection in use only
+func (c *Connection) Active() (*Connection, error) {
+	active := *c
+	active.Sources = maps.Clone(c.Sources)
+	if active.Sources == nil {
+		active.Sources = map[string]string{}
+	}
+	if err := active.applyEnvironment(true); err != nil {
+		return nil, err
+	}
+	return &active, nil
+}
+
+// applyEnvironment overrides the keys of the connection by the
+// SNOWFLAKE_CONNECTIONS_<NAME>_<KEY> variables, then by the SNOWFLAKE_<KEY>
+// ones when generic is set
+func (c *Connection) applyEnvironment(generic bool) error {
+	for _, field := range c.stringFields() {
+		if value, variable := c.environment(field.key, generic); variable != "" {
+			*field.value = value
+			c.Sources[field.key] = variable
+		}
+	}
+
+	if value, variable := c.environment("port", generic); variable != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("parsing %s %w", variable, err)
+		}
+		c.Port = port
+		c.Sources["port"] = variable
+	}
+	if value, variable := c.environment("passcode_in_password", generic); variable != "" {
+		passcodeInPassword, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("parsing %s %w", variable, err)
+		}
+		c.PasscodeInPassword = passcodeInPassword
+		c.Sources["passcode_in_password"] = variable
+	}
+
+	// the passphrase variable of the snowflake cli
+	if generic && c.PrivateKeyFilePwd == "" && os.Getenv("PRIVATE_KEY_PASSPHRASE") != "" {
+		c.PrivateKeyFilePwd = os.Getenv("PRIVATE_KEY_PASSPHRASE")
+		c.Sources["private_key_file_pwd"] = "PRIVATE_KEY_PASSPHRASE"
+	}
+	return nil
+}
+
+// environment returns the value of the key set in the environment and the
+// variable it was read from, empty when unset
+func (c *Connection) environment(key string, generic bool) (string, string) {
+	variables := []string{fmt.Sprintf("SNOWFLAKE_CONNECTIONS_%s_%s", strings.ToUpper(c.Name), strings.ToUpper(key))}
+	if generic {
+		variables = append(variables, fmt.Sprintf("SNOWFLAKE_%s", strings.ToUpper(key)))
+	}
+	for _, variable := range variables {
+		if value := os.Getenv(variable); value != "" {
+			return value, variable
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,194 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// testDirectories points the configuration directories at temporary ones
+// and clears the variables read by Discover, it returns the platform
+// directory, ~/.snowflake and SNOWFLAKE_HOME
+func testDirectories(t *testing.T) (string, string, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("SNOWFLAKE_HOME", filepath.Join(home, "snowflake_home"))
+	for _, variable := range []string{
+		"SNOWFLAKE_CONNECTIONS",
+		"SNOWFLAKE_DEFAULT_CONNECTION_NAME",
+		"SNOWFLAKE_ACCOUNT",
+		"SNOWFLAKE_ROLE",
+		"SNOWFLAKE_WAREHOUSE",
+		"SNOWFLAKE_PORT",
+		"SNOWFLAKE_PASSCODE_IN_PASSWORD",
+		"PRIVATE_KEY_PASSPHRASE",
+	} {
+		t.Setenv(variable, "")
+	}
+	return filepath.Join(home, "config", "snowflake"), filepath.Join(home, ".snowflake"), filepath.Join(home, "snowflake_home")
+}
+
+func writeDirectoryFile(t *testing.T, directory string, name string, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(directory, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func discover(t *testing.T) *Config {
+	t.Helper()
+	config, err := Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func TestDiscoverPrecedence(t *testing.T) {
+	platform, home, snowflakeHome := testDirectories(t)
+	writeDirectoryFile(t, platform, "connections.toml", "[dev]\naccount = \"platform\"\n\n[platform]\naccount = \"p\"\n")
+	writeDirectoryFile(t, home, "config.toml", "default_connection_name = \"dev\"\n\n[connections.dev]\naccount = \"home_config\"\n\n[connections.inline]\naccount = \"i\"\nrole = \"R\"\n")
+	homeConnections := writeDirectoryFile(t, home, "connections.toml", "[dev]\naccount = \"home\"\nuser = \"alice\"\n")
+	writeDirectoryFile(t, snowflakeHome, "connections.toml", "[connections.prod]\naccount = \"prod\"\n")
+
+	config := discover(t)
+
+	var files []string
+	for _, file := range config.Files {
+		if file.Found {
+			files = append(files, file.Path)
+		}
+	}
+	want := []string{
+		filepath.Join(platform, "connections.toml"),
+		filepath.Join(home, "config.toml"),
+		homeConnections,
+		filepath.Join(snowflakeHome, "connections.toml"),
+	}
+	if !slices.Equal(files, want) {
+		t.Errorf("got files %q, want %q", files, want)
+	}
+	if len(config.Files) != 6 {
+		t.Errorf("got %d files searched, want 6", len(config.Files))
+	}
+
+	// connections.toml takes precedence over config.toml and later
+	// directories over earlier ones, whole connections are replaced
+	dev := config.Connections["dev"]
+	if dev.Account != "home" || dev.User != "alice" || dev.Path != homeConnections {
+		t.Errorf("got dev %s %s from %s", dev.Account, dev.User, dev.Path)
+	}
+	if source := dev.Sources["account"]; source != homeConnections {
+		t.Errorf("got account of dev from %s", source)
+	}
+	for name, account := range map[string]string{"platform": "p", "inline": "i", "prod": "prod"} {
+		if connection := config.Connections[name]; connection == nil || connection.Account != account {
+			t.Errorf("got connection %s %v, want account %s", name, connection, account)
+		}
+	}
+	if role := config.Connections["inline"].Role; role != "R" {
+		t.Errorf("got inline role %s", role)
+	}
+	if config.DefaultConnectionName != "dev" || config.DefaultConnectionSource != filepath.Join(home, "config.toml") {
+		t.Errorf("got default connection %s from %s", config.DefaultConnectionName, config.DefaultConnectionSource)
+	}
+}
+
+func TestDiscoverConnectionsVariable(t *testing.T) {
+	_, home, _ := testDirectories(t)
+	writeDirectoryFile(t, home, "connections.toml", "default_connection_name = \"dev\"\n\n[dev]\naccount = \"home\"\n")
+	t.Setenv("SNOWFLAKE_CONNECTIONS", "[dev]\naccount = \"variable\"\n\n[connections.ci]\naccount = \"ci\"\n")
+	t.Setenv("SNOWFLAKE_DEFAULT_CONNECTION_NAME", "ci")
+
+	config := discover(t)
+
+	dev := config.Connections["dev"]
+	if dev.Account != "variable" || dev.Path != "" || dev.File() != "SNOWFLAKE_CONNECTIONS" {
+		t.Errorf("got dev %s from %s", dev.Account, dev.File())
+	}
+	if source := dev.Sources["account"]; source != "SNOWFLAKE_CONNECTIONS" {
+		t.Errorf("got account of dev from %s", source)
+	}
+	if ci := config.Connections["ci"]; ci == nil || ci.Account != "ci" {
+		t.Errorf("got ci %v", ci)
+	}
+	if config.DefaultConnectionName != "ci" || config.DefaultConnectionSource != "SNOWFLAKE_DEFAULT_CONNECTION_NAME" {
+		t.Errorf("got default connection %s from %s", config.DefaultConnectionName, config.DefaultConnectionSource)
+	}
+
+	connections, err := ReadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connections["default"] != connections["ci"] {
+		t.Error("the default connection is not listed as default")
+	}
+}
+
+func TestDiscoverEnvironment(t *testing.T) {
+	_, home, _ := testDirectories(t)
+	path := writeDirectoryFile(t, home, "connections.toml", "default_connection_name = \"dev\"\n\n[dev]\naccount = \"dev\"\nrole = \"R\"\n\n[prod]\naccount = \"prod\"\nport = 443\n")
+	t.Setenv("SNOWFLAKE_CONNECTIONS_PROD_ROLE", "ADMIN")
+	t.Setenv("SNOWFLAKE_CONNECTIONS_DEV_ROLE", "DEV_ROLE")
+	t.Setenv("SNOWFLAKE_ROLE", "GENERIC")
+	t.Setenv("SNOWFLAKE_WAREHOUSE", "WH")
+	t.Setenv("SNOWFLAKE_PORT", "8443")
+	t.Setenv("SNOWFLAKE_PASSCODE_IN_PASSWORD", "true")
+
+	config := discover(t)
+
+	// the variables of a connection take precedence over the generic ones,
+	// which only set the keys of the default connection
+	dev := config.Connections["dev"]
+	if dev.Role != "DEV_ROLE" || dev.Sources["role"] != "SNOWFLAKE_CONNECTIONS_DEV_ROLE" {
+		t.Errorf("got dev role %s from %s", dev.Role, dev.Sources["role"])
+	}
+	if dev.Warehouse != "WH" || dev.Port != 8443 || !dev.PasscodeInPassword {
+		t.Errorf("got dev warehouse %s port %d passcode in password %t", dev.Warehouse, dev.Port, dev.PasscodeInPassword)
+	}
+	if dev.Sources["port"] != "SNOWFLAKE_PORT" || dev.Sources["account"] != path {
+		t.Errorf("got dev sources %v", dev.Sources)
+	}
+
+	prod := config.Connections["prod"]
+	if prod.Role != "ADMIN" || prod.Warehouse != "" || prod.Port != 443 || prod.PasscodeInPassword {
+		t.Errorf("got prod role %s warehouse %s port %d passcode in password %t", prod.Role, prod.Warehouse, prod.Port, prod.PasscodeInPassword)
+	}
+
+	// selecting prod applies the generic variables to it
+	active, err := prod.Active()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active.Role != "ADMIN" || active.Warehouse != "WH" || active.Port != 8443 || active.Sources["warehouse"] != "SNOWFLAKE_WAREHOUSE" {
+		t.Errorf("got active prod role %s warehouse %s port %d", active.Role, active.Warehouse, active.Port)
+	}
+	if prod.Warehouse != "" || prod.Sources["warehouse"] != "" {
+		t.Error("Active changed the discovered connection")
+	}
+}
+
+func TestDiscoverInvalidEnvironment(t *testing.T) {
+	_, home, _ := testDirectories(t)
+	writeDirectoryFile(t, home, "connections.toml", "[dev]\naccount = \"dev\"\n")
+
+	t.Setenv("SNOWFLAKE_CONNECTIONS_DEV_PORT", "https")
+	if _, err := Discover(); err == nil {
+		t.Error("discovered a connection with an invalid port")
+	}
+
+	t.Setenv("SNOWFLAKE_CONNECTIONS_DEV_PORT", "")
+	t.Setenv("SNOWFLAKE_PASSCODE_IN_PASSWORD", "maybe")
+	config := discover(t)
+	if _, err := config.Connections["dev"].Active(); err == nil {
+		t.Error("activated a connection with an invalid passcode_in_password")
+	}
+}
@@ -0,0 +1,75 @@
+package configuration
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/youmark/pkcs8"
+)
+
+// LoadPrivateKey reads the PEM private key of key pair authentication,
+// decrypting it with the passphrase when it is encrypted, a leading ~ of
+// the path is expanded
+func LoadPrivateKey(path string, passphrase string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(ExpandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading private key %w", err)
+	}
+
+	key, err := ParsePrivateKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key %s %w", path, err)
+	}
+	return key, nil
+}
+
+// ParsePrivateKey parses a PEM RSA private key in PKCS #8, encrypted or not,
+// or PKCS #1 form
+func ParsePrivateKey(data []byte, passphrase string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "ENCRYPTED PRIVATE KEY":
+		if passphrase == "" {
+			return nil, fmt.Errorf("key is encrypted and no passphrase was given, set private_key_file_pwd or PRIVATE_KEY_PASSPHRASE")
+		}
+		key, err = pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(passphrase))
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA key got %T", key)
+	}
+	return rsaKey, nil
+}
+
+// ExpandHome replaces a leading ~ of the path by the home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
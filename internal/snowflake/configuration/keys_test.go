@@ -0,0 +1,111 @@
+package configuration
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/youmark/pkcs8"
+)
+
+// testKey generates the RSA key written to the PEM files of the tests
+func testKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// encodeKey returns the key as a PEM block of the type, encrypted with the
+// passphrase for ENCRYPTED PRIVATE KEY
+func encodeKey(t *testing.T, key *rsa.PrivateKey, blockType string, passphrase string) []byte {
+	t.Helper()
+	var der []byte
+	var err error
+	switch blockType {
+	case "RSA PRIVATE KEY":
+		der = x509.MarshalPKCS1PrivateKey(key)
+	case "PRIVATE KEY":
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	case "ENCRYPTED PRIVATE KEY":
+		der, err = pkcs8.MarshalPrivateKey(key, []byte(passphrase), nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+// writeKey writes the PEM key to a file of a temporary directory
+func writeKey(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rsa_key.p8")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := testKey(t)
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		valid      bool
+	}{
+		{"pkcs8", encodeKey(t, key, "PRIVATE KEY", ""), "", true},
+		{"pkcs8 with passphrase", encodeKey(t, key, "PRIVATE KEY", ""), "ignored", true},
+		{"encrypted pkcs8", encodeKey(t, key, "ENCRYPTED PRIVATE KEY", "secret"), "secret", true},
+		{"encrypted pkcs8 without passphrase", encodeKey(t, key, "ENCRYPTED PRIVATE KEY", "secret"), "", false},
+		{"encrypted pkcs8 with wrong passphrase", encodeKey(t, key, "ENCRYPTED PRIVATE KEY", "secret"), "wrong", false},
+		{"pkcs1", encodeKey(t, key, "RSA PRIVATE KEY", ""), "", true},
+		{"not pem", []byte("MIIEvQIBADANBg"), "", false},
+		{"certificate", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{0}}), "", false},
+		{"corrupt", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{0}}), "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, err := ParsePrivateKey(test.data, test.passphrase)
+			if !test.valid {
+				if err == nil {
+					t.Error("parsed an invalid key")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsed.Equal(key) {
+				t.Error("parsed another key")
+			}
+		})
+	}
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	key := testKey(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "rsa_key.p8"), encodeKey(t, key, "ENCRYPTED PRIVATE KEY", "secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := LoadPrivateKey("~/rsa_key.p8", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(key) {
+		t.Error("loaded another key")
+	}
+
+	if _, err := LoadPrivateKey(filepath.Join(home, "missing.p8"), ""); err == nil {
+		t.Error("loaded a missing key")
+	}
+}
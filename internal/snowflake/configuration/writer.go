@@ -0,0 +1,348 @@
+package configuration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var (
+	tomlHeader = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*(#.*)?$`)
+	// tomlArrayHeader is the header of a table of an array of tables, it
+	// ends the table before it as a table header does
+	tomlArrayHeader = regexp.MustCompile(`^\s*\[\[([^\[\]]+)\]\]\s*(#.*)?$`)
+	tomlKey         = regexp.MustCompile(`^\s*("[^"]*"|'[^']*'|[A-Za-z0-9_-]+)\s*=\s*(.*)$`)
+	tomlBareKey     = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// ConnectionsPath returns the connections.toml file new connections are
+// written to, in SNOWFLAKE_HOME when set then ~/.snowflake when it exists as
+// the snowflake connector picks them
+func ConnectionsPath() (string, error) {
+	if snowflakeHome := os.Getenv("SNOWFLAKE_HOME"); snowflakeHome != "" {
+		return filepath.Join(ExpandHome(snowflakeHome), "connections.toml"), nil
+	}
+
+	directories := getConfigurationDirectories()
+	if len(directories) == 0 {
+		return "", fmt.Errorf("no snowflake configuration directory")
+	}
+	for _, directory := range directories {
+		if filepath.Base(directory) == ".snowflake" {
+			if stat, err := os.Stat(directory); err == nil && stat.IsDir() {
+				return filepath.Join(directory, "connections.toml"), nil
+			}
+		}
+	}
+	return filepath.Join(directories[0], "connections.toml"), nil
+}
+
+// SaveConnection sets the keys of the connection in the toml file, an empty
+// value removes the key and the table is added when missing. The other
+// lines of the file are kept as they are.
+func SaveConnection(path string, name string, values map[string]string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+
+	start, end, found := findConnectionTable(lines, name)
+	if !found {
+		if err := checkNotDefined(lines, name); err != nil {
+			return fmt.Errorf("%s %w", path, err)
+		}
+
+		table := []string{"[" + connectionTableName(path, lines, name) + "]"}
+		for _, key := range sortedKeys(values) {
+			if values[key] == "" {
+				continue
+			}
+			table = append(table, key+" = "+tomlString(values[key]))
+		}
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		return WriteFile(path, joinLines(append(lines, table...)))
+	}
+
+	block := make([]string, 0, end-start)
+	written := map[string]bool{}
+	// last is the index in block after the last key of the table, new keys
+	// are written there rather than after the comments before the next table
+	last := 1
+	for i := start; i < end; i++ {
+		key, valueEnd, ok := tomlKeyAt(lines, i, end)
+		if !ok || i == start {
+			block = append(block, lines[i])
+			continue
+		}
+
+		if value, edited := values[key]; edited {
+			written[key] = true
+			if value != "" {
+				block = append(block, key+" = "+tomlString(value))
+			}
+		} else {
+			block = append(block, lines[i:valueEnd+1]...)
+		}
+		last = len(block)
+		i = valueEnd
+	}
+
+	added := make([]string, 0)
+	for _, key := range sortedKeys(values) {
+		if !written[key] && values[key] != "" {
+			added = append(added, key+" = "+tomlString(values[key]))
+		}
+	}
+	block = slices.Insert(block, last, added...)
+
+	return WriteFile(path, joinLines(slices.Concat(lines[:start], block, lines[end:])))
+}
+
+// DeleteConnection removes the table of the connection from the toml file
+func DeleteConnection(path string, name string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+
+	start, end, found := findConnectionTable(lines, name)
+	if !found {
+		return fmt.Errorf("connection %s is not a table of %s", name, path)
+	}
+
+	// the comments right above the next table belong to it
+	for end > start && end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end-1]), "#") {
+		end--
+	}
+	for end < len(lines) && strings.TrimSpace(lines[end]) == "" {
+		end++
+	}
+	// so do the ones right above its header unless they start the file
+	above := start
+	for above > 0 && strings.HasPrefix(strings.TrimSpace(lines[above-1]), "#") {
+		above--
+	}
+	if above > 0 {
+		start = above
+	}
+	if end == len(lines) {
+		for start > 0 && strings.TrimSpace(lines[start-1]) == "" {
+			start--
+		}
+	}
+
+	return WriteFile(path, joinLines(slices.Concat(lines[:start], lines[end:])))
+}
+
+// findConnectionTable returns the lines of the [NAME] or [connections.NAME]
+// table of the connection, from its header to the next table
+func findConnectionTable(lines []string, name string) (int, int, bool) {
+	start := -1
+	for i := 0; i < len(lines); i++ {
+		if tomlArrayHeader.MatchString(lines[i]) {
+			if start >= 0 {
+				return start, i, true
+			}
+			continue
+		}
+		if match := tomlHeader.FindStringSubmatch(lines[i]); match != nil {
+			if start >= 0 {
+				return start, i, true
+			}
+			table := tableName(match[1])
+			if slices.Equal(table, []string{name}) || slices.Equal(table, []string{"connections", name}) {
+				start = i
+			}
+			continue
+		}
+		if _, end, ok := tomlKeyAt(lines, i, len(lines)); ok {
+			i = end
+		}
+	}
+	return start, len(lines), start >= 0
+}
+
+// tomlKeyAt returns the key of the key value pair starting at the line and
+// the line its value ends on, multi-line strings and arrays included
+func tomlKeyAt(lines []string, i int, end int) (string, int, bool) {
+	match := tomlKey.FindStringSubmatch(lines[i])
+	if match == nil {
+		return "", i, false
+	}
+	key := strings.Trim(match[1], `"'`)
+
+	value := match[2]
+	for _, quote := range []string{`"""`, `'''`} {
+		if strings.HasPrefix(value, quote) && strings.Count(value, quote) == 1 {
+			for j := i + 1; j < end; j++ {
+				if strings.Contains(lines[j], quote) {
+					return key, j, true
+				}
+			}
+			return key, end - 1, true
+		}
+	}
+	if strings.HasPrefix(value, "[") {
+		depth := strings.Count(value, "[") - strings.Count(value, "]")
+		for j := i + 1; depth > 0 && j < end; j++ {
+			depth += strings.Count(lines[j], "[") - strings.Count(lines[j], "]")
+			if depth <= 0 {
+				return key, j, true
+			}
+		}
+	}
+	return key, i, true
+}
+
+// tableName splits the name of a table header on the dots outside quotes
+func tableName(header string) []string {
+	parts := make([]string, 0)
+	var part strings.Builder
+	var quote rune
+	for _, r := range header {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			part.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '.':
+			parts = append(parts, strings.TrimSpace(part.String()))
+			part.Reset()
+		case r != ' ' && r != '\t':
+			part.WriteRune(r)
+		}
+	}
+	return append(parts, strings.TrimSpace(part.String()))
+}
+
+// connectionTableName returns the header of a new connection, config.toml
+// and the connections.toml files already using [connections.NAME] tables
+// nest it under connections
+func connectionTableName(path string, lines []string, name string) string {
+	if !tomlBareKey.MatchString(name) {
+		name = tomlString(name)
+	}
+
+	if filepath.Base(path) == "config.toml" {
+		return "connections." + name
+	}
+	for _, line := range lines {
+		if match := tomlHeader.FindStringSubmatch(line); match != nil && tableName(match[1])[0] == "connections" {
+			return "connections." + name
+		}
+	}
+	return name
+}
+
+// checkNotDefined rejects connections defined otherwise than by a table,
+// which cannot be edited line by line
+func checkNotDefined(lines []string, name string) error {
+	metadata, err := toml.Decode(string(joinLines(lines)), &map[string]any{})
+	if err != nil {
+		return fmt.Errorf("decoding toml %w", err)
+	}
+	if metadata.IsDefined(name) || metadata.IsDefined("connections", name) {
+		return fmt.Errorf("connection %s is not defined by a table, edit it by hand", name)
+	}
+	return nil
+}
+
+// tomlString returns the value as a toml basic string
+func tomlString(value string) string {
+	var buffer bytes.Buffer
+	// encoding a string never fails
+	_ = toml.NewEncoder(&buffer).Encode(map[string]string{"v": value})
+	return strings.TrimSuffix(strings.TrimPrefix(buffer.String(), "v = "), "\n")
+}
+
+// sortedKeys orders the keys as EditableKeys then by name
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		i, j := slices.Index(EditableKeys, a), slices.Index(EditableKeys, b)
+		switch {
+		case i >= 0 && j >= 0:
+			return i - j
+		case i >= 0:
+			return -1
+		case j >= 0:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return keys
+}
+
+// readLines reads the lines of the file, none when it does not exist yet
+func readLines(path string) ([]string, error) {
+	data, found, err := readFile(path)
+	if err != nil || !found || len(data) == 0 {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"), nil
+}
+
+func joinLines(lines []string) []byte {
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// WriteFile replaces the file by a temporary file renamed over it keeping
+// its permissions, new files are only readable by the user as they hold
+// credentials
+func WriteFile(path string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	mode := fs.FileMode(0o600)
+	stat, err := os.Stat(path)
+	switch {
+	case err == nil:
+		mode = stat.Mode().Perm()
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("reading %s file %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating directory of %s %w", path, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file for %s %w", path, err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := f.Chmod(mode); err != nil {
+		return fmt.Errorf("setting permissions of %s %w", path, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing %s file %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing %s file %w", path, err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("replacing %s file %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFile writes the content to a connections.toml of a temporary
+// directory with the permissions
+func writeTestFile(t *testing.T, content string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "connections.toml")
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+const unrelatedTables = `# snowflake connections
+[connections.prod]
+account = "prod"
+user = "alice" # the owner
+
+# the dev account
+[connections.dev]
+account = "dev"
+
+[[arr]]
+name = "first"
+
+[other]
+keep = true
+`
+
+func TestSaveConnection(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		values  map[string]string
+		want    string
+	}{
+		{
+			name:    "prod",
+			content: unrelatedTables,
+			values:  map[string]string{"role": "R", "user": ""},
+			want: `# snowflake connections
+[connections.prod]
+account = "prod"
+role = "R"
+
+# the dev account
+[connections.dev]
+account = "dev"
+
+[[arr]]
+name = "first"
+
+[other]
+keep = true
+`,
+		},
+		{
+			name:    "dev",
+			content: unrelatedTables,
+			values:  map[string]string{"role": "R"},
+			want: `# snowflake connections
+[connections.prod]
+account = "prod"
+user = "alice" # the owner
+
+# the dev account
+[connections.dev]
+account = "dev"
+role = "R"
+
+[[arr]]
+name = "first"
+
+[other]
+keep = true
+`,
+		},
+		{
+			name:    "before_array",
+			content: "[before_array]\naccount = \"a\"\n[[arr]]\nname = \"first\"\n",
+			values:  map[string]string{"role": "R"},
+			want:    "[before_array]\naccount = \"a\"\nrole = \"R\"\n[[arr]]\nname = \"first\"\n",
+		},
+		{
+			name:    "new",
+			content: unrelatedTables,
+			values:  map[string]string{"account": "new", "password": `p"w`},
+			want: unrelatedTables + `
+[connections.new]
+account = "new"
+password = "p\"w"
+`,
+		},
+		{
+			name:    "multi",
+			content: "[multi]\nprivate_key_raw = \"\"\"\n[not a table]\n\"\"\"\nscopes = [\n  \"a\",\n]\n\n[next]\n",
+			values:  map[string]string{"role": "R"},
+			want:    "[multi]\nprivate_key_raw = \"\"\"\n[not a table]\n\"\"\"\nscopes = [\n  \"a\",\n]\nrole = \"R\"\n\n[next]\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeTestFile(t, test.content, 0o600)
+			if err := SaveConnection(path, test.name, test.values); err != nil {
+				t.Fatal(err)
+			}
+			if got := readTestFile(t, path); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDeleteConnection(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "prod",
+			want: `# snowflake connections
+# the dev account
+[connections.dev]
+account = "dev"
+
+[[arr]]
+name = "first"
+
+[other]
+keep = true
+`,
+		},
+		{
+			name: "dev",
+			want: `# snowflake connections
+[connections.prod]
+account = "prod"
+user = "alice" # the owner
+
+[[arr]]
+name = "first"
+
+[other]
+keep = true
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeTestFile(t, unrelatedTables, 0o600)
+			if err := DeleteConnection(path, test.name); err != nil {
+				t.Fatal(err)
+			}
+			if got := readTestFile(t, path); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDeleteConnectionMissing(t *testing.T) {
+	path := writeTestFile(t, unrelatedTables, 0o600)
+	for _, name := range []string{"missing", "arr", "connections"} {
+		if err := DeleteConnection(path, name); err == nil {
+			t.Errorf("deleting %s succeeded", name)
+		}
+	}
+	if got := readTestFile(t, path); got != unrelatedTables {
+		t.Errorf("the file changed\n%s", got)
+	}
+}
+
+func TestSaveConnectionInline(t *testing.T) {
+	content := "connections = { inline = { account = \"a\" } }\n"
+	path := writeTestFile(t, content, 0o600)
+	if err := SaveConnection(path, "inline", map[string]string{"role": "R"}); err == nil {
+		t.Error("saving an inline connection succeeded")
+	}
+}
+
+func TestWriteFileKeepsPermissions(t *testing.T) {
+	path := writeTestFile(t, unrelatedTables, 0o640)
+	if err := SaveConnection(path, "prod", map[string]string{"role": "R"}); err != nil {
+		t.Fatal(err)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := stat.Mode().Perm(); mode != 0o640 {
+		t.Errorf("got mode %o, want 640", mode)
+	}
+
+	created := filepath.Join(t.TempDir(), "new", "connections.toml")
+	if err := SaveConnection(created, "dev", map[string]string{"account": "dev"}); err != nil {
+		t.Fatal(err)
+	}
+	if stat, err := os.Stat(created); err != nil || stat.Mode().Perm() != 0o600 {
+		t.Errorf("got %v %v, want a file only readable by the user", stat.Mode(), err)
+	}
+}
@@ -0,0 +1,158 @@
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/costrouc/snowctl/internal/snowflake/configuration"
+	"github.com/costrouc/snowctl/internal/snowflake/snowsql"
+)
+
+// ConnectionField is a key of a connection edited from the connections view
+type ConnectionField struct {
+	Key   string
+	Value string
+	// Secret fields are masked while typed
+	Secret bool
+}
+
+// secretKeys are the keys of both configuration formats holding credentials
+var secretKeys = map[string]bool{
+	"password":             true,
+	"private_key_file_pwd": true,
+}
+
+// ConnectionFields returns the editable keys of the named connection,
+// snowflake.NAME or snowsql.NAME, with their values, empty for a connection
+// that does not exist yet
+func (cm *ConnectionManager) ConnectionFields(name string) ([]ConnectionField, error) {
+	kind, connectionName, err := cm.splitConnectionName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	snowflakeConnections, snowsqlConnections := cm.connections()
+	var keys []string
+	value := func(key string) string { return "" }
+	switch kind {
+	case "snowflake":
+		keys = configuration.EditableKeys
+		if connection := snowflakeConnections[connectionName]; connection != nil {
+			value = connection.Value
+		}
+	case "snowsql":
+		keys = snowsql.EditableKeys
+		if connection := snowsqlConnections[connectionName]; connection != nil {
+			value = connection.Value
+		}
+	}
+
+	fields := make([]ConnectionField, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, ConnectionField{Key: key, Value: value(key), Secret: secretKeys[key]})
+	}
+	return fields, nil
+}
+
+// SaveConnection writes the changed keys of the named connection to the file
+// it was read from, or to the default file of its format when it is new,
+// then reads the configuration again. It returns the file written.
+func (cm *ConnectionManager) SaveConnection(name string, changed map[string]string) (string, error) {
+	kind, path, connectionName, err := cm.connectionPath(name, true)
+	if err != nil {
+		return "", err
+	}
+
+	if kind == "snowflake" {
+		err = configuration.SaveConnection(path, connectionName, changed)
+	} else {
+		err = snowsql.SaveConnection(path, connectionName, changed)
+	}
+	if err != nil {
+		return "", fmt.Errorf("saving connection %s %w", name, err)
+	}
+
+	return path, cm.reload()
+}
+
+// DeleteConnection removes the named connection from the file it was read
+// from then reads the configuration again. It returns the file written.
+func (cm *ConnectionManager) DeleteConnection(name string) (string, error) {
+	kind, path, connectionName, err := cm.connectionPath(name, false)
+	if err != nil {
+		return "", err
+	}
+
+	if kind == "snowflake" {
+		err = configuration.DeleteConnection(path, connectionName)
+	} else {
+		err = snowsql.DeleteConnection(path, connectionName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("deleting connection %s %w", name, err)
+	}
+
+	return path, cm.reload()
+}
+
+// connectionPath returns the format, the file and the name in the file of
+// the named connection, new connections are allowed when create is set
+func (cm *ConnectionManager) connectionPath(name string, create bool) (string, string, string, error) {
+	if cm.staticName != "" && name == cm.staticName {
+		return "", "", "", fmt.Errorf("connection %s is not read from a configuration file", name)
+	}
+
+	kind, connectionName, err := cm.splitConnectionName(name)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	snowflakeConnections, snowsqlConnections := cm.connections()
+	var path string
+	switch kind {
+	case "snowflake":
+		if connection := snowflakeConnections[connectionName]; connection != nil {
+			// default is listed under the name of the default connection
+			path, connectionName = connection.Path, connection.Name
+			if path == "" {
+				return "", "", "", fmt.Errorf("connection %s is read from SNOWFLAKE_CONNECTIONS", name)
+			}
+		} else if create {
+			path, err = configuration.ConnectionsPath()
+		}
+	case "snowsql":
+		if connection := snowsqlConnections[connectionName]; connection != nil {
+			path = connection.Path
+		} else if create {
+			path, err = snowsql.ConnectionsPath()
+		}
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+	if path == "" {
+		return "", "", "", fmt.Errorf("%s connection with name %s does not exist", kind, connectionName)
+	}
+	if connectionName == "" {
+		return "", "", "", fmt.Errorf("connection without a name")
+	}
+	return kind, path, connectionName, nil
+}
+
+// reload reads the configuration files again
+func (cm *ConnectionManager) reload() error {
+	snowflakeConnections, err := configuration.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("reading snowflake standard configuration %w", err)
+	}
+	snowsqlConnections, err := snowsql.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("reading snowflake snowsql configuration %w", err)
+	}
+
+	cm.connectionsMutex.Lock()
+	defer cm.connectionsMutex.Unlock()
+
+	cm.snowflakeConnections = snowflakeConnections
+	cm.snowsqlConnections = snowsqlConnections
+	return nil
+}
@@ -0,0 +1,41 @@
+package snowflake
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestReloadWhileReading reads the connections while they are reloaded, as
+// the connections view does on its load goroutine, run it with -race
+func TestReloadWhileReading(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SNOWFLAKE_HOME", "")
+	t.Setenv("SNOWFLAKE_CONNECTIONS", "[dev]\naccount = \"xy12345\"\nuser = \"alice\"\npassword = \"secret\"\n")
+
+	cm, err := NewConnectionManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			if !cm.HasConnection("snowflake.dev") {
+				t.Error("snowflake.dev is missing")
+				return
+			}
+			if _, err := cm.ConnectionConfig("dev"); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for range 50 {
+		if err := cm.reload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,44 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Databases interface {
+	Show(ctx context.Context) ([]sdk.Database, error)
+	Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropDatabaseOptions) error
+}
+
+type databases struct {
+	client *Client
+}
+
+func (c *databases) Show(ctx context.Context) ([]sdk.Database, error) {
+	return c.client.SDKClient.Databases.Show(ctx, &sdk.ShowDatabasesOptions{})
+}
+
+type DropDatabaseOptions struct {
+	IfExists bool
+}
+
+func (c *databases) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropDatabaseOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropDatabaseOptions{}
+	}
+
+	query, err := newStatement("DROP", "DATABASE").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
@@ -3,7 +3,6 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -26,7 +25,11 @@ type Endpoint struct {
 }
 
 func (s *endpoints) Show(ctx context.Context, id *sdk.SchemaObjectIdentifier) ([]Endpoint, error) {
-	rows, err := s.client.SDKClient.GetConn().Query(fmt.Sprintf("SHOW ENDPOINTS IN SERVICE %s", id.FullyQualifiedName()))
+	stmt, err := newStatement("SHOW", "ENDPOINTS").in("SERVICE", id).build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,563 @@
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+type roles struct {
+	backend *Backend
+}
+
+func (c *roles) Show(ctx context.Context) ([]sdk.Role, error) {
+	var result []sdk.Role
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Roles, func(sdk.Role) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type users struct {
+	backend *Backend
+}
+
+func (c *users) Show(ctx context.Context) ([]sdk.User, error) {
+	var result []sdk.User
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Users, func(sdk.User) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type warehouses struct {
+	backend *Backend
+}
+
+func (c *warehouses) Show(ctx context.Context) ([]sdk.Warehouse, error) {
+	var result []sdk.Warehouse
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Warehouses, func(sdk.Warehouse) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+func (c *warehouses) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.DropWarehouseOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Warehouses, func(w sdk.Warehouse) bool { return w.Name == id.Name() }) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("warehouse", id)
+		}
+		return nil
+	})
+}
+
+type databases struct {
+	backend *Backend
+}
+
+func (c *databases) Show(ctx context.Context) ([]sdk.Database, error) {
+	var result []sdk.Database
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Databases, func(sdk.Database) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+// Drop removes the database along with every schema level object inside it
+func (c *databases) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.DropDatabaseOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Databases, func(d sdk.Database) bool { return d.Name == id.Name() }) {
+			if opts == nil || !opts.IfExists {
+				return doesNotExist("database", id)
+			}
+			return nil
+		}
+
+		remove(&f.Schemas, func(s sdk.Schema) bool { return s.DatabaseName == id.Name() })
+		dropSchemaObjects(f, func(database, _ string) bool { return database == id.Name() })
+		return nil
+	})
+}
+
+type securityintegrations struct {
+	backend *Backend
+}
+
+func (c *securityintegrations) Show(ctx context.Context) ([]sdk.SecurityIntegration, error) {
+	var result []sdk.SecurityIntegration
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.SecurityIntegrations, func(sdk.SecurityIntegration) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+func (c *securityintegrations) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.DropSecurityIntegrationOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.SecurityIntegrations, func(s sdk.SecurityIntegration) bool { return s.Name == id.Name() }) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("integration", id)
+		}
+		return nil
+	})
+}
+
+type applications struct {
+	backend *Backend
+}
+
+func (c *applications) Show(ctx context.Context) ([]sdk.Application, error) {
+	var result []sdk.Application
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Applications, func(sdk.Application) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type applicationpackages struct {
+	backend *Backend
+}
+
+func (c *applicationpackages) Show(ctx context.Context) ([]sdk.ApplicationPackage, error) {
+	var result []sdk.ApplicationPackage
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.ApplicationPackages, func(sdk.ApplicationPackage) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type networkpolicies struct {
+	backend *Backend
+}
+
+func (c *networkpolicies) Show(ctx context.Context) ([]sdk.NetworkPolicy, error) {
+	var result []sdk.NetworkPolicy
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.NetworkPolicies, func(sdk.NetworkPolicy) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type grants struct {
+	backend *Backend
+}
+
+func (c *grants) Show(ctx context.Context, opts *snowflake.ShowGrantOptions) ([]sdk.Grant, error) {
+	if opts == nil || (opts.To == nil && opts.On == nil) {
+		return nil, fmt.Errorf("one of the show grants options must be not nil")
+	}
+
+	var result []sdk.Grant
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Grants, func(g sdk.Grant) bool {
+			if opts.To != nil {
+				return g.GrantedTo == sdk.ObjectTypeRole && g.GranteeName != nil && g.GranteeName.Name() == opts.To.Name()
+			}
+			return g.GrantedOn == opts.On.ObjectType && g.Name != nil && snowflake.FullyQualifiedName(g.Name) == snowflake.FullyQualifiedName(opts.On.Name)
+		})
+		return nil
+	})
+	return result, err
+}
+
+type releasedirectives struct {
+	backend *Backend
+}
+
+func (c *releasedirectives) Show(ctx context.Context, id sdk.AccountObjectIdentifier) ([]snowflake.ReleaseDirective, error) {
+	var result []snowflake.ReleaseDirective
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.ReleaseDirectives[id.Name()], func(snowflake.ReleaseDirective) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type applicationpackageversions struct {
+	backend *Backend
+}
+
+func (c *applicationpackageversions) Show(ctx context.Context, id sdk.AccountObjectIdentifier) ([]snowflake.ApplicationPackageVersion, error) {
+	var result []snowflake.ApplicationPackageVersion
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.ApplicationPackageVersions[id.Name()], func(snowflake.ApplicationPackageVersion) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+type computepools struct {
+	backend *Backend
+}
+
+func (c *computepools) Create(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.CreateComputePoolOptions) error {
+	if opts == nil {
+		opts = &snowflake.CreateComputePoolOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		if findComputePool(f, id) != nil {
+			if opts.IfNotExists {
+				return nil
+			}
+			return alreadyExists("compute pool", id)
+		}
+
+		computePool := snowflake.ComputePool{
+			Name:           id.Name(),
+			State:          "IDLE",
+			MinNodes:       opts.MinNodes,
+			MaxNodes:       opts.MaxNodes,
+			InstanceFamily: string(opts.InstanceFamily),
+			AutoResume:     true,
+			Owner:          f.Session.Role,
+		}
+		if opts.InitiallySuspended != nil && *opts.InitiallySuspended {
+			computePool.State = "SUSPENDED"
+		}
+		if opts.AutoResume != nil {
+			computePool.AutoResume = *opts.AutoResume
+		}
+		if opts.AutoSuspendSecs != nil {
+			computePool.AutoSuspendSecs = *opts.AutoSuspendSecs
+		}
+		if opts.Comment != nil {
+			computePool.Comment = sql.NullString{String: *opts.Comment, Valid: true}
+		}
+		if opts.Application != nil {
+			computePool.Application = sql.NullString{String: opts.Application.Name(), Valid: true}
+		}
+
+		f.ComputePools = append(f.ComputePools, computePool)
+		return nil
+	})
+}
+
+func (c *computepools) Show(ctx context.Context) ([]snowflake.ComputePool, error) {
+	var result []snowflake.ComputePool
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.ComputePools, func(snowflake.ComputePool) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+func (c *computepools) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.DropComputePoolOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.ComputePools, func(cp snowflake.ComputePool) bool { return cp.Name == id.Name() }) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("compute pool", id)
+		}
+		return nil
+	})
+}
+
+func (c *computepools) Describe(ctx context.Context, id sdk.AccountObjectIdentifier) (*snowflake.ComputePoolDetails, error) {
+	var result *snowflake.ComputePoolDetails
+	err := c.backend.with(func(f *Fixtures) error {
+		computePool := findComputePool(f, id)
+		if computePool == nil {
+			return doesNotExist("compute pool", id)
+		}
+
+		result = &snowflake.ComputePoolDetails{
+			Name:            computePool.Name,
+			State:           computePool.State,
+			MinNodes:        computePool.MinNodes,
+			MaxNodes:        computePool.MaxNodes,
+			InstanceFamily:  computePool.InstanceFamily,
+			NumServices:     computePool.NumServices,
+			NumJobs:         computePool.NumJobs,
+			AutoSuspendSecs: computePool.AutoSuspendSecs,
+			AutoResume:      computePool.AutoResume,
+			ActiveNodes:     computePool.ActiveNodes,
+			IdleNodes:       computePool.IdleNodes,
+			CreatedOn:       computePool.CreatedOn,
+			ResumedOn:       computePool.ResumedOn,
+			UpdatedOn:       computePool.UpdatedOn,
+			Owner:           computePool.Owner,
+			Comment:         computePool.Comment.String,
+			IsExclusive:     computePool.IsExclusive,
+			Application:     computePool.Application,
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (c *computepools) Alter(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.AlterComputePoolOptions) error {
+	if opts == nil {
+		opts = &snowflake.AlterComputePoolOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		computePool := findComputePool(f, id)
+		if computePool == nil {
+			if opts.IfExists {
+				return nil
+			}
+			return doesNotExist("compute pool", id)
+		}
+
+		if opts.MinNodes != nil {
+			computePool.MinNodes = *opts.MinNodes
+		}
+		if opts.MaxNodes != nil {
+			computePool.MaxNodes = *opts.MaxNodes
+		}
+		if opts.AutoResume != nil {
+			computePool.AutoResume = *opts.AutoResume
+		}
+		if opts.AutoSuspendSecs != nil {
+			computePool.AutoSuspendSecs = *opts.AutoSuspendSecs
+		}
+		if opts.Comment != nil {
+			computePool.Comment = sql.NullString{String: *opts.Comment, Valid: true}
+		}
+		return nil
+	})
+}
+
+// AlterState follows the documented transitions, STOP ALL drops every
+// service running on the compute pool
+func (c *computepools) AlterState(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.AlterComputePoolStateOptions) error {
+	if opts == nil {
+		return fmt.Errorf("compute pool state action must be set")
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		computePool := findComputePool(f, id)
+		if computePool == nil {
+			if opts.IfExists {
+				return nil
+			}
+			return doesNotExist("compute pool", id)
+		}
+
+		switch opts.StateAction {
+		case snowflake.ComputePoolStateActionSuspend:
+			computePool.State = "SUSPENDED"
+			computePool.ActiveNodes = 0
+			computePool.IdleNodes = 0
+		case snowflake.ComputePoolStateActionResume:
+			computePool.State = "IDLE"
+		case snowflake.ComputePoolStateActionStopAll:
+			remove(&f.Services, func(s snowflake.Service) bool { return s.ComputePool == id.Name() })
+			computePool.NumServices = 0
+			computePool.NumJobs = 0
+		default:
+			return fmt.Errorf("unknown compute pool state action %s", opts.StateAction)
+		}
+		return nil
+	})
+}
+
+func findComputePool(f *Fixtures, id sdk.AccountObjectIdentifier) *snowflake.ComputePool {
+	for i := range f.ComputePools {
+		if f.ComputePools[i].Name == id.Name() {
+			return &f.ComputePools[i]
+		}
+	}
+	return nil
+}
+
+type listings struct {
+	backend *Backend
+}
+
+func (c *listings) Create(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.CreateListingOptions) error {
+	if opts == nil {
+		opts = &snowflake.CreateListingOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		if findListing(f, id) != nil {
+			if opts.IfNotExists {
+				return nil
+			}
+			return alreadyExists("listing", id)
+		}
+
+		listing := snowflake.Listing{
+			Name:          id.Name(),
+			State:         "DRAFT",
+			Owner:         f.Session.Role,
+			IsApplication: opts.ApplicationPackage != nil,
+		}
+		applyListingManifest(&listing, opts.ListingManifest, opts.Publish)
+
+		f.Listings = append(f.Listings, listing)
+		return nil
+	})
+}
+
+func (c *listings) Alter(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.AlterListingOptions) error {
+	if opts == nil {
+		opts = &snowflake.AlterListingOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		listing := findListing(f, id)
+		if listing == nil {
+			if opts.IfExists {
+				return nil
+			}
+			return doesNotExist("listing", id)
+		}
+
+		applyListingManifest(listing, opts.ListingManifest, opts.Publish)
+		return nil
+	})
+}
+
+func (c *listings) Show(ctx context.Context) ([]snowflake.Listing, error) {
+	var result []snowflake.Listing
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Listings, func(snowflake.Listing) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+func (c *listings) Describe(ctx context.Context, id sdk.AccountObjectIdentifier) (*snowflake.ListingDetails, error) {
+	var result *snowflake.ListingDetails
+	err := c.backend.with(func(f *Fixtures) error {
+		listing := findListing(f, id)
+		if listing == nil {
+			return doesNotExist("listing", id)
+		}
+
+		result = &snowflake.ListingDetails{
+			GlobalName:     listing.GlobalName,
+			Name:           listing.Name,
+			Owner:          listing.Owner,
+			OwnerRoleType:  listing.OwnerRoleType,
+			CreatedOn:      listing.CreatedOn,
+			UpdatedOn:      listing.UpdatedOn,
+			PublishedOn:    listing.PublishedOn,
+			Title:          listing.Title,
+			Subtitle:       listing.Subtitle,
+			State:          listing.State,
+			Profile:        sql.NullString{String: listing.Profile, Valid: listing.Profile != ""},
+			Comment:        listing.Comment,
+			TargetAccounts: sql.NullString{String: listing.TargetAccounts, Valid: listing.TargetAccounts != ""},
+			Regions:        listing.Regions,
+			ReviewState:    listing.ReviewState,
+			IsMonetized:    listing.IsMonetized,
+			IsApplication:  listing.IsApplication,
+			IsTargeted:     listing.IsTargeted,
+			IsLimitedTrial: listing.IsLimitedTrial,
+			IsByRequest:    listing.IsByRequest,
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (c *listings) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *snowflake.DropListingOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Listings, func(l snowflake.Listing) bool { return l.Name == id.Name() }) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("listing", id)
+		}
+		return nil
+	})
+}
+
+func findListing(f *Fixtures, id sdk.AccountObjectIdentifier) *snowflake.Listing {
+	for i := range f.Listings {
+		if f.Listings[i].Name == id.Name() {
+			return &f.Listings[i]
+		}
+	}
+	return nil
+}
+
+func applyListingManifest(listing *snowflake.Listing, manifest *snowflake.ListingManifest, publish bool) {
+	if manifest != nil {
+		listing.Title = manifest.Title
+		listing.Subtitle = sql.NullString{String: manifest.Subtitle, Valid: manifest.Subtitle != ""}
+		listing.Profile = manifest.Profile
+	}
+	if publish {
+		listing.State = "PUBLISHED"
+	}
+}
+
+type sessions struct {
+	backend *Backend
+}
+
+func (c *sessions) UseRole(ctx context.Context, id sdk.AccountObjectIdentifier) error {
+	return c.backend.with(func(f *Fixtures) error {
+		if len(filter(f.Roles, func(r sdk.Role) bool { return r.Name == id.Name() })) == 0 {
+			return doesNotExist("role", id)
+		}
+		f.Session.Role = id.Name()
+		return nil
+	})
+}
+
+func (c *sessions) UseWarehouse(ctx context.Context, id sdk.AccountObjectIdentifier) error {
+	return c.backend.with(func(f *Fixtures) error {
+		if len(filter(f.Warehouses, func(w sdk.Warehouse) bool { return w.Name == id.Name() })) == 0 {
+			return doesNotExist("warehouse", id)
+		}
+		f.Session.Warehouse = id.Name()
+		return nil
+	})
+}
+
+// UseDatabase also resets the current schema the way Snowflake does
+func (c *sessions) UseDatabase(ctx context.Context, id sdk.AccountObjectIdentifier) error {
+	return c.backend.with(func(f *Fixtures) error {
+		if len(filter(f.Databases, func(d sdk.Database) bool { return d.Name == id.Name() })) == 0 {
+			return doesNotExist("database", id)
+		}
+		f.Session.Database = id.Name()
+		f.Session.Schema = ""
+		return nil
+	})
+}
+
+func (c *sessions) UseSchema(ctx context.Context, id sdk.DatabaseObjectIdentifier) error {
+	return c.backend.with(func(f *Fixtures) error {
+		if len(filter(f.Schemas, func(s sdk.Schema) bool { return s.DatabaseName == id.DatabaseName() && s.Name == id.Name() })) == 0 {
+			return doesNotExist("schema", id)
+		}
+		f.Session.Database = id.DatabaseName()
+		f.Session.Schema = id.Name()
+		return nil
+	})
+}
+
+func (c *sessions) Current(ctx context.Context) (*snowflake.SessionDetails, error) {
+	var result snowflake.SessionDetails
+	err := c.backend.with(func(f *Fixtures) error {
+		result = f.Session
+		return nil
+	})
+	return &result, err
+}
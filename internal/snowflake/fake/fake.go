@@ -0,0 +1,244 @@
+// Package fake provides an in-memory implementation of every interface on
+// snowflake.Client. A Backend is seeded with Fixtures and applies SHOW, DROP,
+// ALTER and USE calls against them so that components can be exercised
+// without a live Snowflake account.
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+// ConnectionName is the name the fake connection is listed under
+const ConnectionName = "fake.default"
+
+// Fixtures is the complete state of a fake account
+type Fixtures struct {
+	Session snowflake.SessionDetails
+
+	Roles                []sdk.Role
+	Users                []sdk.User
+	Warehouses           []sdk.Warehouse
+	Databases            []sdk.Database
+	Schemas              []sdk.Schema
+	Tables               []sdk.Table
+	Views                []sdk.View
+	Stages               []sdk.Stage
+	Grants               []sdk.Grant
+	Applications         []sdk.Application
+	ApplicationPackages  []sdk.ApplicationPackage
+	NetworkRules         []sdk.NetworkRule
+	NetworkPolicies      []sdk.NetworkPolicy
+	Procedures           []sdk.Procedure
+	Streamlits           []sdk.Streamlit
+	SecurityIntegrations []sdk.SecurityIntegration
+
+	Listings          []snowflake.Listing
+	ImageRepositories []snowflake.ImageRepository
+	ComputePools      []snowflake.ComputePool
+	Services          []snowflake.Service
+	ServiceInstances  []snowflake.ServiceInstance
+	ServiceContainers []snowflake.ServiceContainer
+	Snapshots         []snowflake.Snapshot
+	Secrets           []snowflake.Secret
+
+	// Images are keyed by snowflake.FullyQualifiedName of the image repository
+	Images map[string][]snowflake.Image
+	// Endpoints are keyed by snowflake.FullyQualifiedName of the service
+	Endpoints map[string][]snowflake.Endpoint
+	// ServiceLogs are keyed by ServiceLogsKey
+	ServiceLogs map[string]string
+	// ReleaseDirectives are keyed by the application package name
+	ReleaseDirectives map[string][]snowflake.ReleaseDirective
+	// ApplicationPackageVersions are keyed by the application package name
+	ApplicationPackageVersions map[string][]snowflake.ApplicationPackageVersion
+	// StageFiles are keyed by snowflake.FullyQualifiedName of the stage and
+	// record the destination of every PUT
+	StageFiles map[string][]string
+}
+
+// ServiceLogsKey is the key used for Fixtures.ServiceLogs
+func ServiceLogsKey(service sdk.SchemaObjectIdentifier, instanceId int, containerName string) string {
+	return fmt.Sprintf("%s/%d/%s", snowflake.FullyQualifiedName(service), instanceId, containerName)
+}
+
+// Backend holds the fixtures of a fake account and serves them through a
+// snowflake.Client. It is safe for concurrent use.
+type Backend struct {
+	mu       sync.Mutex
+	fixtures Fixtures
+	client   *snowflake.Client
+}
+
+func NewBackend(fixtures *Fixtures) *Backend {
+	if fixtures == nil {
+		fixtures = &Fixtures{}
+	}
+
+	backend := &Backend{
+		fixtures: fixtures.clone(),
+	}
+	backend.client = &snowflake.Client{
+		Listings:                   &listings{backend: backend},
+		ImageRepositories:          &imagerepositories{backend: backend},
+		ComputePools:               &computepools{backend: backend},
+		Services:                   &services{backend: backend},
+		ServiceInstances:           &serviceinstances{backend: backend},
+		ServiceContainers:          &servicecontainers{backend: backend},
+		Endpoints:                  &endpoints{backend: backend},
+		Snapshots:                  &snapshots{backend: backend},
+		Secrets:                    &secrets{backend: backend},
+		Files:                      &files{backend: backend},
+		ReleaseDirectives:          &releasedirectives{backend: backend},
+		ApplicationPackageVersions: &applicationpackageversions{backend: backend},
+
+		Roles:                &roles{backend: backend},
+		Users:                &users{backend: backend},
+		Warehouses:           &warehouses{backend: backend},
+		Databases:            &databases{backend: backend},
+		Schemas:              &schemas{backend: backend},
+		Tables:               &tables{backend: backend},
+		Views:                &views{backend: backend},
+		Stages:               &stages{backend: backend},
+		Grants:               &grants{backend: backend},
+		Applications:         &applications{backend: backend},
+		ApplicationPackages:  &applicationpackages{backend: backend},
+		NetworkRules:         &networkrules{backend: backend},
+		NetworkPolicies:      &networkpolicies{backend: backend},
+		Procedures:           &procedures{backend: backend},
+		Streamlits:           &streamlits{backend: backend},
+		SecurityIntegrations: &securityintegrations{backend: backend},
+		Sessions:             &sessions{backend: backend},
+	}
+
+	return backend
+}
+
+// Client returns a snowflake client backed by the fixtures
+func (b *Backend) Client() *snowflake.Client {
+	return b.client
+}
+
+// ConnectionManager returns a connection manager that serves the fake
+// client under ConnectionName
+func (b *Backend) ConnectionManager() *snowflake.ConnectionManager {
+	return snowflake.NewStaticConnectionManager(ConnectionName, b.client)
+}
+
+// Fixtures returns a copy of the current state of the fake account
+func (b *Backend) Fixtures() Fixtures {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.fixtures.clone()
+}
+
+// Seed modifies the fixtures of a running backend
+func (b *Backend) Seed(seed func(fixtures *Fixtures)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	seed(&b.fixtures)
+}
+
+// with runs f while holding the backend lock
+func (b *Backend) with(f func(fixtures *Fixtures) error) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return f(&b.fixtures)
+}
+
+func (f *Fixtures) clone() Fixtures {
+	clone := *f
+
+	clone.Roles = slices.Clone(f.Roles)
+	clone.Users = slices.Clone(f.Users)
+	clone.Warehouses = slices.Clone(f.Warehouses)
+	clone.Databases = slices.Clone(f.Databases)
+	clone.Schemas = slices.Clone(f.Schemas)
+	clone.Tables = slices.Clone(f.Tables)
+	clone.Views = slices.Clone(f.Views)
+	clone.Stages = slices.Clone(f.Stages)
+	clone.Grants = slices.Clone(f.Grants)
+	clone.Applications = slices.Clone(f.Applications)
+	clone.ApplicationPackages = slices.Clone(f.ApplicationPackages)
+	clone.NetworkRules = slices.Clone(f.NetworkRules)
+	clone.NetworkPolicies = slices.Clone(f.NetworkPolicies)
+	clone.Procedures = slices.Clone(f.Procedures)
+	clone.Streamlits = slices.Clone(f.Streamlits)
+	clone.SecurityIntegrations = slices.Clone(f.SecurityIntegrations)
+
+	clone.Listings = slices.Clone(f.Listings)
+	clone.ImageRepositories = slices.Clone(f.ImageRepositories)
+	clone.ComputePools = slices.Clone(f.ComputePools)
+	clone.Services = slices.Clone(f.Services)
+	clone.ServiceInstances = slices.Clone(f.ServiceInstances)
+	clone.ServiceContainers = slices.Clone(f.ServiceContainers)
+	clone.Snapshots = slices.Clone(f.Snapshots)
+	clone.Secrets = slices.Clone(f.Secrets)
+
+	clone.Images = cloneMapOfSlices(f.Images)
+	clone.Endpoints = cloneMapOfSlices(f.Endpoints)
+	clone.ServiceLogs = maps.Clone(f.ServiceLogs)
+	clone.ReleaseDirectives = cloneMapOfSlices(f.ReleaseDirectives)
+	clone.ApplicationPackageVersions = cloneMapOfSlices(f.ApplicationPackageVersions)
+	clone.StageFiles = cloneMapOfSlices(f.StageFiles)
+
+	return clone
+}
+
+func cloneMapOfSlices[T any](m map[string][]T) map[string][]T {
+	if m == nil {
+		return nil
+	}
+
+	clone := make(map[string][]T, len(m))
+	for key, value := range m {
+		clone[key] = slices.Clone(value)
+	}
+	return clone
+}
+
+// filter returns a copy of the items for which keep returns true
+func filter[T any](items []T, keep func(T) bool) []T {
+	result := make([]T, 0)
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// remove deletes the items that match and reports whether any were deleted
+func remove[T any](items *[]T, match func(T) bool) bool {
+	length := len(*items)
+	*items = slices.DeleteFunc(*items, match)
+	return len(*items) != length
+}
+
+// inScope mirrors SHOW ... IN DATABASE / IN SCHEMA where the most specific
+// scope wins
+func inScope(databaseName, schemaName string, database *sdk.AccountObjectIdentifier, schema *sdk.DatabaseObjectIdentifier) bool {
+	if schema != nil {
+		return databaseName == schema.DatabaseName() && schemaName == schema.Name()
+	}
+	if database != nil {
+		return databaseName == database.Name()
+	}
+	return true
+}
+
+func doesNotExist(objectType string, id sdk.ObjectIdentifier) error {
+	return fmt.Errorf("%s %s does not exist or not authorized", objectType, snowflake.FullyQualifiedName(id))
+}
+
+func alreadyExists(objectType string, id sdk.ObjectIdentifier) error {
+	return fmt.Errorf("%s %s already exists", objectType, snowflake.FullyQualifiedName(id))
+}
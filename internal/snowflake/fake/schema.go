@@ -0,0 +1,384 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+// dropSchemaObjects removes every schema level object for which match
+// returns true, used when a database or schema is dropped
+func dropSchemaObjects(f *Fixtures, match func(database, schema string) bool) {
+	remove(&f.Tables, func(t sdk.Table) bool { return match(t.DatabaseName, t.SchemaName) })
+	remove(&f.Views, func(v sdk.View) bool { return match(v.DatabaseName, v.SchemaName) })
+	remove(&f.Stages, func(s sdk.Stage) bool { return match(s.DatabaseName, s.SchemaName) })
+	remove(&f.NetworkRules, func(n sdk.NetworkRule) bool { return match(n.DatabaseName, n.SchemaName) })
+	remove(&f.Procedures, func(p sdk.Procedure) bool { return match(p.CatalogName, p.SchemaName) })
+	remove(&f.Streamlits, func(s sdk.Streamlit) bool { return match(s.DatabaseName, s.SchemaName) })
+	remove(&f.Secrets, func(s snowflake.Secret) bool { return match(s.DatabaseName, s.SchemaName) })
+	remove(&f.Snapshots, func(s snowflake.Snapshot) bool { return match(s.DatabaseName, s.SchemaName) })
+	remove(&f.ImageRepositories, func(r snowflake.ImageRepository) bool { return match(r.DatabaseName, r.SchemaName) })
+	remove(&f.Services, func(s snowflake.Service) bool { return match(s.DatabaseName, s.SchemaName) })
+	remove(&f.ServiceInstances, func(s snowflake.ServiceInstance) bool { return match(s.DatabaseName, s.SchemaName) })
+	remove(&f.ServiceContainers, func(s snowflake.ServiceContainer) bool { return match(s.DatabaseName, s.SchemaName) })
+}
+
+type schemas struct {
+	backend *Backend
+}
+
+func (c *schemas) Show(ctx context.Context, opts *snowflake.ShowSchemaOptions) ([]sdk.Schema, error) {
+	if opts == nil {
+		opts = &snowflake.ShowSchemaOptions{}
+	}
+
+	var result []sdk.Schema
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Schemas, func(s sdk.Schema) bool { return inScope(s.DatabaseName, s.Name, opts.Database, nil) })
+		return nil
+	})
+	return result, err
+}
+
+// Drop removes the schema along with every object inside it
+func (c *schemas) Drop(ctx context.Context, id sdk.DatabaseObjectIdentifier, opts *snowflake.DropSchemaOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Schemas, func(s sdk.Schema) bool { return s.DatabaseName == id.DatabaseName() && s.Name == id.Name() }) {
+			if opts == nil || !opts.IfExists {
+				return doesNotExist("schema", id)
+			}
+			return nil
+		}
+
+		dropSchemaObjects(f, func(database, schema string) bool {
+			return database == id.DatabaseName() && schema == id.Name()
+		})
+		return nil
+	})
+}
+
+type tables struct {
+	backend *Backend
+}
+
+func (c *tables) Show(ctx context.Context, opts *snowflake.ShowTableOptions) ([]sdk.Table, error) {
+	if opts == nil {
+		opts = &snowflake.ShowTableOptions{}
+	}
+
+	var result []sdk.Table
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Tables, func(t sdk.Table) bool { return inScope(t.DatabaseName, t.SchemaName, opts.Database, opts.Schema) })
+		return nil
+	})
+	return result, err
+}
+
+func (c *tables) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.DropTableOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Tables, func(t sdk.Table) bool {
+			return t.DatabaseName == id.DatabaseName() && t.SchemaName == id.SchemaName() && t.Name == id.Name()
+		}) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("table", id)
+		}
+		return nil
+	})
+}
+
+type views struct {
+	backend *Backend
+}
+
+func (c *views) Show(ctx context.Context, opts *snowflake.ShowViewOptions) ([]sdk.View, error) {
+	if opts == nil {
+		opts = &snowflake.ShowViewOptions{}
+	}
+
+	var result []sdk.View
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Views, func(v sdk.View) bool { return inScope(v.DatabaseName, v.SchemaName, opts.Database, opts.Schema) })
+		return nil
+	})
+	return result, err
+}
+
+type stages struct {
+	backend *Backend
+}
+
+func (c *stages) Show(ctx context.Context, opts *snowflake.ShowStageOptions) ([]sdk.Stage, error) {
+	if opts == nil {
+		opts = &snowflake.ShowStageOptions{}
+	}
+
+	var result []sdk.Stage
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Stages, func(s sdk.Stage) bool { return inScope(s.DatabaseName, s.SchemaName, opts.Database, opts.Schema) })
+		return nil
+	})
+	return result, err
+}
+
+func (c *stages) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.DropStageOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Stages, func(s sdk.Stage) bool {
+			return s.DatabaseName == id.DatabaseName() && s.SchemaName == id.SchemaName() && s.Name == id.Name()
+		}) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("stage", id)
+		}
+		delete(f.StageFiles, snowflake.FullyQualifiedName(id))
+		return nil
+	})
+}
+
+type files struct {
+	backend *Backend
+}
+
+// Put records the destination of the file on the stage
+func (c *files) Put(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.PutFileOptions) error {
+	if opts == nil {
+		return fmt.Errorf("put file options must be set")
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		if len(filter(f.Stages, func(s sdk.Stage) bool {
+			return s.DatabaseName == id.DatabaseName() && s.SchemaName == id.SchemaName() && s.Name == id.Name()
+		})) == 0 {
+			return doesNotExist("stage", id)
+		}
+
+		if f.StageFiles == nil {
+			f.StageFiles = make(map[string][]string)
+		}
+		destination := path.Join(opts.DestinationPath, path.Base(opts.SourcePath))
+		f.StageFiles[snowflake.FullyQualifiedName(id)] = append(f.StageFiles[snowflake.FullyQualifiedName(id)], destination)
+		return nil
+	})
+}
+
+type networkrules struct {
+	backend *Backend
+}
+
+func (c *networkrules) Show(ctx context.Context, opts *snowflake.ShowNetworkRuleOptions) ([]sdk.NetworkRule, error) {
+	if opts == nil {
+		opts = &snowflake.ShowNetworkRuleOptions{}
+	}
+
+	var result []sdk.NetworkRule
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.NetworkRules, func(n sdk.NetworkRule) bool { return inScope(n.DatabaseName, n.SchemaName, opts.Database, opts.Schema) })
+		return nil
+	})
+	return result, err
+}
+
+type procedures struct {
+	backend *Backend
+}
+
+func (c *procedures) Show(ctx context.Context, opts *snowflake.ShowProcedureOptions) ([]sdk.Procedure, error) {
+	if opts == nil {
+		opts = &snowflake.ShowProcedureOptions{}
+	}
+
+	var result []sdk.Procedure
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Procedures, func(p sdk.Procedure) bool { return inScope(p.CatalogName, p.SchemaName, opts.Database, opts.Schema) })
+		return nil
+	})
+	return result, err
+}
+
+type streamlits struct {
+	backend *Backend
+}
+
+func (c *streamlits) Show(ctx context.Context, opts *snowflake.ShowStreamlitOptions) ([]sdk.Streamlit, error) {
+	if opts == nil {
+		opts = &snowflake.ShowStreamlitOptions{}
+	}
+
+	var result []sdk.Streamlit
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Streamlits, func(s sdk.Streamlit) bool { return inScope(s.DatabaseName, s.SchemaName, opts.Database, opts.Schema) })
+		return nil
+	})
+	return result, err
+}
+
+type secrets struct {
+	backend *Backend
+}
+
+func (c *secrets) Show(ctx context.Context, opts *snowflake.ShowSecretsOptions) ([]snowflake.Secret, error) {
+	if opts == nil {
+		opts = &snowflake.ShowSecretsOptions{}
+	}
+
+	var result []snowflake.Secret
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Secrets, func(s snowflake.Secret) bool {
+			if opts.Application != nil {
+				return s.DatabaseName == opts.Application.Name()
+			}
+			if opts.ApplicationPackage != nil {
+				return s.DatabaseName == opts.ApplicationPackage.Name()
+			}
+			return inScope(s.DatabaseName, s.SchemaName, opts.Database, opts.Schema)
+		})
+		return nil
+	})
+	return result, err
+}
+
+func (c *secrets) Drop(ctx context.Context, opts *snowflake.DropSecretsOptions) error {
+	if opts == nil || opts.Secret == nil {
+		return fmt.Errorf("secret must be set")
+	}
+
+	id := opts.Secret
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Secrets, func(s snowflake.Secret) bool {
+			return s.DatabaseName == id.DatabaseName() && s.SchemaName == id.SchemaName() && s.Name == id.Name()
+		}) && !opts.IfExists {
+			return doesNotExist("secret", id)
+		}
+		return nil
+	})
+}
+
+type snapshots struct {
+	backend *Backend
+}
+
+func (c *snapshots) Show(ctx context.Context, opts *snowflake.ShowSnapshotOptions) ([]snowflake.Snapshot, error) {
+	if opts == nil {
+		opts = &snowflake.ShowSnapshotOptions{}
+	}
+
+	var result []snowflake.Snapshot
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Snapshots, func(s snowflake.Snapshot) bool {
+			return inScope(s.DatabaseName, s.SchemaName, opts.Database, opts.Schema)
+		})
+		return nil
+	})
+	return result, err
+}
+
+func (c *snapshots) Drop(ctx context.Context, opts *snowflake.DropSnapshotOptions) error {
+	if opts == nil || opts.Snapshot == nil {
+		return fmt.Errorf("snapshot must be set")
+	}
+
+	id := opts.Snapshot
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.Snapshots, func(s snowflake.Snapshot) bool {
+			return s.DatabaseName == id.DatabaseName() && s.SchemaName == id.SchemaName() && s.Name == id.Name()
+		}) && !opts.IfExists {
+			return doesNotExist("snapshot", id)
+		}
+		return nil
+	})
+}
+
+type imagerepositories struct {
+	backend *Backend
+}
+
+func (c *imagerepositories) Create(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.CreateImageRepositoryOptions) error {
+	if opts == nil {
+		opts = &snowflake.CreateImageRepositoryOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		if findImageRepository(f, id) != nil {
+			if opts.IfNotExists {
+				return nil
+			}
+			return alreadyExists("image repository", id)
+		}
+
+		f.ImageRepositories = append(f.ImageRepositories, snowflake.ImageRepository{
+			Name:          id.Name(),
+			DatabaseName:  id.DatabaseName(),
+			SchemaName:    id.SchemaName(),
+			RepositoryURL: fmt.Sprintf("%s/%s/%s/%s", f.Session.Account, id.DatabaseName(), id.SchemaName(), id.Name()),
+			Owner:         f.Session.Role,
+			OwnerRoleType: "ROLE",
+		})
+		return nil
+	})
+}
+
+func (c *imagerepositories) Show(ctx context.Context, opts *snowflake.ShowImageRepositoryOptions) ([]snowflake.ImageRepository, error) {
+	if opts == nil {
+		opts = &snowflake.ShowImageRepositoryOptions{}
+	}
+
+	var result []snowflake.ImageRepository
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.ImageRepositories, func(r snowflake.ImageRepository) bool {
+			return inScope(r.DatabaseName, r.SchemaName, opts.Database, opts.Schema)
+		})
+		return nil
+	})
+	return result, err
+}
+
+func (c *imagerepositories) ShowImages(ctx context.Context, id sdk.SchemaObjectIdentifier) ([]snowflake.Image, error) {
+	var result []snowflake.Image
+	err := c.backend.with(func(f *Fixtures) error {
+		if findImageRepository(f, id) == nil {
+			return doesNotExist("image repository", id)
+		}
+		result = filter(f.Images[snowflake.FullyQualifiedName(id)], func(snowflake.Image) bool { return true })
+		return nil
+	})
+	return result, err
+}
+
+func (c *imagerepositories) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.DropImageRepositoryOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		if !remove(&f.ImageRepositories, func(r snowflake.ImageRepository) bool {
+			return r.DatabaseName == id.DatabaseName() && r.SchemaName == id.SchemaName() && r.Name == id.Name()
+		}) && (opts == nil || !opts.IfExists) {
+			return doesNotExist("image repository", id)
+		}
+		delete(f.Images, snowflake.FullyQualifiedName(id))
+		return nil
+	})
+}
+
+func findImageRepository(f *Fixtures, id sdk.SchemaObjectIdentifier) *snowflake.ImageRepository {
+	for i := range f.ImageRepositories {
+		r := &f.ImageRepositories[i]
+		if r.DatabaseName == id.DatabaseName() && r.SchemaName == id.SchemaName() && r.Name == id.Name() {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+	"github.com/costrouc/snowctl/internal/snowflake"
+)
+
+type services struct {
+	backend *Backend
+}
+
+func (c *services) Create(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.CreateServiceOptions) error {
+	if opts == nil {
+		opts = &snowflake.CreateServiceOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		if findService(f, id) != nil {
+			if opts.IfNotExists {
+				return nil
+			}
+			return alreadyExists("service", id)
+		}
+
+		computePool := findComputePool(f, opts.ComputePool)
+		if computePool == nil {
+			return doesNotExist("compute pool", opts.ComputePool)
+		}
+		computePool.NumServices++
+
+		service := snowflake.Service{
+			Name:          id.Name(),
+			Status:        "PENDING",
+			DatabaseName:  id.DatabaseName(),
+			SchemaName:    id.SchemaName(),
+			Owner:         f.Session.Role,
+			ComputePool:   opts.ComputePool.Name(),
+			MinInstances:  opts.MinInstances,
+			MaxInstances:  opts.MaxInstances,
+			AutoResume:    opts.AutoResume,
+			OwnerRoleType: "ROLE",
+		}
+		if opts.QueryWarehouse.Name() != "" {
+			service.QueryWarehouse = sql.NullString{String: opts.QueryWarehouse.Name(), Valid: true}
+		}
+		if opts.Comment != "" {
+			service.Comment = sql.NullString{String: opts.Comment, Valid: true}
+		}
+
+		f.Services = append(f.Services, service)
+		return nil
+	})
+}
+
+// Show mirrors SHOW SERVICES where the most specific scope wins
+func (c *services) Show(ctx context.Context, opts *snowflake.ShowServiceOptions) ([]snowflake.Service, error) {
+	if opts == nil {
+		opts = &snowflake.ShowServiceOptions{}
+	}
+
+	var result []snowflake.Service
+	err := c.backend.with(func(f *Fixtures) error {
+		result = filter(f.Services, func(s snowflake.Service) bool {
+			if opts.Database == nil && opts.Schema == nil && opts.ComputePool != nil {
+				return s.ComputePool == opts.ComputePool.Name()
+			}
+			return inScope(s.DatabaseName, s.SchemaName, opts.Database, opts.Schema)
+		})
+		return nil
+	})
+	return result, err
+}
+
+func (c *services) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.DropServiceOptions) error {
+	if err := c.backend.client.CheckDrop(id); err != nil {
+		return err
+	}
+	return c.backend.with(func(f *Fixtures) error {
+		service := findService(f, id)
+		if service == nil {
+			if opts == nil || !opts.IfExists {
+				return doesNotExist("service", id)
+			}
+			return nil
+		}
+
+		if computePool := findComputePool(f, sdk.NewAccountObjectIdentifier(service.ComputePool)); computePool != nil && computePool.NumServices > 0 {
+			computePool.NumServices--
+		}
+
+		remove(&f.Services, func(s snowflake.Service) bool { return isService(id, s.DatabaseName, s.SchemaName, s.Name) })
+		remove(&f.ServiceInstances, func(s snowflake.ServiceInstance) bool {
+			return isService(id, s.DatabaseName, s.SchemaName, s.ServiceName)
+		})
+		remove(&f.ServiceContainers, func(s snowflake.ServiceContainer) bool {
+			return isService(id, s.DatabaseName, s.SchemaName, s.ServiceName)
+		})
+		delete(f.Endpoints, snowflake.FullyQualifiedName(id))
+		return nil
+	})
+}
+
+func (c *services) Describe(ctx context.Context, id sdk.SchemaObjectIdentifier) (*snowflake.ServiceDetails, error) {
+	var result *snowflake.ServiceDetails
+	err := c.backend.with(func(f *Fixtures) error {
+		service := findService(f, id)
+		if service == nil {
+			return doesNotExist("service", id)
+		}
+
+		result = &snowflake.ServiceDetails{
+			Name:                      service.Name,
+			DatabaseName:              service.DatabaseName,
+			SchemaName:                service.SchemaName,
+			Owner:                     service.Owner,
+			ComputePool:               service.ComputePool,
+			DNSName:                   service.DNSName,
+			MinInstances:              service.MinInstances,
+			MaxInstances:              service.MaxInstances,
+			AutoResume:                service.AutoResume,
+			ExternalAccessIntegration: service.ExternalAccessIntegration,
+			CreatedOn:                 service.CreatedOn,
+			UpdatedOn:                 service.UpdatedOn,
+			ResumedOn:                 service.ResumedOn,
+			Comment:                   service.Comment.String,
+			OwnerRoleType:             service.OwnerRoleType,
+			QueryWarehouse:            service.QueryWarehouse.String,
+			IsJob:                     service.IsJob,
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (c *services) Alter(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.AlterServiceOptions) error {
+	if opts == nil {
+		opts = &snowflake.AlterServiceOptions{}
+	}
+
+	return c.backend.with(func(f *Fixtures) error {
+		service := findService(f, id)
+		if service == nil {
+			if opts.IfExists {
+				return nil
+			}
+			return doesNotExist("service", id)
+		}
+
+		if opts.MinInstances != nil {
+			service.MinInstances = *opts.MinInstances
+		}
+		if opts.MaxInstances != nil {
+			service.MaxInstances = *opts.MaxInstances
+		}
+		if opts.QueryWarehouse != nil {
+			service.QueryWarehouse = sql.NullString{String: opts.QueryWarehouse.Name(), Valid: true}
+		}
+		if opts.AutoResume != nil {
+			service.AutoResume = *opts.AutoResume
+		}
+		if opts.Comment != nil {
+			service.Comment = sql.NullString{String: *opts.Comment, Valid: true}
+		}
+		return nil
+	})
+}
+
+func (c *services) Logs(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *snowflake.ServiceLogsOptions) (string, error) {
+	if opts == nil {
+		opts = &snowflake.ServiceLogsOptions{}
+	}
+
+	var result string
+	err := c.backend.with(func(f *Fixtures) error {
+		if findService(f, id) == nil {
+			return doesNotExist("service", id)
+		}
+
+		logs, ok := f.ServiceLogs[ServiceLogsKey(id, opts.InstanceId, opts.ContainerName)]
+		if !ok {
+			return fmt.Errorf("container %s in instance %d of service %s does not exist", opts.ContainerName, opts.InstanceId, snowflake.FullyQualifiedName(id))
+		}
+		result = logs
+		return nil
+	})
+	return result, err
+}
+
+func findService(f *Fixtures, id sdk.SchemaObjectIdentifier) *snowflake.Service {
+	for i := range f.Services {
+		s := &f.Services[i]
+		if isService(id, s.DatabaseName, s.SchemaName, s.Name) {
+			return s
+		}
+	}
+	return nil
+}
+
+func isService(id sdk.SchemaObjectIdentifier, databaseName, schemaName, serviceName string) bool {
+	return databaseName == id.DatabaseName() && schemaName == id.SchemaName() && serviceName == id.Name()
+}
+
+type serviceinstances struct {
+	backend *Backend
+}
+
+func (c *serviceinstances) Show(ctx context.Context, id *sdk.SchemaObjectIdentifier) ([]snowflake.ServiceInstance, error) {
+	if id == nil {
+		return nil, fmt.Errorf("service must be set")
+	}
+
+	var result []snowflake.ServiceInstance
+	err := c.backend.with(func(f *Fixtures) error {
+		if findService(f, *id) == nil {
+			return doesNotExist("service", id)
+		}
+		result = filter(f.ServiceInstances, func(s snowflake.ServiceInstance) bool {
+			return isService(*id, s.DatabaseName, s.SchemaName, s.ServiceName)
+		})
+		return nil
+	})
+	return result, err
+}
+
+type servicecontainers struct {
+	backend *Backend
+}
+
+func (c *servicecontainers) Show(ctx context.Context, id *sdk.SchemaObjectIdentifier) ([]snowflake.ServiceContainer, error) {
+	if id == nil {
+		return nil, fmt.Errorf("service must be set")
+	}
+
+	var result []snowflake.ServiceContainer
+	err := c.backend.with(func(f *Fixtures) error {
+		if findService(f, *id) == nil {
+			return doesNotExist("service", id)
+		}
+		result = filter(f.ServiceContainers, func(s snowflake.ServiceContainer) bool {
+			return isService(*id, s.DatabaseName, s.SchemaName, s.ServiceName)
+		})
+		return nil
+	})
+	return result, err
+}
+
+type endpoints struct {
+	backend *Backend
+}
+
+func (c *endpoints) Show(ctx context.Context, id *sdk.SchemaObjectIdentifier) ([]snowflake.Endpoint, error) {
+	if id == nil {
+		return nil, fmt.Errorf("service must be set")
+	}
+
+	var result []snowflake.Endpoint
+	err := c.backend.with(func(f *Fixtures) error {
+		if findService(f, *id) == nil {
+			return doesNotExist("service", id)
+		}
+		result = filter(f.Endpoints[snowflake.FullyQualifiedName(id)], func(snowflake.Endpoint) bool { return true })
+		return nil
+	})
+	return result, err
+}
@@ -3,7 +3,7 @@ package snowflake
 import (
 	"context"
 	"fmt"
-	"path/filepath"
+	"path"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -28,7 +28,7 @@ func (c *files) Put(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *Pu
 		opts = &PutFileOptions{}
 	}
 
-	stmt := fmt.Sprintf("PUT 'file://%s' '@%s'", opts.SourcePath, filepath.Join(id.FullyQualifiedName(), opts.DestinationPath))
+	stmt := fmt.Sprintf("PUT %s %s", QuoteString("file://"+opts.SourcePath), QuoteString("@"+path.Join(FullyQualifiedName(id), opts.DestinationPath)))
 	if opts.Overwrite {
 		stmt += " OVERWRITE = TRUE"
 	} else {
@@ -41,6 +41,6 @@ func (c *files) Put(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *Pu
 		stmt += " AUTO_COMPRESS = FALSE"
 	}
 
-	_, err := c.client.SDKClient.GetConn().Query(stmt)
+	_, err := c.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	return err
 }
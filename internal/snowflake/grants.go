@@ -0,0 +1,37 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Grants interface {
+	Show(ctx context.Context, opts *ShowGrantOptions) ([]sdk.Grant, error)
+}
+
+type grants struct {
+	client *Client
+}
+
+// ShowGrantOptions selects either the privileges granted to a role (To)
+// or the privileges granted on an object (On)
+type ShowGrantOptions struct {
+	To *sdk.AccountObjectIdentifier
+	On *sdk.Object
+}
+
+func (c *grants) Show(ctx context.Context, opts *ShowGrantOptions) ([]sdk.Grant, error) {
+	var sdkOpts sdk.ShowGrantOptions
+	switch {
+	case opts.To != nil:
+		sdkOpts.To = &sdk.ShowGrantsTo{Role: *opts.To}
+	case opts.On != nil:
+		sdkOpts.On = &sdk.ShowGrantsOn{Object: opts.On}
+	default:
+		return nil, fmt.Errorf("one of the show grants options must be not nil")
+	}
+
+	return c.client.SDKClient.Grants.Show(ctx, &sdkOpts)
+}
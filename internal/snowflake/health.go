@@ -0,0 +1,164 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	sf "github.com/snowflakedb/gosnowflake"
+)
+
+// healthCheckTimeout bounds the check of each connection
+const healthCheckTimeout = 10 * time.Second
+
+// interactiveAuthenticators prompt the user, they are only checked on demand
+var interactiveAuthenticators = map[string]bool{
+	"externalbrowser":       true,
+	"username_password_mfa": true,
+}
+
+// ConnectionCheck is the result of a successful connection test
+type ConnectionCheck struct {
+	Latency time.Duration
+	// Session resolves the account locator and region of the connection
+	Session *SessionDetails
+}
+
+// ConnectionHealth is the last check of a connection, Check is set when it
+// was reachable, Err when not and neither when it was skipped
+type ConnectionHealth struct {
+	Checked time.Time
+	Check   *ConnectionCheck
+	Err     error
+	Skipped bool
+}
+
+// TestConnection opens a session with the configuration and runs the
+// context query, returning the time both took. The session is closed and
+// the current client of the connection manager is left as is. The driver
+// does not stop logging in on cancellation so the test returns as soon as
+// the context is done and the session is closed in the background.
+func TestConnection(ctx context.Context, config *sf.Config) (*ConnectionCheck, error) {
+	type result struct {
+		check *ConnectionCheck
+		err   error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		start := time.Now()
+
+		sdkClient, err := newSDKClient(config, false)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		client := &Client{SDKClient: sdkClient}
+		client.initialize()
+		defer client.Close()
+
+		session, err := client.Sessions.Current(ctx)
+		if err != nil {
+			done <- result{err: fmt.Errorf("querying session context %w", err)}
+			return
+		}
+		done <- result{check: &ConnectionCheck{Latency: time.Since(start), Session: session}}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("connecting %w", ctx.Err())
+	case result := <-done:
+		return result.check, result.err
+	}
+}
+
+// CheckConnections checks the connections concurrently, each within
+// healthCheckTimeout, and returns their health by name. Cached results are
+// reused unless recheck is set, connections with an interactive
+// authenticator are skipped.
+func (cm *ConnectionManager) CheckConnections(ctx context.Context, connections []*ConnectionInfo, recheck bool) map[string]*ConnectionHealth {
+	cm.healthMutex.Lock()
+	cached := maps.Clone(cm.health)
+	cm.healthMutex.Unlock()
+
+	results := make(map[string]*ConnectionHealth, len(connections))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, connection := range connections {
+		if health := cached[connection.Name]; health != nil && !recheck {
+			results[connection.Name] = health
+			continue
+		}
+		if interactiveAuthenticators[connection.Authenticator] {
+			results[connection.Name] = &ConnectionHealth{Checked: time.Now(), Skipped: true}
+			continue
+		}
+
+		check := cm.checkFunc(connection.Name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			result, err := check(checkCtx)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[connection.Name] = &ConnectionHealth{Checked: time.Now(), Check: result, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	// checks cut short by the caller are not cached
+	if ctx.Err() == nil {
+		cm.healthMutex.Lock()
+		if cm.health == nil {
+			cm.health = make(map[string]*ConnectionHealth)
+		}
+		maps.Copy(cm.health, results)
+		cm.healthMutex.Unlock()
+	}
+	return results
+}
+
+// SetConnectionHealth caches the result of a test of the named connection
+func (cm *ConnectionManager) SetConnectionHealth(name string, check *ConnectionCheck, err error) {
+	cm.healthMutex.Lock()
+	defer cm.healthMutex.Unlock()
+
+	if cm.health == nil {
+		cm.health = make(map[string]*ConnectionHealth)
+	}
+	cm.health[name] = &ConnectionHealth{Checked: time.Now(), Check: check, Err: err}
+}
+
+// checkFunc returns the check of the named connection, the static
+// connection queries its current client
+func (cm *ConnectionManager) checkFunc(name string) func(ctx context.Context) (*ConnectionCheck, error) {
+	if cm.staticName != "" && name == cm.staticName {
+		client := cm.GetClient()
+		return func(ctx context.Context) (*ConnectionCheck, error) {
+			start := time.Now()
+			session, err := client.Sessions.Current(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("querying session context %w", err)
+			}
+			return &ConnectionCheck{Latency: time.Since(start), Session: session}, nil
+		}
+	}
+
+	config, err := cm.ConnectionConfig(name)
+	if err != nil {
+		return func(ctx context.Context) (*ConnectionCheck, error) {
+			return nil, err
+		}
+	}
+	config.LoginTimeout = healthCheckTimeout
+	return func(ctx context.Context) (*ConnectionCheck, error) {
+		return TestConnection(ctx, config)
+	}
+}
@@ -0,0 +1,174 @@
+package snowflake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+// SplitIdentifier splits a dotted identifier such as DB.SCHEMA.NAME
+// keeping dots that appear inside double quotes, parts below one accepts
+// any number of parts
+func SplitIdentifier(identifier string, parts int) ([]string, error) {
+	result := make([]string, 0, parts)
+
+	var current strings.Builder
+	quoted := false
+	for _, r := range identifier {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '.' && !quoted:
+			result = append(result, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	result = append(result, current.String())
+
+	if parts > 0 && len(result) != parts {
+		return nil, fmt.Errorf("identifier %s must have %d dot separated parts", identifier, parts)
+	}
+	for _, part := range result {
+		if identifierName(part) == "" {
+			return nil, fmt.Errorf("identifier %s has an empty part", identifier)
+		}
+	}
+	return result, nil
+}
+
+// identifierName is the name of a part of an identifier, unquoted parts are
+// upper cased as snowflake does and quoted ones keep their case and have
+// their doubled quotes unescaped
+func identifierName(part string) string {
+	if len(part) >= 2 && strings.HasPrefix(part, `"`) && strings.HasSuffix(part, `"`) {
+		return strings.ReplaceAll(part[1:len(part)-1], `""`, `"`)
+	}
+	return strings.ToUpper(part)
+}
+
+// identifierNames splits the identifier into the names of its parts. The
+// identifiers of the sdk trim the double quotes around names so the names
+// starting or ending with one are refused rather than naming another object.
+func identifierNames(identifier string, parts int) ([]string, error) {
+	split, err := SplitIdentifier(identifier, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(split))
+	for i, part := range split {
+		names[i] = identifierName(part)
+		if strings.HasPrefix(names[i], `"`) || strings.HasSuffix(names[i], `"`) {
+			return nil, fmt.Errorf("identifier %s has a name starting or ending with a double quote which is not supported", identifier)
+		}
+	}
+	return names, nil
+}
+
+func ParseAccountObjectIdentifier(identifier string) (sdk.AccountObjectIdentifier, error) {
+	names, err := identifierNames(identifier, 1)
+	if err != nil {
+		return sdk.AccountObjectIdentifier{}, err
+	}
+	return sdk.NewAccountObjectIdentifier(names[0]), nil
+}
+
+func ParseDatabaseObjectIdentifier(identifier string) (sdk.DatabaseObjectIdentifier, error) {
+	names, err := identifierNames(identifier, 2)
+	if err != nil {
+		return sdk.DatabaseObjectIdentifier{}, err
+	}
+	return sdk.NewDatabaseObjectIdentifier(names[0], names[1]), nil
+}
+
+func ParseSchemaObjectIdentifier(identifier string) (sdk.SchemaObjectIdentifier, error) {
+	names, err := identifierNames(identifier, 3)
+	if err != nil {
+		return sdk.SchemaObjectIdentifier{}, err
+	}
+	return sdk.NewSchemaObjectIdentifier(names[0], names[1], names[2]), nil
+}
+
+// ParseObjectIdentifier parses an identifier of one to three parts into the
+// account, database or schema object identifier it is
+func ParseObjectIdentifier(identifier string) (sdk.ObjectIdentifier, error) {
+	parts, err := SplitIdentifier(identifier, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch len(parts) {
+	case 1:
+		return ParseAccountObjectIdentifier(identifier)
+	case 2:
+		return ParseDatabaseObjectIdentifier(identifier)
+	case 3:
+		return ParseSchemaObjectIdentifier(identifier)
+	}
+	return nil, fmt.Errorf("identifier %s must have at most 3 dot separated parts", identifier)
+}
+
+// QuoteIdentifier quotes a name so that it keeps its case, spaces and dots,
+// doubling the double quotes it contains
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// identifierParts returns the names the identifier is made of, database,
+// schema and name, it is false for identifiers snowctl does not build and
+// nil for nil pointers
+func identifierParts(id sdk.ObjectIdentifier) ([]string, bool) {
+	switch id := id.(type) {
+	case sdk.AccountObjectIdentifier:
+		return []string{id.Name()}, true
+	case *sdk.AccountObjectIdentifier:
+		if id == nil {
+			return nil, true
+		}
+		return identifierParts(*id)
+	case sdk.DatabaseObjectIdentifier:
+		return []string{id.DatabaseName(), id.Name()}, true
+	case *sdk.DatabaseObjectIdentifier:
+		if id == nil {
+			return nil, true
+		}
+		return identifierParts(*id)
+	case sdk.SchemaObjectIdentifier:
+		return []string{id.DatabaseName(), id.SchemaName(), id.Name()}, true
+	case *sdk.SchemaObjectIdentifier:
+		if id == nil {
+			return nil, true
+		}
+		return identifierParts(*id)
+	}
+	return nil, false
+}
+
+// FullyQualifiedName quotes every part of the identifier, unlike the one of
+// the sdk it escapes the double quotes within names
+func FullyQualifiedName(id sdk.ObjectIdentifier) string {
+	parts, ok := identifierParts(id)
+	if !ok {
+		return id.FullyQualifiedName()
+	}
+
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = QuoteIdentifier(part)
+	}
+	name := strings.Join(quoted, ".")
+
+	// procedures and functions are identified by their argument types too
+	if function, ok := id.(interface{ Arguments() []sdk.DataType }); ok && len(parts) > 0 {
+		if arguments := function.Arguments(); len(arguments) > 0 {
+			types := make([]string, len(arguments))
+			for i, argument := range arguments {
+				types[i] = string(argument)
+			}
+			name += "(" + strings.Join(types, ", ") + ")"
+		}
+	}
+	return name
+}
@@ -0,0 +1,130 @@
+package snowflake
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+// nastyNames are valid snowflake names that break naive quoting
+var nastyNames = []string{
+	"PLAIN",
+	"MixedCase",
+	"with space",
+	"dotted.name",
+	`double"quote`,
+	`a""b`,
+	"single'quote",
+	`back\slash`,
+	"dollar$$name",
+	"trailing$",
+	" ",
+}
+
+func TestParseAccountObjectIdentifierRoundTrip(t *testing.T) {
+	for _, name := range nastyNames {
+		t.Run(name, func(t *testing.T) {
+			id := sdk.NewAccountObjectIdentifier(name)
+			parsed, err := ParseAccountObjectIdentifier(FullyQualifiedName(id))
+			if err != nil {
+				t.Fatalf("parsing %s: %v", FullyQualifiedName(id), err)
+			}
+			if parsed.Name() != name {
+				t.Errorf("got %q, want %q", parsed.Name(), name)
+			}
+		})
+	}
+}
+
+func TestParseDatabaseObjectIdentifierRoundTrip(t *testing.T) {
+	for _, name := range nastyNames {
+		t.Run(name, func(t *testing.T) {
+			id := sdk.NewDatabaseObjectIdentifier(name, name)
+			parsed, err := ParseDatabaseObjectIdentifier(FullyQualifiedName(id))
+			if err != nil {
+				t.Fatalf("parsing %s: %v", FullyQualifiedName(id), err)
+			}
+			if parsed.DatabaseName() != name || parsed.Name() != name {
+				t.Errorf("got %q.%q, want %q.%q", parsed.DatabaseName(), parsed.Name(), name, name)
+			}
+		})
+	}
+}
+
+func TestParseSchemaObjectIdentifierRoundTrip(t *testing.T) {
+	for _, name := range nastyNames {
+		t.Run(name, func(t *testing.T) {
+			id := sdk.NewSchemaObjectIdentifier("DB", name, name)
+			parsed, err := ParseSchemaObjectIdentifier(FullyQualifiedName(id))
+			if err != nil {
+				t.Fatalf("parsing %s: %v", FullyQualifiedName(id), err)
+			}
+			if !reflect.DeepEqual(parsed, id) {
+				t.Errorf("got %#v, want %#v", parsed, id)
+			}
+		})
+	}
+}
+
+func TestParseObjectIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		want       sdk.ObjectIdentifier
+	}{
+		{"name", sdk.NewAccountObjectIdentifier("NAME")},
+		{`"MixedCase"`, sdk.NewAccountObjectIdentifier("MixedCase")},
+		{`db."with space"`, sdk.NewDatabaseObjectIdentifier("DB", "with space")},
+		{`"a.b"."c"".d".e`, sdk.NewSchemaObjectIdentifier("a.b", `c".d`, "E")},
+		{`DB.SCH."it's$$"`, sdk.NewSchemaObjectIdentifier("DB", "SCH", "it's$$")},
+	}
+	for _, test := range tests {
+		t.Run(test.identifier, func(t *testing.T) {
+			got, err := ParseObjectIdentifier(test.identifier)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseObjectIdentifierErrors(t *testing.T) {
+	for _, identifier := range []string{"", `""`, "DB.", ".NAME", `DB."".NAME`, "A.B.C.D", `""""`, `DB."""x"`, `"x"""`} {
+		t.Run(identifier, func(t *testing.T) {
+			if _, err := ParseObjectIdentifier(identifier); err == nil {
+				t.Errorf("parsing %q succeeded", identifier)
+			}
+		})
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		want       []string
+	}{
+		{"A.B.C", []string{"A", "B", "C"}},
+		{`"a.b".C`, []string{`"a.b"`, "C"}},
+		{`"a"".b".C`, []string{`"a"".b"`, "C"}},
+		{`""""`, []string{`""""`}},
+	}
+	for _, test := range tests {
+		t.Run(test.identifier, func(t *testing.T) {
+			got, err := SplitIdentifier(test.identifier, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("got %q, want %q", got, test.want)
+				}
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -28,9 +27,15 @@ func (c *imagerepositories) Create(ctx context.Context, id sdk.SchemaObjectIdent
 		opts = &CreateImageRepositoryOptions{}
 	}
 
-	createListingTemplate := fmt.Sprintf("CREATE IMAGE REPOSITORY {{ if .IfNotExists }}IF NOT EXISTS{{ end }} %s.%s.%s", id.DatabaseName(), id.SchemaName(), id.Name())
-	stmt := templateToQuery(createListingTemplate, opts)
-	_, err := c.client.SDKClient.GetConn().Exec(stmt)
+	query, err := newStatement("CREATE", "IMAGE REPOSITORY").
+		keywordIf(opts.IfNotExists, "IF NOT EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
 
@@ -51,12 +56,21 @@ type ShowImageRepositoryOptions struct {
 }
 
 func (c *imagerepositories) Show(ctx context.Context, opts *ShowImageRepositoryOptions) ([]ImageRepository, error) {
-	query := "SHOW IMAGE REPOSITORIES"
-	if opts.Database != nil {
-		query = fmt.Sprintf("SHOW IMAGE REPOSITORIES IN DATABASE %s", opts.Database.FullyQualifiedName())
+	if opts == nil {
+		opts = &ShowImageRepositoryOptions{}
 	}
-	if opts.Schema != nil {
-		query = fmt.Sprintf("SHOW IMAGE REPOSITORIES IN SCHEMA %s", opts.Schema.FullyQualifiedName())
+
+	// the most specific scope wins
+	stmt := newStatement("SHOW", "IMAGE REPOSITORIES")
+	switch {
+	case opts.Schema != nil:
+		stmt.in("SCHEMA", opts.Schema)
+	case opts.Database != nil:
+		stmt.in("DATABASE", opts.Database)
+	}
+	query, err := stmt.build()
+	if err != nil {
+		return nil, err
 	}
 
 	rows, err := c.client.SDKClient.GetConn().QueryContext(ctx, query)
@@ -95,7 +109,10 @@ type Image struct {
 }
 
 func (c *imagerepositories) ShowImages(ctx context.Context, id sdk.SchemaObjectIdentifier) ([]Image, error) {
-	stmt := fmt.Sprintf("SHOW IMAGES IN IMAGE REPOSITORY %s;", id.FullyQualifiedName())
+	stmt, err := newStatement("SHOW", "IMAGES").in("IMAGE REPOSITORY", id).build()
+	if err != nil {
+		return nil, err
+	}
 	rows, err := c.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
@@ -125,12 +142,21 @@ type DropImageRepositoryOptions struct {
 }
 
 func (c *imagerepositories) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropImageRepositoryOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
 	if opts == nil {
 		opts = &DropImageRepositoryOptions{}
 	}
 
-	dropListingTemplate := fmt.Sprintf("DROP IMAGE REPOSITORY {{ if .IfExists }}IF EXISTS{{ end }} %s.%s.%s", id.DatabaseName(), id.SchemaName(), id.Name())
-	stmt := templateToQuery(dropListingTemplate, opts)
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	query, err := newStatement("DROP", "IMAGE REPOSITORY").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
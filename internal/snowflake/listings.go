@@ -3,15 +3,16 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
 
 type Listings interface {
-	Create(ctx context.Context, id string, opts *CreateListingOptions) error
-	Alter(ctx context.Context, id string, opts *AlterListingOptions) error
+	Create(ctx context.Context, id sdk.AccountObjectIdentifier, opts *CreateListingOptions) error
+	Alter(ctx context.Context, id sdk.AccountObjectIdentifier, opts *AlterListingOptions) error
 	Show(ctx context.Context) ([]Listing, error)
-	Describe(ctx context.Context, id string) (*ListingDetails, error)
-	Drop(ctx context.Context, id string, opts *DropListingOptions) error
+	Describe(ctx context.Context, id sdk.AccountObjectIdentifier) (*ListingDetails, error)
+	Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropListingOptions) error
 }
 
 type listings struct {
@@ -54,31 +55,35 @@ type ListingManifest struct {
 type CreateListingOptions struct {
 	ListingManifest    *ListingManifest
 	IfNotExists        bool
-	ApplicationPackage string
+	ApplicationPackage *sdk.AccountObjectIdentifier
 	Publish            bool
 	Review             bool
 }
 
 // https://other-docs.snowflake.com/en/sql-reference/sql/create-listing
-func (c *listings) Create(ctx context.Context, id string, opts *CreateListingOptions) error {
+func (c *listings) Create(ctx context.Context, id sdk.AccountObjectIdentifier, opts *CreateListingOptions) error {
 	if opts == nil {
 		opts = &CreateListingOptions{}
 	}
 
-	createListingTemplate := fmt.Sprintf(`
-	CREATE EXTERNAL LISTING {{if .IfNotExists}}IF NOT EXISTS{{end}} %s
-	    APPLICATION PACKAGE {{.ApplicationPackage}}
-		AS $$ {{json .ListingManifest}} $$
-		PUBLISH = {{if .Publish}}TRUE{{ else }}FALSE{{end}}
-		REVIEW = {{if .Review}}TRUE{{else}}FALSE{{end}};
-	`, id)
-	stmt := templateToQuery(createListingTemplate, opts)
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	stmt := newStatement("CREATE", "EXTERNAL LISTING").
+		keywordIf(opts.IfNotExists, "IF NOT EXISTS").
+		identifier(id)
+	if opts.ApplicationPackage != nil {
+		stmt.keyword("APPLICATION PACKAGE").identifier(opts.ApplicationPackage)
+	}
+	query, err := stmt.
+		keyword("AS").
+		json(opts.ListingManifest).
+		boolProperty("PUBLISH", &opts.Publish).
+		boolProperty("REVIEW", &opts.Review).
+		build()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
 }
 
 type AlterListingOptions struct {
@@ -89,20 +94,25 @@ type AlterListingOptions struct {
 }
 
 // https://other-docs.snowflake.com/en/sql-reference/sql/alter-listing
-func (c *listings) Alter(ctx context.Context, id string, opts *AlterListingOptions) error {
-	alterListingTemplate := fmt.Sprintf(`
-	ALTER LISTING {{if .IfExists}}IF EXISTS{{end}} %s
-		AS $$ {{json .ListingManifest}} $$
-		PUBLISH = {{if .Publish}}TRUE{{ else }}FALSE{{end}}
-		REVIEW = {{if .Review}}TRUE{{else}}FALSE{{end}};
-	`, id)
-	stmt := templateToQuery(alterListingTemplate, opts)
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+func (c *listings) Alter(ctx context.Context, id sdk.AccountObjectIdentifier, opts *AlterListingOptions) error {
+	if opts == nil {
+		opts = &AlterListingOptions{}
+	}
+
+	query, err := newStatement("ALTER", "LISTING").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		keyword("AS").
+		json(opts.ListingManifest).
+		boolProperty("PUBLISH", &opts.Publish).
+		boolProperty("REVIEW", &opts.Review).
+		build()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
 }
 
 type Listing struct {
@@ -132,7 +142,7 @@ type Listing struct {
 
 // https://other-docs.snowflake.com/en/sql-reference/sql/show-listings
 func (c *listings) Show(ctx context.Context) ([]Listing, error) {
-	rows, err := c.client.SDKClient.GetConn().Query("SHOW LISTINGS")
+	rows, err := c.client.SDKClient.GetConn().QueryContext(ctx, "SHOW LISTINGS")
 	if err != nil {
 		return nil, err
 	}
@@ -196,11 +206,14 @@ type ListingDetails struct {
 }
 
 // https://other-docs.snowflake.com/en/sql-reference/sql/desc-listing
-func (c *listings) Describe(ctx context.Context, id string) (*ListingDetails, error) {
-	stmt := fmt.Sprintf("DESCRIBE LISTING %s", id)
+func (c *listings) Describe(ctx context.Context, id sdk.AccountObjectIdentifier) (*ListingDetails, error) {
+	stmt, err := newStatement("DESCRIBE", "LISTING").identifier(id).build()
+	if err != nil {
+		return nil, err
+	}
 	var describeListingResult ListingDetails
 
-	err := c.client.SDKClient.GetConn().QueryRow(stmt).Scan(&describeListingResult.GlobalName, &describeListingResult.Name, &describeListingResult.Owner, &describeListingResult.OwnerRoleType, &describeListingResult.CreatedOn, &describeListingResult.UpdatedOn, &describeListingResult.PublishedOn, &describeListingResult.Title, &describeListingResult.Subtitle, &describeListingResult.Description, &describeListingResult.ListingTerms, &describeListingResult.State, &describeListingResult.Share, &describeListingResult.ApplicationPackage, &describeListingResult.BusinessNeeds, &describeListingResult.UsageExamples, &describeListingResult.DataAttributes, &describeListingResult.Categories, &describeListingResult.Resources, &describeListingResult.Profile, &describeListingResult.CustomizedContactInfo, &describeListingResult.DataDictionary, &describeListingResult.DataPreview, &describeListingResult.Comment, &describeListingResult.Revisions, &describeListingResult.TargetAccounts, &describeListingResult.Regions, &describeListingResult.RefreshSchedule, &describeListingResult.RefreshType, &describeListingResult.ReviewState, &describeListingResult.RejectionReason, &describeListingResult.UnpublishedByAdminReason, &describeListingResult.IsMonetized, &describeListingResult.IsApplication, &describeListingResult.IsTargeted, &describeListingResult.IsLimitedTrial, &describeListingResult.IsByRequest, &describeListingResult.LimitedTrialPlan, &describeListingResult.RetiredOn, &describeListingResult.ScheduledDropTime, &describeListingResult.ManifestYAML)
+	err = c.client.SDKClient.GetConn().QueryRowContext(ctx, stmt).Scan(&describeListingResult.GlobalName, &describeListingResult.Name, &describeListingResult.Owner, &describeListingResult.OwnerRoleType, &describeListingResult.CreatedOn, &describeListingResult.UpdatedOn, &describeListingResult.PublishedOn, &describeListingResult.Title, &describeListingResult.Subtitle, &describeListingResult.Description, &describeListingResult.ListingTerms, &describeListingResult.State, &describeListingResult.Share, &describeListingResult.ApplicationPackage, &describeListingResult.BusinessNeeds, &describeListingResult.UsageExamples, &describeListingResult.DataAttributes, &describeListingResult.Categories, &describeListingResult.Resources, &describeListingResult.Profile, &describeListingResult.CustomizedContactInfo, &describeListingResult.DataDictionary, &describeListingResult.DataPreview, &describeListingResult.Comment, &describeListingResult.Revisions, &describeListingResult.TargetAccounts, &describeListingResult.Regions, &describeListingResult.RefreshSchedule, &describeListingResult.RefreshType, &describeListingResult.ReviewState, &describeListingResult.RejectionReason, &describeListingResult.UnpublishedByAdminReason, &describeListingResult.IsMonetized, &describeListingResult.IsApplication, &describeListingResult.IsTargeted, &describeListingResult.IsLimitedTrial, &describeListingResult.IsByRequest, &describeListingResult.LimitedTrialPlan, &describeListingResult.RetiredOn, &describeListingResult.ScheduledDropTime, &describeListingResult.ManifestYAML)
 	if err != nil {
 		return nil, err
 	}
@@ -213,17 +226,22 @@ type DropListingOptions struct {
 }
 
 // https://other-docs.snowflake.com/en/sql-reference/sql/drop-listing
-func (c *listings) Drop(ctx context.Context, id string, opts *DropListingOptions) error {
+func (c *listings) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropListingOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
 	if opts == nil {
 		opts = &DropListingOptions{}
 	}
 
-	dropListingTemplate := fmt.Sprintf("DROP LISTING %s {{if .IfExists}}IF EXISTS{{end}};", id)
-	stmt := templateToQuery(dropListingTemplate, opts)
-	_, err := c.client.SDKClient.GetConn().Exec(stmt)
+	query, err := newStatement("DROP", "LISTING").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
 }
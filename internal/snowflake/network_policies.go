@@ -0,0 +1,19 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type NetworkPolicies interface {
+	Show(ctx context.Context) ([]sdk.NetworkPolicy, error)
+}
+
+type networkpolicies struct {
+	client *Client
+}
+
+func (c *networkpolicies) Show(ctx context.Context) ([]sdk.NetworkPolicy, error) {
+	return c.client.SDKClient.NetworkPolicies.Show(ctx, sdk.NewShowNetworkPolicyRequest())
+}
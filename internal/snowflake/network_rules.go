@@ -0,0 +1,31 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type NetworkRules interface {
+	Show(ctx context.Context, opts *ShowNetworkRuleOptions) ([]sdk.NetworkRule, error)
+}
+
+type networkrules struct {
+	client *Client
+}
+
+type ShowNetworkRuleOptions struct {
+	Database *sdk.AccountObjectIdentifier
+	Schema   *sdk.DatabaseObjectIdentifier
+}
+
+func (c *networkrules) Show(ctx context.Context, opts *ShowNetworkRuleOptions) ([]sdk.NetworkRule, error) {
+	request := sdk.NewShowNetworkRuleRequest()
+	if opts != nil {
+		if in := showIn(opts.Database, opts.Schema); in != nil {
+			request.WithIn(in)
+		}
+	}
+
+	return c.client.SDKClient.NetworkRules.Show(ctx, request)
+}
@@ -0,0 +1,31 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Procedures interface {
+	Show(ctx context.Context, opts *ShowProcedureOptions) ([]sdk.Procedure, error)
+}
+
+type procedures struct {
+	client *Client
+}
+
+type ShowProcedureOptions struct {
+	Database *sdk.AccountObjectIdentifier
+	Schema   *sdk.DatabaseObjectIdentifier
+}
+
+func (c *procedures) Show(ctx context.Context, opts *ShowProcedureOptions) ([]sdk.Procedure, error) {
+	request := sdk.NewShowProcedureRequest()
+	if opts != nil {
+		if in := showIn(opts.Database, opts.Schema); in != nil {
+			request.WithIn(in)
+		}
+	}
+
+	return c.client.SDKClient.Procedures.Show(ctx, request)
+}
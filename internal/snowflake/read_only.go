@@ -0,0 +1,155 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// readOnlyStatements are the statements run by the read-only clients, DESC
+// is short for DESCRIBE
+var readOnlyStatements = []string{"SHOW", "DESCRIBE", "DESC", "SELECT"}
+
+// checkReadOnly refuses the statements not starting with a read-only
+// keyword, comments included, and the queries holding several statements
+// as a later one could change the account whatever the first one is
+func checkReadOnly(query string) error {
+	keyword := ""
+	if fields := strings.Fields(strings.ToUpper(query)); len(fields) > 0 {
+		keyword = strings.TrimRight(fields[0], ";")
+	}
+	if !slices.Contains(readOnlyStatements, keyword) {
+		return fmt.Errorf("read-only connection refuses %s statements", keyword)
+	}
+	if multipleStatements(query) {
+		return fmt.Errorf("read-only connection refuses multiple statements")
+	}
+	return nil
+}
+
+// multipleStatements reports whether a statement follows a ; of the query,
+// skipping the string literals, quoted identifiers and comments where a ;
+// does not end the statement
+func multipleStatements(query string) bool {
+	ended := false
+	for i := 0; i < len(query); i++ {
+		switch {
+		case query[i] == ';':
+			ended = true
+			continue
+		case strings.HasPrefix(query[i:], "--") || strings.HasPrefix(query[i:], "//"):
+			i = skipTo(query, i+2, "\n")
+			continue
+		case strings.HasPrefix(query[i:], "/*"):
+			i = skipTo(query, i+2, "*/")
+			continue
+		case query[i] == ' ' || query[i] == '\t' || query[i] == '\n' || query[i] == '\r':
+			continue
+		}
+
+		if ended {
+			return true
+		}
+		switch {
+		case strings.HasPrefix(query[i:], "$$"):
+			i = skipTo(query, i+2, "$$")
+		case query[i] == '\'':
+			i = skipQuoted(query, i, '\'', true)
+		case query[i] == '"':
+			i = skipQuoted(query, i, '"', false)
+		}
+	}
+	return false
+}
+
+// skipTo returns the index of the last byte of the end searched from start,
+// the last index of the query when it is missing
+func skipTo(query string, start int, end string) int {
+	if j := strings.Index(query[start:], end); j >= 0 {
+		return start + j + len(end) - 1
+	}
+	return len(query) - 1
+}
+
+// skipQuoted returns the index of the quote closing the one at start, a
+// doubled quote is part of the value as is the character after a
+// backslash in string literals
+func skipQuoted(query string, start int, quote byte, backslash bool) int {
+	for j := start + 1; j < len(query); j++ {
+		switch {
+		case backslash && query[j] == '\\':
+			j++
+		case query[j] == quote && j+1 < len(query) && query[j+1] == quote:
+			j++
+		case query[j] == quote:
+			return j
+		}
+	}
+	return len(query) - 1
+}
+
+// readOnlyConnector opens connections checking every statement with
+// checkReadOnly before it is sent
+type readOnlyConnector struct {
+	driver.Connector
+}
+
+func (c readOnlyConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyConn{Conn: conn}, nil
+}
+
+type readOnlyConn struct {
+	driver.Conn
+}
+
+func (c *readOnlyConn) Prepare(query string) (driver.Stmt, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *readOnlyConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *readOnlyConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *readOnlyConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *readOnlyConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
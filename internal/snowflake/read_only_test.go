@@ -0,0 +1,41 @@
+package snowflake
+
+import "testing"
+
+func TestCheckReadOnly(t *testing.T) {
+	tests := []struct {
+		query   string
+		allowed bool
+	}{
+		{"SHOW WAREHOUSES", true},
+		{"show warehouses;", true},
+		{"DESC SERVICE DB.SCH.SVC", true},
+		{"DESCRIBE COMPUTE POOL POOL ;  ", true},
+		{"SELECT 1; -- done", true},
+		{"SELECT 1; /* done */", true},
+		{"SELECT 1;;", true},
+		{`SELECT SYSTEM$GET_SERVICE_LOGS('DB.SCH."a;b"', 0, 'main')`, true},
+		{`SELECT 'it''s; fine', 'back\'slash;'`, true},
+		{`SELECT "odd;""name" FROM T`, true},
+		{"SELECT $$a; b$$", true},
+		{"SELECT 1 -- not; a statement\n", true},
+		{"DROP TABLE X", false},
+		{"  drop table x", false},
+		{"/* SELECT */ DROP TABLE X", false},
+		{"", false},
+		{"SELECT 1; DROP TABLE X", false},
+		{"SELECT 1;DROP TABLE X", false},
+		{"SHOW TABLES; -- comment\nDROP TABLE X", false},
+		{"SELECT 'a;b'; DROP TABLE X", false},
+		{"SELECT;DROP TABLE X", false},
+		{"SELECT 1 /* ; */; DROP TABLE X", false},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			err := checkReadOnly(test.query)
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("got allowed %t, want %t (%v)", allowed, test.allowed, err)
+			}
+		})
+	}
+}
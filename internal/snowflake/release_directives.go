@@ -3,7 +3,6 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -31,8 +30,11 @@ type ReleaseDirective struct {
 }
 
 func (c *releasedirectives) Show(ctx context.Context, id sdk.AccountObjectIdentifier) ([]ReleaseDirective, error) {
-	stmt := fmt.Sprintf("SHOW RELEASE DIRECTIVES IN APPLICATION PACKAGE %s;", id.Name())
-	rows, err := c.client.SDKClient.GetConn().Query(stmt)
+	stmt, err := newStatement("SHOW", "RELEASE DIRECTIVES").in("APPLICATION PACKAGE", id).build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
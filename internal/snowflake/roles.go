@@ -0,0 +1,19 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Roles interface {
+	Show(ctx context.Context) ([]sdk.Role, error)
+}
+
+type roles struct {
+	client *Client
+}
+
+func (c *roles) Show(ctx context.Context) ([]sdk.Role, error) {
+	return c.client.SDKClient.Roles.Show(ctx, sdk.NewShowRoleRequest())
+}
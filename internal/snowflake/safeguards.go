@@ -0,0 +1,198 @@
+package snowflake
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+// DefaultProtectedEnvironments require the name of an object to be typed
+// before it is dropped unless configured otherwise
+var DefaultProtectedEnvironments = []string{"prod", "staging"}
+
+// Safeguards protect the objects of the accounts from the destructive
+// actions of snowctl
+type Safeguards struct {
+	// Environments tag the connections as prod, staging or dev by
+	// connection name, snowflake.NAME, snowsql.NAME or NAME for both
+	Environments map[string]string
+	// Protected are the environments where dropping an object requires
+	// typing its name, DefaultProtectedEnvironments when nil
+	Protected []string
+	// DenyDrop are patterns of the names of the objects that are never
+	// dropped, DB.SCHEMA.NAME without quotes, * matching any character dots
+	// included. The containers of the objects a pattern matches are never
+	// dropped either, PROD.* protects the database PROD.
+	DenyDrop []string
+	// ReadOnly refuses the statements other than SHOW, DESCRIBE and SELECT
+	// on every connection and ReadOnlyConnections on the ones named as
+	// Environments are
+	ReadOnly            bool
+	ReadOnlyConnections []string
+}
+
+func (s *Safeguards) validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, pattern := range s.DenyDrop {
+		if slices.Contains(strings.Split(pattern, "."), "") {
+			return fmt.Errorf("invalid deny drop pattern %q, a part of the name is empty", pattern)
+		}
+	}
+	return nil
+}
+
+// CheckDrop refuses the objects matching a deny drop pattern and the ones
+// holding objects the pattern matches, names are compared ignoring case
+func (s *Safeguards) CheckDrop(id sdk.ObjectIdentifier) error {
+	if s == nil {
+		return nil
+	}
+
+	parts, ok := identifierParts(id)
+	if !ok {
+		parts = strings.Split(strings.ReplaceAll(id.FullyQualifiedName(), `"`, ""), ".")
+	}
+	name := strings.Join(parts, ".")
+	for _, pattern := range s.DenyDrop {
+		if matchPattern(pattern, name) {
+			return fmt.Errorf("%s cannot be dropped, it matches the deny drop pattern %s", name, pattern)
+		}
+		if containsMatches(pattern, parts) {
+			return fmt.Errorf("%s cannot be dropped, it holds objects matching the deny drop pattern %s", name, pattern)
+		}
+	}
+	return nil
+}
+
+// containsMatches reports whether the object named by the parts holds
+// objects matching the pattern, its parts match the leading parts of a
+// pattern naming objects at a deeper level
+func containsMatches(pattern string, parts []string) bool {
+	patternParts := strings.Split(pattern, ".")
+	if len(parts) == 0 || len(parts) >= len(patternParts) {
+		return false
+	}
+	for i, part := range parts {
+		if !matchPattern(patternParts[i], part) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPattern matches a name against a pattern ignoring case, * matches
+// any sequence of characters and ? a single one, unlike path.Match both
+// match / and . too
+func matchPattern(patternText string, nameText string) bool {
+	pattern, name := []rune(strings.ToUpper(patternText)), []rune(strings.ToUpper(nameText))
+	// star is the position after the last * and match the position in the
+	// name it was tried from, the name is backtracked to it on a mismatch
+	star, match := -1, 0
+	p, n := 0, 0
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			p++
+			star, match = p, n
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == name[n]):
+			p++
+			n++
+		case star >= 0:
+			match++
+			p, n = star, match
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// CheckDrop refuses the objects denied by the safeguards of the client,
+// backends replacing the interfaces of the client check it before dropping
+func (c *Client) CheckDrop(id sdk.ObjectIdentifier) error {
+	return c.safeguards.CheckDrop(id)
+}
+
+// SetSafeguards sets the safeguards of every connection, the clients created
+// afterwards and the static client check them before dropping objects
+func (cm *ConnectionManager) SetSafeguards(safeguards *Safeguards) error {
+	if err := safeguards.validate(); err != nil {
+		return err
+	}
+
+	cm.safeguards = safeguards
+	if cm.staticClient != nil {
+		cm.staticClient.safeguards = safeguards
+	}
+	return nil
+}
+
+// Environment returns the environment of the connection of the current
+// client, empty when it is not tagged
+func (cm *ConnectionManager) Environment() string {
+	name := cm.Name()
+	if cm.safeguards == nil || name == "" {
+		return ""
+	}
+	for _, name := range cm.safeguardNames(name) {
+		if environment, ok := cm.safeguards.Environments[name]; ok {
+			return environment
+		}
+	}
+	return ""
+}
+
+// safeguardNames are the names the safeguards of a connection are looked up
+// by, as given, then snowflake.NAME or snowsql.NAME and NAME alone
+func (cm *ConnectionManager) safeguardNames(name string) []string {
+	kind, connectionName, err := cm.splitConnectionName(name)
+	if err != nil {
+		return []string{name}
+	}
+	return []string{name, kind + "." + connectionName, connectionName}
+}
+
+// ReadOnly reports whether the current connection is read-only
+func (cm *ConnectionManager) ReadOnly() bool {
+	return cm.readOnly(cm.Name())
+}
+
+func (cm *ConnectionManager) readOnly(name string) bool {
+	if cm.safeguards == nil {
+		return false
+	}
+	if cm.safeguards.ReadOnly {
+		return true
+	}
+	return slices.ContainsFunc(cm.safeguardNames(name), func(name string) bool {
+		return slices.Contains(cm.safeguards.ReadOnlyConnections, name)
+	})
+}
+
+// Protected reports whether dropping an object of the current connection
+// requires typing its name
+func (cm *ConnectionManager) Protected() bool {
+	environment := cm.Environment()
+	if environment == "" {
+		return false
+	}
+
+	protected := DefaultProtectedEnvironments
+	if cm.safeguards.Protected != nil {
+		protected = cm.safeguards.Protected
+	}
+	return slices.Contains(protected, environment)
+}
+
+// CheckDrop refuses the objects denied by the safeguards, see
+// Safeguards.CheckDrop
+func (cm *ConnectionManager) CheckDrop(id sdk.ObjectIdentifier) error {
+	return cm.safeguards.CheckDrop(id)
+}
@@ -0,0 +1,90 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+func TestCheckDrop(t *testing.T) {
+	tests := []struct {
+		pattern string
+		id      sdk.ObjectIdentifier
+		refused bool
+	}{
+		{"PROD.*", sdk.NewAccountObjectIdentifier("PROD"), true},
+		{"PROD.*", sdk.NewAccountObjectIdentifier("prod"), true},
+		{"PROD.*", sdk.NewDatabaseObjectIdentifier("PROD", "PUBLIC"), true},
+		{"PROD.*", sdk.NewSchemaObjectIdentifier("PROD", "PUBLIC", "T"), true},
+		{"PROD.*", sdk.NewAccountObjectIdentifier("PRODUCTION"), false},
+		{"PROD.*", sdk.NewAccountObjectIdentifier("DEV"), false},
+		{"*.AUDIT_LOG", sdk.NewSchemaObjectIdentifier("A", "B/C", "AUDIT_LOG"), true},
+		{"*.AUDIT_LOG", sdk.NewSchemaObjectIdentifier("A", "B.C", "AUDIT_LOG"), true},
+		{"*.AUDIT_LOG", sdk.NewDatabaseObjectIdentifier("A", "AUDIT_LOG"), true},
+		{"*.AUDIT_LOG", sdk.NewSchemaObjectIdentifier("A", "B", "AUDIT_LOGS"), false},
+		// any database may hold an AUDIT_LOG
+		{"*.AUDIT_LOG", sdk.NewAccountObjectIdentifier("A"), true},
+		{"analytics.public.keep_?", sdk.NewAccountObjectIdentifier("ANALYTICS"), true},
+		{"analytics.public.keep_?", sdk.NewDatabaseObjectIdentifier("ANALYTICS", "PUBLIC"), true},
+		{"analytics.public.keep_?", sdk.NewDatabaseObjectIdentifier("ANALYTICS", "STAGING"), false},
+		{"analytics.public.keep_?", sdk.NewSchemaObjectIdentifier("ANALYTICS", "PUBLIC", "KEEP_1"), true},
+		{"analytics.public.keep_?", sdk.NewSchemaObjectIdentifier("ANALYTICS", "PUBLIC", "KEEP_10"), false},
+		{"analytics.public.keep_?", sdk.NewAccountObjectIdentifier("OTHER"), false},
+		{"WH", sdk.NewAccountObjectIdentifier("WH"), true},
+		{"WH", sdk.NewDatabaseObjectIdentifier("WH", "PUBLIC"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+" "+FullyQualifiedName(test.id), func(t *testing.T) {
+			safeguards := &Safeguards{DenyDrop: []string{test.pattern}}
+			if err := safeguards.validate(); err != nil {
+				t.Fatal(err)
+			}
+			err := safeguards.CheckDrop(test.id)
+			if refused := err != nil; refused != test.refused {
+				t.Errorf("got refused %t, want %t (%v)", refused, test.refused, err)
+			}
+		})
+	}
+}
+
+func TestCheckDropWithoutSafeguards(t *testing.T) {
+	var safeguards *Safeguards
+	if err := safeguards.CheckDrop(sdk.NewAccountObjectIdentifier("PROD")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateDenyDrop(t *testing.T) {
+	for _, pattern := range []string{"", "PROD.", ".PUBLIC", "PROD..T"} {
+		t.Run(pattern, func(t *testing.T) {
+			if err := (&Safeguards{DenyDrop: []string{pattern}}).validate(); err == nil {
+				t.Errorf("pattern %q was accepted", pattern)
+			}
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "A.B/C", true},
+		{"A*C", "ABBC", true},
+		{"A*C", "ABBD", false},
+		{"a?c", "ABC", true},
+		{"?", "É", true},
+		{"*.LOG", "A.B.LOG", true},
+		{"*X*Y", "AXBXCY", true},
+		{"[A]", "A", false},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.name, func(t *testing.T) {
+			if got := matchPattern(test.pattern, test.name); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Schemas interface {
+	Show(ctx context.Context, opts *ShowSchemaOptions) ([]sdk.Schema, error)
+	Drop(ctx context.Context, id sdk.DatabaseObjectIdentifier, opts *DropSchemaOptions) error
+}
+
+type schemas struct {
+	client *Client
+}
+
+type ShowSchemaOptions struct {
+	Database *sdk.AccountObjectIdentifier
+}
+
+func (c *schemas) Show(ctx context.Context, opts *ShowSchemaOptions) ([]sdk.Schema, error) {
+	sdkOpts := &sdk.ShowSchemaOptions{}
+	if opts != nil && opts.Database != nil {
+		sdkOpts.In = &sdk.SchemaIn{Database: sdk.Bool(true), Name: *opts.Database}
+	}
+
+	return c.client.SDKClient.Schemas.Show(ctx, sdkOpts)
+}
+
+type DropSchemaOptions struct {
+	IfExists bool
+}
+
+func (c *schemas) Drop(ctx context.Context, id sdk.DatabaseObjectIdentifier, opts *DropSchemaOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropSchemaOptions{}
+	}
+
+	query, err := newStatement("DROP", "SCHEMA").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
@@ -3,7 +3,6 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -37,18 +36,25 @@ type ShowSecretsOptions struct {
 }
 
 func (s *secrets) Show(ctx context.Context, opts *ShowSecretsOptions) ([]Secret, error) {
-	query := "SHOW SECRETS"
-	if opts.Database != nil {
-		query = fmt.Sprintf("SHOW SECRETS IN DATABASE %s", opts.Database.FullyQualifiedName())
+	if opts == nil {
+		opts = &ShowSecretsOptions{}
 	}
-	if opts.Schema != nil {
-		query = fmt.Sprintf("SHOW SECRETS IN SCHEMA %s", opts.Schema.FullyQualifiedName())
-	}
-	if opts.Application != nil {
-		query = fmt.Sprintf("SHOW SECRETS IN APPLICATION %s", opts.Application.FullyQualifiedName())
+
+	// applications and packages win over the most specific scope
+	stmt := newStatement("SHOW", "SECRETS")
+	switch {
+	case opts.ApplicationPackage != nil:
+		stmt.in("APPLICATION PACKAGE", opts.ApplicationPackage)
+	case opts.Application != nil:
+		stmt.in("APPLICATION", opts.Application)
+	case opts.Schema != nil:
+		stmt.in("SCHEMA", opts.Schema)
+	case opts.Database != nil:
+		stmt.in("DATABASE", opts.Database)
 	}
-	if opts.Application != nil {
-		query = fmt.Sprintf("SHOW SECRETS IN APPLICATION PACKAGE %s", opts.ApplicationPackage.FullyQualifiedName())
+	query, err := stmt.build()
+	if err != nil {
+		return nil, err
 	}
 
 	rows, err := s.client.SDKClient.GetConn().QueryContext(ctx, query)
@@ -76,10 +82,20 @@ type DropSecretsOptions struct {
 }
 
 func (s *secrets) Drop(ctx context.Context, opts *DropSecretsOptions) error {
-	query := fmt.Sprintf("DROP SECRET %s", opts.Secret.FullyQualifiedName())
-	if opts.IfExists {
-		query = fmt.Sprintf("DROP SECRET IF EXISTS %s", opts.Secret.FullyQualifiedName())
+	if opts.Secret != nil {
+		if err := s.client.safeguards.CheckDrop(opts.Secret); err != nil {
+			return err
+		}
 	}
-	_, err := s.client.SDKClient.GetConn().ExecContext(ctx, query)
+
+	query, err := newStatement("DROP", "SECRET").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(opts.Secret).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
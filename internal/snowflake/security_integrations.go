@@ -0,0 +1,44 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type SecurityIntegrations interface {
+	Show(ctx context.Context) ([]sdk.SecurityIntegration, error)
+	Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropSecurityIntegrationOptions) error
+}
+
+type securityintegrations struct {
+	client *Client
+}
+
+func (c *securityintegrations) Show(ctx context.Context) ([]sdk.SecurityIntegration, error) {
+	return c.client.SDKClient.SecurityIntegrations.Show(ctx, sdk.NewShowSecurityIntegrationRequest())
+}
+
+type DropSecurityIntegrationOptions struct {
+	IfExists bool
+}
+
+func (c *securityintegrations) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropSecurityIntegrationOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropSecurityIntegrationOptions{}
+	}
+
+	query, err := newStatement("DROP", "SECURITY INTEGRATION").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
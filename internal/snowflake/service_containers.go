@@ -2,7 +2,6 @@ package snowflake
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -31,7 +30,11 @@ type ServiceContainer struct {
 
 // https://docs.snowflake.com/en/sql-reference/sql/show-service-containers-in-service
 func (s *servicecontainers) Show(ctx context.Context, id *sdk.SchemaObjectIdentifier) ([]ServiceContainer, error) {
-	rows, err := s.client.SDKClient.GetConn().Query(fmt.Sprintf("SHOW SERVICE CONTAINERS IN SERVICE %s", id.FullyQualifiedName()))
+	stmt, err := newStatement("SHOW", "SERVICE CONTAINERS").in("SERVICE", id).build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -5,7 +5,6 @@ package snowflake
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -31,7 +30,11 @@ type ServiceInstance struct {
 
 // https://docs.snowflake.com/en/sql-reference/sql/show-service-containers-in-service
 func (s *serviceinstances) Show(ctx context.Context, id *sdk.SchemaObjectIdentifier) ([]ServiceInstance, error) {
-	rows, err := s.client.SDKClient.GetConn().Query(fmt.Sprintf("SHOW SERVICE INSTANCES IN SERVICE %s", id.FullyQualifiedName()))
+	stmt, err := newStatement("SHOW", "SERVICE INSTANCES").in("SERVICE", id).build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.client.SDKClient.GetConn().QueryContext(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
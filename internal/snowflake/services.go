@@ -14,6 +14,7 @@ type Services interface {
 	Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropServiceOptions) error
 	Describe(ctx context.Context, id sdk.SchemaObjectIdentifier) (*ServiceDetails, error)
 	Alter(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *AlterServiceOptions) error
+	Logs(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *ServiceLogsOptions) (string, error)
 }
 
 type services struct {
@@ -112,24 +113,42 @@ type CreateServiceOptions struct {
 	Comment                    string
 }
 
+// https://docs.snowflake.com/en/sql-reference/sql/create-service
 func (s *services) Create(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *CreateServiceOptions) error {
-	createComputePoolTemplate := fmt.Sprintf(`
-	CREATE SERVICE {{if .IfNotExists}}IF NOT EXISTS{{end}} %s
-	    IF COMPUTE POOL {{ .ComputePool }}
-	    {{ if .ExternalAccessIntegrations }}EXTERNAL_ACCESS_INTEGRATIONS = ({{ range .ExternalAccessIntegrations }}{{ .Name }},{{ end }}){{ end }}
-		AUTO_RESUME = {{ .AutoResume }}
-		MIN_INSTANCES = {{ .MinInstances }}
-		MAX_INSTANCES = {{ .MaxInstances }}
-		{{ if .QueryWarehouse }}QUERY_WAREHOUSE = {{ .QueryWarehouse }}{{ end }}
-		{{ if .Comment }}COMMENT = '{{ .Comment }}'{{ end }};
-	`, id.FullyQualifiedName())
-	stmt := templateToQuery(createComputePoolTemplate, opts)
-	_, err := s.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	if opts == nil {
+		opts = &CreateServiceOptions{}
+	}
+	if opts.ServiceManifest == nil {
+		return fmt.Errorf("creating service %s without a specification", id.Name())
+	}
+
+	stmt := newStatement("CREATE", "SERVICE").
+		keywordIf(opts.IfNotExists, "IF NOT EXISTS").
+		identifier(id).
+		in("COMPUTE POOL", opts.ComputePool).
+		keyword("FROM", "SPECIFICATION").
+		json(opts.ServiceManifest).
+		identifiersProperty("EXTERNAL_ACCESS_INTEGRATIONS", opts.ExternalAccessIntegrations).
+		boolProperty("AUTO_RESUME", &opts.AutoResume)
+	if opts.MinInstances > 0 {
+		stmt.intProperty("MIN_INSTANCES", &opts.MinInstances)
+	}
+	if opts.MaxInstances > 0 {
+		stmt.intProperty("MAX_INSTANCES", &opts.MaxInstances)
+	}
+	if opts.QueryWarehouse.Name() != "" {
+		stmt.identifierProperty("QUERY_WAREHOUSE", &opts.QueryWarehouse)
+	}
+	if opts.Comment != "" {
+		stmt.stringProperty("COMMENT", &opts.Comment)
+	}
+	query, err := stmt.build()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
 }
 
 type Service struct {
@@ -160,22 +179,26 @@ type ShowServiceOptions struct {
 }
 
 func (s *services) Show(ctx context.Context, opts *ShowServiceOptions) ([]Service, error) {
-	query := "SHOW SERVICES"
-	if opts.ComputePool != nil {
-		query = fmt.Sprintf("SHOW SERVICES IN COMPUTE POOL %s", opts.ComputePool.FullyQualifiedName())
-	}
-	if opts.Database != nil {
-		query = fmt.Sprintf("SHOW SERVICES IN DATABASE %s", opts.Database.FullyQualifiedName())
-	}
-	if opts.Schema != nil {
-		query = fmt.Sprintf("SHOW SERVICES IN SCHEMA %s", opts.Schema.FullyQualifiedName())
+	if opts == nil {
+		opts = &ShowServiceOptions{}
 	}
 
-	if query == "" {
-		return nil, fmt.Errorf("one of the show users options must be not nil")
+	// the most specific scope wins
+	stmt := newStatement("SHOW", "SERVICES")
+	switch {
+	case opts.Schema != nil:
+		stmt.in("SCHEMA", opts.Schema)
+	case opts.Database != nil:
+		stmt.in("DATABASE", opts.Database)
+	case opts.ComputePool != nil:
+		stmt.in("COMPUTE POOL", opts.ComputePool)
+	}
+	query, err := stmt.build()
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err := s.client.SDKClient.GetConn().Query(query)
+	rows, err := s.client.SDKClient.GetConn().QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -199,9 +222,22 @@ type DropServiceOptions struct {
 }
 
 func (s *services) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropServiceOptions) error {
-	template := fmt.Sprintf("DROP SERVICE {{ if .IfExists }}IF EXISTS{{ end}} %s", id.FullyQualifiedName())
-	stmt := templateToQuery(template, opts)
-	_, err := s.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	if err := s.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropServiceOptions{}
+	}
+
+	query, err := newStatement("DROP", "SERVICE").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
 
@@ -226,10 +262,13 @@ type ServiceDetails struct {
 }
 
 func (s *services) Describe(ctx context.Context, id sdk.SchemaObjectIdentifier) (*ServiceDetails, error) {
-	stmt := fmt.Sprintf("DESCRIBE SERVICE %s", id.FullyQualifiedName())
+	stmt, err := newStatement("DESCRIBE", "SERVICE").identifier(id).build()
+	if err != nil {
+		return nil, err
+	}
 	var describeServiceResult ServiceDetails
 
-	err := s.client.SDKClient.GetConn().QueryRow(stmt).Scan(&describeServiceResult.Name, &describeServiceResult.DatabaseName, &describeServiceResult.SchemaName, &describeServiceResult.Owner, &describeServiceResult.ComputePool, &describeServiceResult.DNSName, &describeServiceResult.MinInstances, &describeServiceResult.MaxInstances, &describeServiceResult.AutoResume, &describeServiceResult.ExternalAccessIntegration, &describeServiceResult.CreatedOn, &describeServiceResult.UpdatedOn, &describeServiceResult.ResumedOn, &describeServiceResult.Comment, &describeServiceResult.OwnerRoleType, &describeServiceResult.QueryWarehouse, &describeServiceResult.IsJob)
+	err = s.client.SDKClient.GetConn().QueryRowContext(ctx, stmt).Scan(&describeServiceResult.Name, &describeServiceResult.DatabaseName, &describeServiceResult.SchemaName, &describeServiceResult.Owner, &describeServiceResult.ComputePool, &describeServiceResult.DNSName, &describeServiceResult.MinInstances, &describeServiceResult.MaxInstances, &describeServiceResult.AutoResume, &describeServiceResult.ExternalAccessIntegration, &describeServiceResult.CreatedOn, &describeServiceResult.UpdatedOn, &describeServiceResult.ResumedOn, &describeServiceResult.Comment, &describeServiceResult.OwnerRoleType, &describeServiceResult.QueryWarehouse, &describeServiceResult.IsJob)
 	if err != nil {
 		return nil, err
 	}
@@ -252,17 +291,38 @@ func (c *services) Alter(ctx context.Context, id sdk.SchemaObjectIdentifier, opt
 		opts = &AlterServiceOptions{}
 	}
 
-	alterServiceTemplate := fmt.Sprintf(`
-	ALTER SERVICE {{ if .IfExists }}IF EXISTS{{ end }} %s
-	     SET
-		 {{ if .MinInstances }}MIN_INSTANCES = {{ .MinInstances }}{{ end }}
-		 {{ if .MaxInstances }}MAX_INSTANCES = {{ .MaxInstances }}{{ end }}
-		 {{ if .QueryWarehouse }}QUERY_WAREHOUSE = {{ .QueryWarehouse }}{{ end }}
-		 {{ if .AutoResume }}AUTO_RESUME = {{ .AutoResume }}{{ end }}
-		 {{ if .ExternalAccessIntegration }}EXTERNAL_ACCESS_INTEGRATIONS = ({{ range .ExternalAccessIntegration }}{{ .Name }},{{ end }}){{ end }}
-		 {{ if .Comment }}COMMENT = '{{ .Comment }}'{{ end }};
-	`, id.FullyQualifiedName())
-	stmt := templateToQuery(alterServiceTemplate, opts)
-	_, err := c.client.SDKClient.GetConn().ExecContext(ctx, stmt)
+	query, err := newStatement("ALTER", "SERVICE").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		setProperties().
+		intProperty("MIN_INSTANCES", opts.MinInstances).
+		intProperty("MAX_INSTANCES", opts.MaxInstances).
+		identifierProperty("QUERY_WAREHOUSE", opts.QueryWarehouse).
+		boolProperty("AUTO_RESUME", opts.AutoResume).
+		identifiersProperty("EXTERNAL_ACCESS_INTEGRATIONS", opts.ExternalAccessIntegration).
+		stringProperty("COMMENT", opts.Comment).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
+
+type ServiceLogsOptions struct {
+	InstanceId    int
+	ContainerName string
+}
+
+// https://docs.snowflake.com/en/sql-reference/functions/system_get_service_logs
+func (c *services) Logs(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *ServiceLogsOptions) (string, error) {
+	var serviceLogs string
+	query := fmt.Sprintf("SELECT SYSTEM$GET_SERVICE_LOGS(%s, %d, %s)", QuoteString(FullyQualifiedName(id)), opts.InstanceId, QuoteString(opts.ContainerName))
+	err := c.client.SDKClient.GetConn().QueryRowContext(ctx, query).Scan(&serviceLogs)
+	if err != nil {
+		return "", err
+	}
+
+	return serviceLogs, nil
+}
@@ -0,0 +1,47 @@
+package snowflake
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SessionRegistry holds the connection managers of the open sessions, each
+// with its own client, sharing the configuration of the first one
+type SessionRegistry struct {
+	sessions []*ConnectionManager
+}
+
+// NewSessionRegistry returns a registry holding the connection manager
+func NewSessionRegistry(cm *ConnectionManager) *SessionRegistry {
+	return &SessionRegistry{sessions: []*ConnectionManager{cm}}
+}
+
+// Open connects a new session to the named connection, see SetClient
+func (r *SessionRegistry) Open(name string) (*ConnectionManager, error) {
+	if len(r.sessions) == 0 {
+		return nil, fmt.Errorf("session registry is closed")
+	}
+
+	cm := &ConnectionManager{connectionConfig: r.sessions[0].connectionConfig}
+	if err := cm.SetClient(name); err != nil {
+		return nil, err
+	}
+	r.sessions = append(r.sessions, cm)
+	return cm, nil
+}
+
+// Close closes the client of the session and removes it from the registry
+func (r *SessionRegistry) Close(cm *ConnectionManager) {
+	cm.Close()
+	r.sessions = slices.DeleteFunc(r.sessions, func(session *ConnectionManager) bool {
+		return session == cm
+	})
+}
+
+// CloseAll closes the clients of every session
+func (r *SessionRegistry) CloseAll() {
+	for _, cm := range r.sessions {
+		cm.Close()
+	}
+	r.sessions = nil
+}
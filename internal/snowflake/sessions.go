@@ -0,0 +1,94 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Sessions interface {
+	UseRole(ctx context.Context, id sdk.AccountObjectIdentifier) error
+	UseWarehouse(ctx context.Context, id sdk.AccountObjectIdentifier) error
+	UseDatabase(ctx context.Context, id sdk.AccountObjectIdentifier) error
+	UseSchema(ctx context.Context, id sdk.DatabaseObjectIdentifier) error
+	Current(ctx context.Context) (*SessionDetails, error)
+}
+
+type sessions struct {
+	client *Client
+}
+
+func (s *sessions) UseRole(ctx context.Context, id sdk.AccountObjectIdentifier) error {
+	query, err := newStatement("USE", "ROLE").identifier(id).build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
+
+func (s *sessions) UseWarehouse(ctx context.Context, id sdk.AccountObjectIdentifier) error {
+	query, err := newStatement("USE", "WAREHOUSE").identifier(id).build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
+
+func (s *sessions) UseDatabase(ctx context.Context, id sdk.AccountObjectIdentifier) error {
+	query, err := newStatement("USE", "DATABASE").identifier(id).build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
+
+func (s *sessions) UseSchema(ctx context.Context, id sdk.DatabaseObjectIdentifier) error {
+	query, err := newStatement("USE", "SCHEMA").identifier(id).build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
+
+type SessionDetails struct {
+	Database  string
+	Schema    string
+	Warehouse string
+	Role      string
+
+	// Account Locator https://docs.snowflake.com/en/user-guide/admin-account-identifier#format-2-account-locator-in-a-region
+	Account string
+	// Account Name within Organization https://docs.snowflake.com/en/user-guide/admin-account-identifier#format-1-preferred-account-name-in-your-organization
+	AccountName      string
+	OrganizationName string
+	Region           string
+}
+
+func (s *sessions) Current(ctx context.Context) (*SessionDetails, error) {
+	var database, schema, warehouse, role, account, accountName, organizationName, region sql.NullString
+
+	err := s.client.SDKClient.GetConn().QueryRowContext(ctx, "SELECT CURRENT_DATABASE(), CURRENT_SCHEMA(), CURRENT_WAREHOUSE(), CURRENT_ROLE(), CURRENT_ACCOUNT(), CURRENT_ACCOUNT_NAME(), CURRENT_ORGANIZATION_NAME(), CURRENT_REGION();").Scan(&database, &schema, &warehouse, &role, &account, &accountName, &organizationName, &region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionDetails{
+		Database:         database.String,
+		Schema:           schema.String,
+		Warehouse:        warehouse.String,
+		Role:             role.String,
+		Account:          account.String,
+		AccountName:      accountName.String,
+		OrganizationName: organizationName.String,
+		Region:           region.String,
+	}, nil
+}
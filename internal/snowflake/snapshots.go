@@ -3,7 +3,6 @@ package snowflake
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
 	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
@@ -39,14 +38,23 @@ type ShowSnapshotOptions struct {
 }
 
 func (s *snapshots) Show(ctx context.Context, opts *ShowSnapshotOptions) ([]Snapshot, error) {
-	query := "SHOW SNAPSHOTS"
-	if opts.Database != nil {
-		query = fmt.Sprintf("SHOW SNAPSHOTS IN DATABASE %s", opts.Database.FullyQualifiedName())
+	if opts == nil {
+		opts = &ShowSnapshotOptions{}
 	}
-	if opts.Schema != nil {
-		query = fmt.Sprintf("SHOW SNAPSHOTS IN SCHEMA %s", opts.Schema.FullyQualifiedName())
+
+	// the most specific scope wins
+	stmt := newStatement("SHOW", "SNAPSHOTS")
+	switch {
+	case opts.Schema != nil:
+		stmt.in("SCHEMA", opts.Schema)
+	case opts.Database != nil:
+		stmt.in("DATABASE", opts.Database)
 	}
-	rows, err := s.client.SDKClient.GetConn().Query(query)
+	query, err := stmt.build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.client.SDKClient.GetConn().QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +79,20 @@ type DropSnapshotOptions struct {
 }
 
 func (s *snapshots) Drop(ctx context.Context, opts *DropSnapshotOptions) error {
-	query := fmt.Sprintf("DROP SNAPSHOT %s", opts.Snapshot.FullyQualifiedName())
-	if opts.IfExists {
-		query = fmt.Sprintf("DROP SNAPSHOT IF EXISTS %s", opts.Snapshot.FullyQualifiedName())
+	if opts.Snapshot != nil {
+		if err := s.client.safeguards.CheckDrop(opts.Snapshot); err != nil {
+			return err
+		}
 	}
-	_, err := s.client.SDKClient.GetConn().ExecContext(ctx, query)
+
+	query, err := newStatement("DROP", "SNAPSHOT").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(opts.Snapshot).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SDKClient.GetConn().ExecContext(ctx, query)
 	return err
 }
@@ -1,143 +1,400 @@
 package snowsql
 
 import (
-	"cmp"
 	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
-	"os/user"
 	"path/filepath"
+	"strings"
+	"time"
 
 	sf "github.com/snowflakedb/gosnowflake"
 	"gopkg.in/ini.v1"
+
+	"github.com/costrouc/snowctl/internal/snowflake/configuration"
 )
 
 type Connection struct {
-	AccountName   string `ini:"accountname"`
-	Region        string `ini:"region"`
-	Username      string `ini:"username"`
-	Password      string `ini:"password"`
-	DBName        string `ini:"dbname"`
-	SchemaName    string `ini:"schemaname"`
-	WarehouseName string `ini:"warehousename"`
-	RoleName      string `ini:"rolename"`
-	ProxyHost     string `ini:"proxyhost"`
-	ProxyPort     string `ini:"proxyport"`
-}
-
-func (c *Connection) SnowflakeConfig() *sf.Config {
-	return &sf.Config{
-		Account:   c.AccountName,
-		Region:    c.Region,
-		User:      c.Username,
-		Password:  c.Password,
-		Database:  c.DBName,
-		Schema:    c.SchemaName,
-		Warehouse: c.WarehouseName,
-		Role:      c.RoleName,
+	AccountName    string `ini:"accountname"`
+	Region         string `ini:"region"`
+	Host           string `ini:"host"`
+	Port           int    `ini:"port"`
+	Protocol       string `ini:"protocol"`
+	Username       string `ini:"username"`
+	Password       string `ini:"password"`
+	DBName         string `ini:"dbname"`
+	SchemaName     string `ini:"schemaname"`
+	WarehouseName  string `ini:"warehousename"`
+	RoleName       string `ini:"rolename"`
+	Authenticator  string `ini:"authenticator"`
+	PrivateKeyPath string `ini:"private_key_path"`
+	ProxyHost      string `ini:"proxyhost"`
+	ProxyPort      string `ini:"proxyport"`
+	ProxyUser      string `ini:"proxyuser"`
+	ProxyPassword  string `ini:"proxypassword"`
+
+	// PrivateKeyPassphrase is only read from the environment like snowsql
+	// does, PRIVATE_KEY_PASSPHRASE
+	PrivateKeyPassphrase string `ini:"-"`
+
+	// Options are the ones of the [options] section shared by every
+	// connection of the file
+	Options Options `ini:"-"`
+
+	// Name is the name of the connection, default for the [connections]
+	// section, and Path the last file with its section
+	Name string `ini:"-"`
+	Path string `ini:"-"`
+
+	// Sources maps the keys set on the connection to the file or the
+	// environment variable they were read from
+	Sources map[string]string `ini:"-"`
+}
+
+// EditableKeys are the keys of a connection edited from the connections
+// view, in the order they are written to new connections
+var EditableKeys = []string{
+	"accountname",
+	"username",
+	"password",
+	"authenticator",
+	"rolename",
+	"warehousename",
+	"dbname",
+	"schemaname",
+	"region",
+	"host",
+	"private_key_path",
+}
+
+// Value returns the value of a string key of the connection
+func (c *Connection) Value(key string) string {
+	switch key {
+	case "accountname":
+		return c.AccountName
+	case "region":
+		return c.Region
+	case "host":
+		return c.Host
+	case "protocol":
+		return c.Protocol
+	case "username":
+		return c.Username
+	case "password":
+		return c.Password
+	case "dbname":
+		return c.DBName
+	case "schemaname":
+		return c.SchemaName
+	case "warehousename":
+		return c.WarehouseName
+	case "rolename":
+		return c.RoleName
+	case "authenticator":
+		return c.Authenticator
+	case "private_key_path":
+		return c.PrivateKeyPath
+	case "proxyhost":
+		return c.ProxyHost
+	case "proxyport":
+		return c.ProxyPort
+	case "proxyuser":
+		return c.ProxyUser
+	case "proxypassword":
+		return c.ProxyPassword
 	}
+	return ""
 }
 
-func getConfigurationPaths() []string {
-	usr, _ := user.Current()
-	homedir := usr.HomeDir
+// Options are the settings of the [options] section that apply to the
+// connection, the ones about the snowsql shell are ignored
+type Options struct {
+	LoginTimeout           int   `ini:"login_timeout"`
+	ClientSessionKeepAlive bool  `ini:"client_session_keep_alive"`
+	InsecureMode           bool  `ini:"insecure_mode"`
+	OCSPFailOpen           *bool `ini:"ocsp_fail_open"`
+}
 
-	return []string{
-		"/etc/snowsql.cnf",
-		"/etc/snowflake/snowsql.cnf",
-		"/usr/local/etc/snowsql.cnf",
-		filepath.Join(homedir, ".snowsql.cnf"),
-		filepath.Join(homedir, ".snowsql/config"),
+// Settings returns the configuration of the connection, as listed in the
+// connections view
+func (c *Connection) Settings() *sf.Config {
+	return &sf.Config{
+		Account:          c.AccountName,
+		Region:           c.Region,
+		Host:             c.Host,
+		Port:             c.Port,
+		Protocol:         c.Protocol,
+		User:             c.Username,
+		Password:         c.Password,
+		Database:         c.DBName,
+		Schema:           c.SchemaName,
+		Warehouse:        c.WarehouseName,
+		Role:             c.RoleName,
+		LoginTimeout:     time.Duration(c.Options.LoginTimeout) * time.Second,
+		KeepSessionAlive: c.Options.ClientSessionKeepAlive,
+		InsecureMode:     c.Options.InsecureMode,
 	}
 }
 
-func ReadConfig() (map[string]*Connection, error) {
-	var connections map[string]*Connection
+// SnowflakeConfig returns the configuration of the connection with the
+// credentials of its authenticator and the transport of its proxy
+func (c *Connection) SnowflakeConfig() (*sf.Config, error) {
+	config := c.Settings()
 
-	paths := getConfigurationPaths()
+	if c.Options.OCSPFailOpen != nil {
+		config.OCSPFailOpen = sf.OCSPFailOpenFalse
+		if *c.Options.OCSPFailOpen {
+			config.OCSPFailOpen = sf.OCSPFailOpenTrue
+		}
+	}
 
-	for _, path := range paths {
-		if stat, err := os.Stat(path); err == nil && !stat.IsDir() {
-			connections, err = readConfig(path)
-			if err != nil {
-				return nil, err
-			}
+	authenticator := c.AuthenticatorName()
+
+	switch {
+	case authenticator == "snowflake":
+		config.Authenticator = sf.AuthTypeSnowflake
+	case authenticator == "externalbrowser":
+		config.Authenticator = sf.AuthTypeExternalBrowser
+	case authenticator == "username_password_mfa":
+		config.Authenticator = sf.AuthTypeUsernamePasswordMFA
+	case authenticator == "snowflake_jwt":
+		if c.PrivateKeyPath == "" {
+			return nil, fmt.Errorf("snowflake_jwt authenticator needs private_key_path")
+		}
+		privateKey, err := configuration.LoadPrivateKey(c.PrivateKeyPath, c.PrivateKeyPassphrase)
+		if err != nil {
+			return nil, err
+		}
+		config.Authenticator = sf.AuthTypeJwt
+		config.PrivateKey = privateKey
+	case strings.HasPrefix(authenticator, "https://"):
+		oktaURL, err := url.Parse(c.Authenticator)
+		if err != nil {
+			return nil, fmt.Errorf("parsing okta authenticator %w", err)
 		}
+		config.Authenticator = sf.AuthTypeOkta
+		config.OktaURL = oktaURL
+	default:
+		return nil, fmt.Errorf("unknown authenticator %s, expected snowflake, externalbrowser, snowflake_jwt, username_password_mfa or an okta URL", c.Authenticator)
 	}
-	return connections, nil
+
+	if c.ProxyHost != "" {
+		transport, err := c.proxyTransport()
+		if err != nil {
+			return nil, err
+		}
+		config.Transporter = transport
+	}
+
+	return config, nil
 }
 
-func readSectionConnection(section *ini.Section) (*Connection, error) {
-	var connection Connection
-	err := section.MapTo(&connection)
+// AuthenticatorName returns the authenticator in lower case, snowflake_jwt
+// for a connection with a private key and snowflake when unset
+func (c *Connection) AuthenticatorName() string {
+	authenticator := strings.ToLower(strings.TrimSpace(c.Authenticator))
+	if authenticator == "" && c.PrivateKeyPath != "" {
+		return "snowflake_jwt"
+	}
+	if authenticator == "" {
+		return "snowflake"
+	}
+	return authenticator
+}
+
+// File returns the file the connection was read from
+func (c *Connection) File() string {
+	return c.Path
+}
+
+// proxyTransport returns the transport of the driver going through the
+// proxy of the connection instead of the one of the environment
+func (c *Connection) proxyTransport() (*http.Transport, error) {
+	host := c.ProxyHost
+	if c.ProxyPort != "" {
+		host = net.JoinHostPort(c.ProxyHost, c.ProxyPort)
+	}
+	proxyURL, err := url.Parse("http://" + host)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("parsing proxy %s %w", host, err)
+	}
+	if c.ProxyUser != "" {
+		proxyURL.User = url.UserPassword(c.ProxyUser, c.ProxyPassword)
 	}
 
-	connection.AccountName = cmp.Or(os.Getenv("SNOWSQL_ACCOUNT"), connection.AccountName)
-	connection.Region = cmp.Or(os.Getenv("SNOWSQL_REGION"), connection.Region)
-	connection.Username = cmp.Or(os.Getenv("SNOWSQL_USER"), connection.Username)
-	connection.Password = cmp.Or(os.Getenv("SNOWSQL_PWD"), connection.Password)
-	connection.DBName = cmp.Or(os.Getenv("SNOWSQL_DATABASE"), connection.DBName)
-	connection.SchemaName = cmp.Or(os.Getenv("SNOWSQL_SCHEMA"), connection.SchemaName)
-	connection.WarehouseName = cmp.Or(os.Getenv("SNOWSQL_WAREHOUSE"), connection.WarehouseName)
-	connection.RoleName = cmp.Or(os.Getenv("SNOWSQL_ROLE"), connection.RoleName)
-	connection.ProxyHost = cmp.Or(os.Getenv("PROXY_HOST"), connection.ProxyHost)
-	connection.ProxyPort = cmp.Or(os.Getenv("PROXY_PORT"), connection.ProxyPort)
+	transport := sf.SnowflakeTransport.Clone()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	if c.Options.InsecureMode {
+		// the driver skips the revocation check only for its own transport
+		transport.TLSClientConfig.VerifyPeerCertificate = nil
+	}
+	return transport, nil
+}
 
-	return &connection, nil
+// getConfigurationPaths returns the files snowsql reads, lowest precedence
+// first
+func getConfigurationPaths() []string {
+	paths := []string{
+		"/etc/snowsql.cnf",
+		"/etc/snowflake/snowsql.cnf",
+		"/usr/local/etc/snowsql.cnf",
+	}
+	if homedir, err := os.UserHomeDir(); err == nil {
+		paths = append(
+			paths,
+			filepath.Join(homedir, ".snowsql.cnf"),
+			filepath.Join(homedir, ".snowsql/config"),
+		)
+	}
+	return paths
 }
 
-func readConfig(path string) (map[string]*Connection, error) {
-	connections := make(map[string]*Connection, 0)
+// Config is the configuration merged from every snowsql configuration file
+type Config struct {
+	Connections map[string]*Connection
 
-	cfg, err := ini.InsensitiveLoad(path)
-	if err != nil {
-		return nil, fmt.Errorf("reading init file %s %w", path, err)
+	// Files are the files searched, lowest precedence first
+	Files []configuration.File
+}
+
+// optionKeys are the keys of the [options] section read by Options
+var optionKeys = []string{"login_timeout", "client_session_keep_alive", "insecure_mode", "ocsp_fail_open"}
+
+// Discover reads every snowsql configuration file, the keys of later files
+// overriding the ones of earlier files as snowsql does, then the
+// environment overrides
+func Discover() (*Config, error) {
+	config := &Config{Connections: map[string]*Connection{}}
+
+	// sources maps each section and key to the last file setting it and
+	// paths each section to the last file with it
+	sources := map[string]map[string]string{}
+	paths := map[string]string{}
+	found := make([]any, 0)
+	for _, path := range getConfigurationPaths() {
+		stat, err := os.Stat(path)
+		exists := err == nil && !stat.IsDir()
+		config.Files = append(config.Files, configuration.File{Path: path, Found: exists})
+		if !exists {
+			continue
+		}
+
+		cfg, err := ini.InsensitiveLoad(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading ini file %s %w", path, err)
+		}
+		for _, section := range cfg.Sections() {
+			if sources[section.Name()] == nil {
+				sources[section.Name()] = map[string]string{}
+			}
+			paths[section.Name()] = path
+			for _, key := range section.KeyStrings() {
+				sources[section.Name()][key] = path
+			}
+		}
+		found = append(found, path)
+	}
+	if len(found) == 0 {
+		return config, nil
 	}
 
-	sections := []string{""}
-	section, err := cfg.GetSection("connections")
+	cfg, err := ini.InsensitiveLoad(found[0], found[1:]...)
 	if err != nil {
-		return connections, nil
-	}
-
-	ignoredNames := map[string]bool{
-		"accountname":   true,
-		"region":        true,
-		"username":      true,
-		"password":      true,
-		"dbname":        true,
-		"schemaname":    true,
-		"warehousename": true,
-		"rolename":      true,
-		"proxyhost":     true,
-		"proxyport":     true,
-	}
-	for _, key := range section.KeyStrings() {
-		if !ignoredNames[key] {
-			sections = append(sections, key)
+		return nil, fmt.Errorf("reading ini files %w", err)
+	}
+
+	var options Options
+	if section, err := cfg.GetSection("options"); err == nil {
+		if err := section.MapTo(&options); err != nil {
+			return nil, fmt.Errorf("reading section options %w", err)
 		}
 	}
 
-	for _, name := range sections {
-		sectionName := "connections"
-		connectionName := "default"
-		if name != "" {
-			sectionName = fmt.Sprintf("connections.%s", name)
-			connectionName = name
+	// [connections] is the default connection and [connections.NAME] the
+	// named ones, which inherit its keys
+	for _, section := range cfg.Sections() {
+		name, ok := connectionName(section)
+		if !ok {
+			continue
 		}
 
-		section, err = cfg.GetSection(sectionName)
+		connection, err := readSectionConnection(section, options)
 		if err != nil {
-			return connections, nil
+			return nil, fmt.Errorf("reading section %s %w", section.Name(), err)
 		}
 
-		connection, err := readSectionConnection(section)
-		if err != nil {
-			return nil, fmt.Errorf("reading section %s %w", sectionName, err)
+		for _, key := range optionKeys {
+			if source, ok := sources["options"][key]; ok {
+				connection.Sources[key] = source
+			}
 		}
-		connections[connectionName] = connection
+		maps.Copy(connection.Sources, sources["connections"])
+		maps.Copy(connection.Sources, sources[section.Name()])
+		connection.applyEnvironment()
+
+		connection.Name = name
+		connection.Path = paths[section.Name()]
+		config.Connections[name] = connection
+	}
+
+	return config, nil
+}
+
+// connectionName returns the name of the connection of the section, the
+// [connections] section is only a connection when it sets keys
+func connectionName(section *ini.Section) (string, bool) {
+	if section.Name() == "connections" {
+		return "default", len(section.Keys()) > 0
 	}
+	return strings.CutPrefix(section.Name(), "connections.")
+}
 
-	return connections, nil
+// ReadConfig returns the connections by name
+func ReadConfig() (map[string]*Connection, error) {
+	config, err := Discover()
+	if err != nil {
+		return nil, err
+	}
+	return config.Connections, nil
+}
+
+func readSectionConnection(section *ini.Section, options Options) (*Connection, error) {
+	connection := Connection{Options: options, Sources: map[string]string{}}
+	err := section.MapTo(&connection)
+	if err != nil {
+		return nil, err
+	}
+	return &connection, nil
+}
+
+// applyEnvironment overrides the keys of the connection by the snowsql
+// environment variables
+func (c *Connection) applyEnvironment() {
+	for _, field := range []struct {
+		variable string
+		key      string
+		value    *string
+	}{
+		{"SNOWSQL_ACCOUNT", "accountname", &c.AccountName},
+		{"SNOWSQL_REGION", "region", &c.Region},
+		{"SNOWSQL_USER", "username", &c.Username},
+		{"SNOWSQL_PWD", "password", &c.Password},
+		{"SNOWSQL_DATABASE", "dbname", &c.DBName},
+		{"SNOWSQL_SCHEMA", "schemaname", &c.SchemaName},
+		{"SNOWSQL_WAREHOUSE", "warehousename", &c.WarehouseName},
+		{"SNOWSQL_ROLE", "rolename", &c.RoleName},
+		{"PROXY_HOST", "proxyhost", &c.ProxyHost},
+		{"PROXY_PORT", "proxyport", &c.ProxyPort},
+		{"PROXY_USER", "proxyuser", &c.ProxyUser},
+		{"PROXY_PASSWORD", "proxypassword", &c.ProxyPassword},
+		{"PRIVATE_KEY_PASSPHRASE", "private_key_passphrase", &c.PrivateKeyPassphrase},
+	} {
+		if value := os.Getenv(field.variable); value != "" {
+			*field.value = value
+			c.Sources[field.key] = field.variable
+		}
+	}
 }
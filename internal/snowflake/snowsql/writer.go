@@ -0,0 +1,257 @@
+package snowsql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/costrouc/snowctl/internal/snowflake/configuration"
+)
+
+var (
+	iniHeader = regexp.MustCompile(`^\s*\[([^\]]+)\]\s*([#;].*)?$`)
+	iniKey    = regexp.MustCompile(`^\s*([^\s=:#;\[][^=:]*?)\s*[=:]`)
+)
+
+// ConnectionsPath returns the file new connections are written to, the last
+// snowsql file of the home directory when one exists
+func ConnectionsPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("finding home directory %w", err)
+	}
+
+	path := filepath.Join(homedir, ".snowsql/config")
+	if _, err := os.Stat(path); err != nil {
+		if _, err := os.Stat(filepath.Join(homedir, ".snowsql.cnf")); err == nil {
+			return filepath.Join(homedir, ".snowsql.cnf"), nil
+		}
+	}
+	return path, nil
+}
+
+// SaveConnection sets the keys of the connection in the ini file, an empty
+// value removes the key and the section is added when missing. The other
+// lines of the file are kept as they are.
+func SaveConnection(path string, name string, values map[string]string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+
+	encoded := make(map[string]string, len(values))
+	for key, value := range values {
+		if encoded[key], err = iniValue(value); err != nil {
+			return fmt.Errorf("writing %s %w", key, err)
+		}
+	}
+
+	start, end, found := findSection(lines, sectionName(name))
+	if !found {
+		section := []string{"[" + sectionName(name) + "]"}
+		for _, key := range sortedKeys(values) {
+			if values[key] != "" {
+				section = append(section, key+" = "+encoded[key])
+			}
+		}
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		return configuration.WriteFile(path, joinLines(append(lines, section...)))
+	}
+
+	block := make([]string, 0, end-start)
+	written := map[string]bool{}
+	// last is the index in block after the last key of the section, new
+	// keys are written there rather than after the comments before the next
+	// section
+	last := 1
+	for i := start; i < end; i++ {
+		match := iniKey.FindStringSubmatch(lines[i])
+		if match == nil || i == start {
+			block = append(block, lines[i])
+			continue
+		}
+
+		key := strings.ToLower(match[1])
+		if _, edited := values[key]; edited {
+			written[key] = true
+			if values[key] != "" {
+				block = append(block, key+" = "+encoded[key])
+			}
+		} else {
+			block = append(block, lines[i])
+		}
+		last = len(block)
+	}
+
+	added := make([]string, 0)
+	for _, key := range sortedKeys(values) {
+		if !written[key] && values[key] != "" {
+			added = append(added, key+" = "+encoded[key])
+		}
+	}
+	block = slices.Insert(block, last, added...)
+
+	return configuration.WriteFile(path, joinLines(slices.Concat(lines[:start], block, lines[end:])))
+}
+
+// DeleteConnection removes the section of the connection from the ini file
+func DeleteConnection(path string, name string) error {
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+
+	start, end, found := findSection(lines, sectionName(name))
+	if !found {
+		return fmt.Errorf("connection %s has no section in %s", name, path)
+	}
+	if name == "default" {
+		// the snowsql files are merged, the sections of any of them inherit
+		if child := childSection(append(getConfigurationPaths(), path)); child != "" {
+			return fmt.Errorf("connection default cannot be deleted, connection %s inherits its keys", child)
+		}
+	}
+
+	// the comments right above the next section belong to it
+	for end > start && end < len(lines) && isComment(lines[end-1]) {
+		end--
+	}
+	for end < len(lines) && strings.TrimSpace(lines[end]) == "" {
+		end++
+	}
+	// so do the ones right above its header unless they start the file
+	above := start
+	for above > 0 && isComment(lines[above-1]) {
+		above--
+	}
+	if above > 0 {
+		start = above
+	}
+	if end == len(lines) {
+		for start > 0 && strings.TrimSpace(lines[start-1]) == "" {
+			start--
+		}
+	}
+
+	return configuration.WriteFile(path, joinLines(slices.Concat(lines[:start], lines[end:])))
+}
+
+// sectionName returns the section of the connection, [connections] for the
+// default one
+func sectionName(name string) string {
+	if name == "default" {
+		return "connections"
+	}
+	return "connections." + name
+}
+
+// childSection returns the name of the first [connections.NAME] section of
+// the files, these inherit the keys of [connections], unreadable files are
+// skipped as the file edited was read already
+func childSection(paths []string) string {
+	for _, path := range paths {
+		lines, err := readLines(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range lines {
+			match := iniHeader.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			if name, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(match[1])), "connections."); ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// findSection returns the lines of the section, from its header to the next
+// section, names are matched ignoring case as they are read
+func findSection(lines []string, name string) (int, int, bool) {
+	start := -1
+	for i, line := range lines {
+		match := iniHeader.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if start >= 0 {
+			return start, i, true
+		}
+		if strings.EqualFold(strings.TrimSpace(match[1]), name) {
+			start = i
+		}
+	}
+	return start, len(lines), start >= 0
+}
+
+// iniValue quotes the values that would be cut at a comment or trimmed
+func iniValue(value string) (string, error) {
+	if strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("values cannot span lines")
+	}
+	if !strings.ContainsAny(value, `#;"'`) && strings.TrimSpace(value) == value {
+		return value, nil
+	}
+	if !strings.Contains(value, `"`) {
+		return `"` + value + `"`, nil
+	}
+	if strings.Contains(value, `"""`) || strings.HasSuffix(value, `"`) {
+		return "", fmt.Errorf("value cannot be quoted")
+	}
+	return `"""` + value + `"""`, nil
+}
+
+func isComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";")
+}
+
+// sortedKeys orders the keys as EditableKeys then by name
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		i, j := slices.Index(EditableKeys, a), slices.Index(EditableKeys, b)
+		switch {
+		case i >= 0 && j >= 0:
+			return i - j
+		case i >= 0:
+			return -1
+		case j >= 0:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return keys
+}
+
+// readLines reads the lines of the file, none when it does not exist yet
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s file %w", path, err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"), nil
+}
+
+func joinLines(lines []string) []byte {
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
@@ -0,0 +1,107 @@
+package snowsql
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const snowsqlConfig = `[options]
+log_level = DEBUG
+
+; the default connection
+[connections]
+accountname = shared
+username = alice
+
+[connections.prod]
+accountname = prod
+
+# staging is a copy of prod
+[connections.staging]
+accountname = staging
+`
+
+// writeConfig writes the snowsql file of a temporary home directory
+func writeConfig(t *testing.T, content string, mode os.FileMode) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".snowsql", "config")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readConfig(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSaveConnection(t *testing.T) {
+	path := writeConfig(t, snowsqlConfig, 0o640)
+	err := SaveConnection(path, "prod", map[string]string{"rolename": "R", "password": "a#b", "accountname": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(snowsqlConfig, "[connections.prod]\naccountname = prod\n", "[connections.prod]\npassword = \"a#b\"\nrolename = R\n", 1)
+	if got := readConfig(t, path); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := stat.Mode().Perm(); mode != 0o640 {
+		t.Errorf("got mode %o, want 640", mode)
+	}
+}
+
+func TestDeleteConnection(t *testing.T) {
+	path := writeConfig(t, snowsqlConfig, 0o600)
+	if err := DeleteConnection(path, "staging"); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.TrimSuffix(snowsqlConfig, "\n# staging is a copy of prod\n[connections.staging]\naccountname = staging\n")
+	if got := readConfig(t, path); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDeleteDefaultConnection(t *testing.T) {
+	path := writeConfig(t, snowsqlConfig, 0o600)
+	if err := DeleteConnection(path, "default"); err == nil {
+		t.Error("deleting the default connection inherited by prod succeeded")
+	}
+	if got := readConfig(t, path); got != snowsqlConfig {
+		t.Errorf("the file changed\n%s", got)
+	}
+
+	// the sections of the other snowsql files inherit it too
+	other := filepath.Join(t.TempDir(), "snowsql.cnf")
+	if err := os.WriteFile(other, []byte("[connections]\naccountname = shared\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteConnection(other, "default"); err == nil {
+		t.Error("deleting the default connection inherited by the home file succeeded")
+	}
+
+	alone := "[options]\nlog_level = DEBUG\n\n[connections]\naccountname = shared\n"
+	path = writeConfig(t, alone, 0o600)
+	if err := DeleteConnection(path, "default"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readConfig(t, path), "[options]\nlog_level = DEBUG\n"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Stages interface {
+	Show(ctx context.Context, opts *ShowStageOptions) ([]sdk.Stage, error)
+	Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropStageOptions) error
+}
+
+type stages struct {
+	client *Client
+}
+
+type ShowStageOptions struct {
+	Database *sdk.AccountObjectIdentifier
+	Schema   *sdk.DatabaseObjectIdentifier
+}
+
+func (c *stages) Show(ctx context.Context, opts *ShowStageOptions) ([]sdk.Stage, error) {
+	request := sdk.NewShowStageRequest()
+	if opts != nil {
+		if in := showIn(opts.Database, opts.Schema); in != nil {
+			request.WithIn(in)
+		}
+	}
+
+	return c.client.SDKClient.Stages.Show(ctx, request)
+}
+
+type DropStageOptions struct {
+	IfExists bool
+}
+
+func (c *stages) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropStageOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropStageOptions{}
+	}
+
+	query, err := newStatement("DROP", "STAGE").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
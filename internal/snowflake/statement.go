@@ -0,0 +1,166 @@
+package snowflake
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+// keywordValue matches the values written as keywords, such as instance
+// families and compute pool state actions
+var keywordValue = regexp.MustCompile(`^[A-Z][A-Z0-9_]*( [A-Z][A-Z0-9_]*)*$`)
+
+// statement builds a SQL statement clause by clause, the values are quoted
+// by the clause adding them and the first invalid one is returned by build
+type statement struct {
+	// kind is the start of the statement naming it in errors, ALTER SERVICE
+	kind    string
+	clauses []string
+	// set is the number of clauses before SET, properties must follow it
+	set int
+	err error
+}
+
+func newStatement(keywords ...string) *statement {
+	return &statement{kind: strings.Join(keywords, " "), clauses: slices.Clone(keywords), set: -1}
+}
+
+// fail keeps the first error of the statement
+func (s *statement) fail(format string, args ...any) *statement {
+	if s.err == nil {
+		s.err = fmt.Errorf(format, args...)
+	}
+	return s
+}
+
+// keyword appends keywords as is, they never come from user input
+func (s *statement) keyword(keywords ...string) *statement {
+	s.clauses = append(s.clauses, keywords...)
+	return s
+}
+
+func (s *statement) keywordIf(condition bool, keywords ...string) *statement {
+	if condition {
+		s.keyword(keywords...)
+	}
+	return s
+}
+
+// identifier appends the quoted identifier, which must name every part
+func (s *statement) identifier(id sdk.ObjectIdentifier) *statement {
+	if id == nil {
+		return s.fail("%s without an identifier", s.kind)
+	}
+	parts, ok := identifierParts(id)
+	if ok && len(parts) == 0 {
+		return s.fail("%s without an identifier", s.kind)
+	}
+	if slices.Contains(parts, "") {
+		return s.fail("%s with an empty name in %s", s.kind, FullyQualifiedName(id))
+	}
+	s.clauses = append(s.clauses, FullyQualifiedName(id))
+	return s
+}
+
+// in narrows a SHOW statement to the objects in the container of the kind,
+// `IN SCHEMA "DB"."SCHEMA"`
+func (s *statement) in(kind string, id sdk.ObjectIdentifier) *statement {
+	return s.keyword("IN", kind).identifier(id)
+}
+
+// setProperties starts the properties an ALTER statement sets, build fails
+// when none follow
+func (s *statement) setProperties() *statement {
+	s.set = len(s.clauses)
+	return s.keyword("SET")
+}
+
+func (s *statement) property(name string, value string) *statement {
+	s.clauses = append(s.clauses, name+" = "+value)
+	return s
+}
+
+func (s *statement) intProperty(name string, value *int) *statement {
+	if value == nil {
+		return s
+	}
+	return s.property(name, strconv.Itoa(*value))
+}
+
+func (s *statement) boolProperty(name string, value *bool) *statement {
+	if value == nil {
+		return s
+	}
+	return s.property(name, strings.ToUpper(strconv.FormatBool(*value)))
+}
+
+// stringProperty sets a single quoted string literal such as a comment
+func (s *statement) stringProperty(name string, value *string) *statement {
+	if value == nil {
+		return s
+	}
+	return s.property(name, QuoteString(*value))
+}
+
+// keywordProperty sets a value written as a keyword, `INSTANCE_FAMILY =
+// CPU_X64_XS`, rejecting anything else
+func (s *statement) keywordProperty(name string, value string) *statement {
+	if !keywordValue.MatchString(value) {
+		return s.fail("%s with an invalid %s %q", s.kind, name, value)
+	}
+	return s.property(name, value)
+}
+
+// identifierProperty sets an identifier, skipped when nil
+func (s *statement) identifierProperty(name string, id *sdk.AccountObjectIdentifier) *statement {
+	if id == nil {
+		return s
+	}
+	s.keyword(name, "=")
+	return s.identifier(id)
+}
+
+// identifiersProperty sets a list of identifiers, `NAME = ("A", "B")`,
+// skipped when empty
+func (s *statement) identifiersProperty(name string, ids []sdk.AccountObjectIdentifier) *statement {
+	if len(ids) == 0 {
+		return s
+	}
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		if id.Name() == "" {
+			return s.fail("%s with an empty name in %s", s.kind, name)
+		}
+		quoted[i] = FullyQualifiedName(id)
+	}
+	return s.property(name, "("+strings.Join(quoted, ", ")+")")
+}
+
+// json appends the value as a $$ quoted json document, which snowflake
+// reads as yaml for specifications and manifests
+func (s *statement) json(value any) *statement {
+	document, err := jsonMarshal(value)
+	if err != nil {
+		return s.fail("encoding document of %s %w", s.kind, err)
+	}
+	if document == "null" {
+		return s.fail("%s without a document", s.kind)
+	}
+	s.clauses = append(s.clauses, dollarQuote(document))
+	return s
+}
+
+// build returns the statement or the first error found while building it
+func (s *statement) build() (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.set >= 0 && len(s.clauses) == s.set+1 {
+		return "", fmt.Errorf("%s without properties to set", s.kind)
+	}
+	return strings.Join(s.clauses, " "), nil
+}
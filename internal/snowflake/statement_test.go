@@ -0,0 +1,377 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the statements")
+
+// recorder is a database connector keeping the statements sent to it, the
+// queries return no rows
+type recorder struct {
+	statements []string
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return &recorderConn{r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return nil }
+
+type recorderConn struct {
+	recorder *recorder
+}
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not recorded")
+}
+func (c *recorderConn) Close() error { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not recorded")
+}
+
+func (c *recorderConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.recorder.statements = append(c.recorder.statements, query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recorderConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.recorder.statements = append(c.recorder.statements, query)
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+// newRecordingClient returns a client sending its statements to the recorder
+func newRecordingClient(r *recorder) *Client {
+	client := &Client{SDKClient: sdk.NewClientFromDB(sql.OpenDB(r))}
+	client.initialize()
+	return client
+}
+
+func TestStatements(t *testing.T) {
+	account := sdk.NewAccountObjectIdentifier("My Pool")
+	database := sdk.NewAccountObjectIdentifier("DB")
+	schema := sdk.NewDatabaseObjectIdentifier("DB", "my schema")
+	object := sdk.NewSchemaObjectIdentifier("DB", "SCH", `it's "a" name`)
+	warehouse := sdk.NewAccountObjectIdentifier("WH")
+	integrations := []sdk.AccountObjectIdentifier{sdk.NewAccountObjectIdentifier("EAI_A"), sdk.NewAccountObjectIdentifier("eai b")}
+	manifest := &ServiceManifest{}
+	listing := &ListingManifest{Title: "Title with $$ and 'quotes'"}
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, client *Client) error
+	}{
+		{"create_compute_pool", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.Create(ctx, account, &CreateComputePoolOptions{
+				IfNotExists:        true,
+				Application:        &database,
+				MinNodes:           1,
+				MaxNodes:           3,
+				InstanceFamily:     CPU_X64_XS,
+				AutoResume:         sdk.Bool(true),
+				InitiallySuspended: sdk.Bool(false),
+				AutoSuspendSecs:    sdk.Int(600),
+				Comment:            sdk.String("it's a pool"),
+			})
+		}},
+		{"alter_compute_pool", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.Alter(ctx, account, &AlterComputePoolOptions{
+				IfExists:        true,
+				MinNodes:        sdk.Int(2),
+				MaxNodes:        sdk.Int(4),
+				AutoResume:      sdk.Bool(false),
+				AutoSuspendSecs: sdk.Int(60),
+				Comment:         sdk.String(`back\slash`),
+			})
+		}},
+		{"alter_compute_pool_min_nodes", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.Alter(ctx, account, &AlterComputePoolOptions{MinNodes: sdk.Int(1)})
+		}},
+		{"alter_compute_pool_state", func(ctx context.Context, client *Client) error {
+			if err := client.ComputePools.AlterState(ctx, account, &AlterComputePoolStateOptions{StateAction: ComputePoolStateActionSuspend}); err != nil {
+				return err
+			}
+			if err := client.ComputePools.AlterState(ctx, account, &AlterComputePoolStateOptions{IfExists: true, StateAction: ComputePoolStateActionResume}); err != nil {
+				return err
+			}
+			return client.ComputePools.AlterState(ctx, account, &AlterComputePoolStateOptions{StateAction: ComputePoolStateActionStopAll})
+		}},
+		{"drop_compute_pool", func(ctx context.Context, client *Client) error {
+			if err := client.ComputePools.Drop(ctx, account, nil); err != nil {
+				return err
+			}
+			return client.ComputePools.Drop(ctx, account, &DropComputePoolOptions{IfExists: true})
+		}},
+		{"show_compute_pools", func(ctx context.Context, client *Client) error {
+			_, err := client.ComputePools.Show(ctx)
+			return err
+		}},
+		{"describe_compute_pool", func(ctx context.Context, client *Client) error {
+			_, err := client.ComputePools.Describe(ctx, account)
+			return err
+		}},
+		{"create_service", func(ctx context.Context, client *Client) error {
+			return client.Services.Create(ctx, object, &CreateServiceOptions{
+				IfNotExists:                true,
+				ComputePool:                account,
+				ServiceManifest:            manifest,
+				ExternalAccessIntegrations: integrations,
+				AutoResume:                 true,
+				MinInstances:               1,
+				MaxInstances:               2,
+				QueryWarehouse:             warehouse,
+				Comment:                    "it's a service",
+			})
+		}},
+		{"create_service_minimal", func(ctx context.Context, client *Client) error {
+			return client.Services.Create(ctx, object, &CreateServiceOptions{ComputePool: account, ServiceManifest: manifest})
+		}},
+		{"alter_service", func(ctx context.Context, client *Client) error {
+			return client.Services.Alter(ctx, object, &AlterServiceOptions{
+				IfExists:                  true,
+				MinInstances:              sdk.Int(1),
+				MaxInstances:              sdk.Int(3),
+				QueryWarehouse:            &warehouse,
+				AutoResume:                sdk.Bool(true),
+				ExternalAccessIntegration: integrations,
+				Comment:                   sdk.String("$$"),
+			})
+		}},
+		{"drop_service", func(ctx context.Context, client *Client) error {
+			return client.Services.Drop(ctx, object, &DropServiceOptions{IfExists: true})
+		}},
+		{"show_services", func(ctx context.Context, client *Client) error {
+			for _, opts := range []*ShowServiceOptions{nil, {Database: &database}, {Schema: &schema}, {ComputePool: &account}} {
+				if _, err := client.Services.Show(ctx, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"describe_service", func(ctx context.Context, client *Client) error {
+			_, err := client.Services.Describe(ctx, object)
+			return err
+		}},
+		{"service_logs", func(ctx context.Context, client *Client) error {
+			_, err := client.Services.Logs(ctx, object, &ServiceLogsOptions{InstanceId: 0, ContainerName: "main's"})
+			return err
+		}},
+		{"show_service_instances", func(ctx context.Context, client *Client) error {
+			_, err := client.ServiceInstances.Show(ctx, &object)
+			return err
+		}},
+		{"show_service_containers", func(ctx context.Context, client *Client) error {
+			_, err := client.ServiceContainers.Show(ctx, &object)
+			return err
+		}},
+		{"show_endpoints", func(ctx context.Context, client *Client) error {
+			_, err := client.Endpoints.Show(ctx, &object)
+			return err
+		}},
+		{"create_image_repository", func(ctx context.Context, client *Client) error {
+			return client.ImageRepositories.Create(ctx, object, &CreateImageRepositoryOptions{IfNotExists: true})
+		}},
+		{"show_image_repositories", func(ctx context.Context, client *Client) error {
+			for _, opts := range []*ShowImageRepositoryOptions{nil, {Database: &database}, {Schema: &schema}} {
+				if _, err := client.ImageRepositories.Show(ctx, opts); err != nil {
+					return err
+				}
+			}
+			_, err := client.ImageRepositories.ShowImages(ctx, object)
+			return err
+		}},
+		{"drop_image_repository", func(ctx context.Context, client *Client) error {
+			return client.ImageRepositories.Drop(ctx, object, &DropImageRepositoryOptions{IfExists: true})
+		}},
+		{"create_listing", func(ctx context.Context, client *Client) error {
+			return client.Listings.Create(ctx, account, &CreateListingOptions{
+				ListingManifest:    listing,
+				IfNotExists:        true,
+				ApplicationPackage: &database,
+				Publish:            true,
+			})
+		}},
+		{"alter_listing", func(ctx context.Context, client *Client) error {
+			return client.Listings.Alter(ctx, account, &AlterListingOptions{ListingManifest: listing, IfExists: true, Review: true})
+		}},
+		{"drop_listing", func(ctx context.Context, client *Client) error {
+			return client.Listings.Drop(ctx, account, &DropListingOptions{IfExists: true})
+		}},
+		{"show_listings", func(ctx context.Context, client *Client) error {
+			if _, err := client.Listings.Show(ctx); err != nil {
+				return err
+			}
+			_, err := client.Listings.Describe(ctx, account)
+			return err
+		}},
+		{"show_secrets", func(ctx context.Context, client *Client) error {
+			for _, opts := range []*ShowSecretsOptions{nil, {Database: &database}, {Schema: &schema}, {Application: &database}, {ApplicationPackage: &database}} {
+				if _, err := client.Secrets.Show(ctx, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"drop_secret", func(ctx context.Context, client *Client) error {
+			return client.Secrets.Drop(ctx, &DropSecretsOptions{IfExists: true, Secret: &object})
+		}},
+		{"show_snapshots", func(ctx context.Context, client *Client) error {
+			for _, opts := range []*ShowSnapshotOptions{nil, {Database: &database}, {Schema: &schema}} {
+				if _, err := client.Snapshots.Show(ctx, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"drop_snapshot", func(ctx context.Context, client *Client) error {
+			return client.Snapshots.Drop(ctx, &DropSnapshotOptions{Snapshot: &object})
+		}},
+		{"show_release_directives", func(ctx context.Context, client *Client) error {
+			_, err := client.ReleaseDirectives.Show(ctx, database)
+			return err
+		}},
+		{"show_versions", func(ctx context.Context, client *Client) error {
+			_, err := client.ApplicationPackageVersions.Show(ctx, database)
+			return err
+		}},
+		{"put_file", func(ctx context.Context, client *Client) error {
+			return client.Files.Put(ctx, object, &PutFileOptions{SourcePath: "/tmp/it's.yaml", DestinationPath: "specs", Overwrite: true})
+		}},
+		{"use", func(ctx context.Context, client *Client) error {
+			if err := client.Sessions.UseRole(ctx, sdk.NewAccountObjectIdentifier("SYSADMIN")); err != nil {
+				return err
+			}
+			if err := client.Sessions.UseWarehouse(ctx, warehouse); err != nil {
+				return err
+			}
+			if err := client.Sessions.UseDatabase(ctx, database); err != nil {
+				return err
+			}
+			return client.Sessions.UseSchema(ctx, schema)
+		}},
+		{"show_sdk_objects", func(ctx context.Context, client *Client) error {
+			var errs []error
+			show := func(_ any, err error) { errs = append(errs, err) }
+			show(client.Roles.Show(ctx))
+			show(client.Users.Show(ctx))
+			show(client.Warehouses.Show(ctx))
+			show(client.Databases.Show(ctx))
+			show(client.Schemas.Show(ctx, &ShowSchemaOptions{Database: &database}))
+			show(client.Tables.Show(ctx, &ShowTableOptions{Schema: &schema}))
+			show(client.Views.Show(ctx, &ShowViewOptions{Database: &database}))
+			show(client.Stages.Show(ctx, &ShowStageOptions{Schema: &schema}))
+			show(client.Grants.Show(ctx, &ShowGrantOptions{To: &database}))
+			show(client.Applications.Show(ctx))
+			show(client.ApplicationPackages.Show(ctx))
+			show(client.NetworkRules.Show(ctx, &ShowNetworkRuleOptions{Schema: &schema}))
+			show(client.NetworkPolicies.Show(ctx))
+			show(client.Procedures.Show(ctx, &ShowProcedureOptions{Schema: &schema}))
+			show(client.Streamlits.Show(ctx, &ShowStreamlitOptions{Database: &database}))
+			show(client.SecurityIntegrations.Show(ctx))
+			return errors.Join(errs...)
+		}},
+		{"drop_sdk_objects", func(ctx context.Context, client *Client) error {
+			return errors.Join(
+				client.Warehouses.Drop(ctx, warehouse, &DropWarehouseOptions{IfExists: true}),
+				client.Databases.Drop(ctx, database, &DropDatabaseOptions{IfExists: true}),
+				client.Schemas.Drop(ctx, schema, &DropSchemaOptions{IfExists: true}),
+				client.Tables.Drop(ctx, object, &DropTableOptions{IfExists: true}),
+				client.Stages.Drop(ctx, object, &DropStageOptions{IfExists: true}),
+				client.SecurityIntegrations.Drop(ctx, account, &DropSecurityIntegrationOptions{IfExists: true}),
+			)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &recorder{}
+			err := test.call(context.Background(), newRecordingClient(r))
+			// describe and logs read a single row which the recorder has not
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				t.Fatal(err)
+			}
+			compareGolden(t, test.name, strings.Join(r.statements, "\n")+"\n")
+		})
+	}
+}
+
+func compareGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file, run go test -update %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("statements of %s differ from %s\ngot:\n%s\nwant:\n%s", name, path, got, want)
+	}
+}
+
+func TestStatementErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, client *Client) error
+		want string
+	}{
+		{"nil identifier", func(ctx context.Context, client *Client) error {
+			return client.Secrets.Drop(ctx, &DropSecretsOptions{})
+		}, "DROP SECRET without an identifier"},
+		{"empty name", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.Drop(ctx, sdk.NewAccountObjectIdentifier(""), nil)
+		}, "DROP COMPUTE POOL with an empty name"},
+		{"set without properties", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.Alter(ctx, sdk.NewAccountObjectIdentifier("POOL"), nil)
+		}, "ALTER COMPUTE POOL without properties to set"},
+		{"service set without properties", func(ctx context.Context, client *Client) error {
+			return client.Services.Alter(ctx, sdk.NewSchemaObjectIdentifier("DB", "SCH", "SVC"), &AlterServiceOptions{IfExists: true})
+		}, "ALTER SERVICE without properties to set"},
+		{"bad keyword value", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.Create(ctx, sdk.NewAccountObjectIdentifier("POOL"), &CreateComputePoolOptions{InstanceFamily: "CPU; DROP DATABASE X"})
+		}, "CREATE COMPUTE POOL with an invalid INSTANCE_FAMILY"},
+		{"bad state action", func(ctx context.Context, client *Client) error {
+			return client.ComputePools.AlterState(ctx, sdk.NewAccountObjectIdentifier("POOL"), &AlterComputePoolStateOptions{StateAction: "suspend"})
+		}, "invalid compute pool state action"},
+		{"service without specification", func(ctx context.Context, client *Client) error {
+			return client.Services.Create(ctx, sdk.NewSchemaObjectIdentifier("DB", "SCH", "SVC"), &CreateServiceOptions{})
+		}, "without a specification"},
+		{"listing without manifest", func(ctx context.Context, client *Client) error {
+			return client.Listings.Create(ctx, sdk.NewAccountObjectIdentifier("LISTING"), nil)
+		}, "CREATE EXTERNAL LISTING without a document"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &recorder{}
+			err := test.call(context.Background(), newRecordingClient(r))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("got error %v, want %q", err, test.want)
+			}
+			if len(r.statements) > 0 {
+				t.Errorf("statements sent despite the error %q", r.statements)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Streamlits interface {
+	Show(ctx context.Context, opts *ShowStreamlitOptions) ([]sdk.Streamlit, error)
+}
+
+type streamlits struct {
+	client *Client
+}
+
+type ShowStreamlitOptions struct {
+	Database *sdk.AccountObjectIdentifier
+	Schema   *sdk.DatabaseObjectIdentifier
+}
+
+func (c *streamlits) Show(ctx context.Context, opts *ShowStreamlitOptions) ([]sdk.Streamlit, error) {
+	request := sdk.NewShowStreamlitRequest()
+	if opts != nil {
+		if in := showIn(opts.Database, opts.Schema); in != nil {
+			request.WithIn(in)
+		}
+	}
+
+	return c.client.SDKClient.Streamlits.Show(ctx, request)
+}
@@ -0,0 +1,56 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Tables interface {
+	Show(ctx context.Context, opts *ShowTableOptions) ([]sdk.Table, error)
+	Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropTableOptions) error
+}
+
+type tables struct {
+	client *Client
+}
+
+type ShowTableOptions struct {
+	Database *sdk.AccountObjectIdentifier
+	Schema   *sdk.DatabaseObjectIdentifier
+}
+
+func (c *tables) Show(ctx context.Context, opts *ShowTableOptions) ([]sdk.Table, error) {
+	request := sdk.NewShowTableRequest()
+	if opts != nil {
+		if in := showIn(opts.Database, opts.Schema); in != nil {
+			request.WithIn(in)
+		}
+	}
+
+	return c.client.SDKClient.Tables.Show(ctx, request)
+}
+
+type DropTableOptions struct {
+	IfExists bool
+}
+
+func (c *tables) Drop(ctx context.Context, id sdk.SchemaObjectIdentifier, opts *DropTableOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropTableOptions{}
+	}
+
+	query, err := newStatement("DROP", "TABLE").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}
@@ -0,0 +1,19 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Users interface {
+	Show(ctx context.Context) ([]sdk.User, error)
+}
+
+type users struct {
+	client *Client
+}
+
+func (c *users) Show(ctx context.Context) ([]sdk.User, error) {
+	return c.client.SDKClient.Users.Show(ctx, &sdk.ShowUserOptions{})
+}
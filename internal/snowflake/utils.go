@@ -1,23 +1,39 @@
 package snowflake
 
 import (
-	"bytes"
 	"encoding/json"
-	"text/template"
+	"strings"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
 )
 
-func jsonMarshal(data interface{}) string {
-	message, _ := json.Marshal(data)
-	return string(message)
+func jsonMarshal(data interface{}) (string, error) {
+	message, err := json.Marshal(data)
+	return string(message), err
+}
+
+// QuoteString returns a single quoted string literal, escaping the quotes
+// and backslashes of the value
+func QuoteString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(value) + "'"
+}
+
+// dollarQuote returns a $$ quoted literal that keeps the value as is, which
+// reads better for manifests, or a single quoted one when it contains $$
+func dollarQuote(value string) string {
+	if strings.Contains(value, "$$") || strings.HasSuffix(value, "$") {
+		return QuoteString(value)
+	}
+	return "$$" + value + "$$"
 }
 
-func templateToQuery(stmtTemplate string, data interface{}) string {
-	var stmt bytes.Buffer
-	templ := template.New("query")
-	templ = templ.Funcs(template.FuncMap{
-		"json": jsonMarshal,
-	})
-	templ = template.Must(templ.Parse(stmtTemplate))
-	templ.Execute(&stmt, data)
-	return stmt.String()
+// showIn narrows a SHOW statement to the most specific scope given
+func showIn(database *sdk.AccountObjectIdentifier, schema *sdk.DatabaseObjectIdentifier) *sdk.In {
+	if schema != nil {
+		return &sdk.In{Schema: *schema}
+	}
+	if database != nil {
+		return &sdk.In{Database: *database}
+	}
+	return nil
 }
@@ -0,0 +1,86 @@
+package snowflake
+
+import (
+	"strings"
+	"testing"
+)
+
+// unquoteString reads a single quoted literal as snowflake does
+func unquoteString(t *testing.T, literal string) string {
+	t.Helper()
+	if len(literal) < 2 || literal[0] != '\'' || literal[len(literal)-1] != '\'' {
+		t.Fatalf("%s is not a single quoted literal", literal)
+	}
+
+	var value strings.Builder
+	body := literal[1 : len(literal)-1]
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '\'':
+			if i+1 >= len(body) || body[i+1] != '\'' {
+				t.Fatalf("%s ends at an unescaped quote", literal)
+			}
+			i++
+		}
+		if i >= len(body) {
+			t.Fatalf("%s ends with an escape", literal)
+		}
+		value.WriteByte(body[i])
+	}
+	return value.String()
+}
+
+// unquoteDollar reads a $$ quoted literal, or a single quoted one
+func unquoteDollar(t *testing.T, literal string) string {
+	t.Helper()
+	if strings.HasPrefix(literal, "$$") {
+		body, ok := strings.CutSuffix(literal[2:], "$$")
+		if !ok || strings.Contains(body, "$$") || len(literal) < 4 {
+			t.Fatalf("%s is not a $$ quoted literal", literal)
+		}
+		return body
+	}
+	return unquoteString(t, literal)
+}
+
+func TestQuoteStringRoundTrip(t *testing.T) {
+	for _, value := range append(nastyNames, "", `"`, `\'`, `''`, "line\nbreak", `ends\`) {
+		t.Run(value, func(t *testing.T) {
+			if got := unquoteString(t, QuoteString(value)); got != value {
+				t.Errorf("got %q, want %q", got, value)
+			}
+		})
+	}
+}
+
+func TestDollarQuoteRoundTrip(t *testing.T) {
+	for _, value := range append(nastyNames, "", "$", "$$", "a$$b", "spec:\n  image: '$x'", "$$$") {
+		t.Run(value, func(t *testing.T) {
+			if got := unquoteDollar(t, dollarQuote(value)); got != value {
+				t.Errorf("got %q, want %q", got, value)
+			}
+		})
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"PLAIN", `"PLAIN"`},
+		{"MixedCase", `"MixedCase"`},
+		{`double"quote`, `"double""quote"`},
+		{`"`, `""""`},
+		{"dotted.name", `"dotted.name"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := QuoteIdentifier(test.name); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
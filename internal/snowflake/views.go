@@ -0,0 +1,31 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Views interface {
+	Show(ctx context.Context, opts *ShowViewOptions) ([]sdk.View, error)
+}
+
+type views struct {
+	client *Client
+}
+
+type ShowViewOptions struct {
+	Database *sdk.AccountObjectIdentifier
+	Schema   *sdk.DatabaseObjectIdentifier
+}
+
+func (c *views) Show(ctx context.Context, opts *ShowViewOptions) ([]sdk.View, error) {
+	request := sdk.NewShowViewRequest()
+	if opts != nil {
+		if in := showIn(opts.Database, opts.Schema); in != nil {
+			request.WithIn(in)
+		}
+	}
+
+	return c.client.SDKClient.Views.Show(ctx, request)
+}
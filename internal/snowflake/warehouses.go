@@ -0,0 +1,44 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/Snowflake-Labs/terraform-provider-snowflake/pkg/sdk"
+)
+
+type Warehouses interface {
+	Show(ctx context.Context) ([]sdk.Warehouse, error)
+	Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropWarehouseOptions) error
+}
+
+type warehouses struct {
+	client *Client
+}
+
+func (c *warehouses) Show(ctx context.Context) ([]sdk.Warehouse, error) {
+	return c.client.SDKClient.Warehouses.Show(ctx, &sdk.ShowWarehouseOptions{})
+}
+
+type DropWarehouseOptions struct {
+	IfExists bool
+}
+
+func (c *warehouses) Drop(ctx context.Context, id sdk.AccountObjectIdentifier, opts *DropWarehouseOptions) error {
+	if err := c.client.safeguards.CheckDrop(id); err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &DropWarehouseOptions{}
+	}
+
+	query, err := newStatement("DROP", "WAREHOUSE").
+		keywordIf(opts.IfExists, "IF EXISTS").
+		identifier(id).
+		build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.SDKClient.GetConn().ExecContext(ctx, query)
+	return err
+}